@@ -0,0 +1,111 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	userpb "github.com/susilo001/simple-wallet-system/user/proto/user/v1"
+)
+
+func registerAccountRoutes(r *gin.Engine, userClient userpb.UserServiceClient) {
+	// Signs up a new user. The user service emails a verification link, and
+	// the user cannot log in until it has been followed.
+	r.POST("/users", func(c *gin.Context) {
+		var req struct {
+			Name     string `json:"name" binding:"required"`
+			Email    string `json:"email" binding:"required"`
+			Password string `json:"password" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		resp, err := userClient.CreateUser(rpcContext(c), &userpb.CreateUserRequest{
+			Name:     req.Name,
+			Email:    req.Email,
+			Password: req.Password,
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+		c.JSON(http.StatusCreated, gin.H{"message": resp.Message})
+	})
+
+	// Sends a new verification link. The response is the same whether or
+	// not the email is registered.
+	r.POST("/auth/email-verification", func(c *gin.Context) {
+		var req struct {
+			Email string `json:"email" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		resp, err := userClient.SendEmailVerification(rpcContext(c), &userpb.SendEmailVerificationRequest{
+			Email: req.Email,
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+		c.JSON(http.StatusAccepted, gin.H{"message": resp.Message})
+	})
+
+	// The link in the verification email points here.
+	r.GET("/auth/email-verification", func(c *gin.Context) {
+		token := c.Query("token")
+		if token == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "token is required"})
+			return
+		}
+		resp, err := userClient.VerifyEmail(rpcContext(c), &userpb.VerifyEmailRequest{
+			Token: token,
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"message": resp.Message})
+	})
+
+	// Emails a password reset link. The response is the same whether or not
+	// the email is registered.
+	r.POST("/auth/password-reset", func(c *gin.Context) {
+		var req struct {
+			Email string `json:"email" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		resp, err := userClient.RequestPasswordReset(rpcContext(c), &userpb.RequestPasswordResetRequest{
+			Email: req.Email,
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+		c.JSON(http.StatusAccepted, gin.H{"message": resp.Message})
+	})
+
+	r.POST("/auth/password-reset/confirm", func(c *gin.Context) {
+		var req struct {
+			Token       string `json:"token" binding:"required"`
+			NewPassword string `json:"new_password" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		resp, err := userClient.ResetPassword(rpcContext(c), &userpb.ResetPasswordRequest{
+			Token:       req.Token,
+			NewPassword: req.NewPassword,
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"message": resp.Message})
+	})
+}
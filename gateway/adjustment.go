@@ -0,0 +1,106 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	walletpb "github.com/susilo001/simple-wallet-system/wallet/proto/wallet/v1"
+)
+
+func registerAdjustmentRoutes(r *gin.Engine, walletClient walletpb.WalletServiceClient) {
+	// A positive amount credits the wallet, a negative one debits it. The
+	// adjustment stays pending until another admin approves it.
+	r.POST("/wallets/:id/adjustments", func(c *gin.Context) {
+		walletId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		var req struct {
+			Amount float64 `json:"amount" binding:"required"`
+			Reason string  `json:"reason" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		resp, err := walletClient.ProposeBalanceAdjustment(rpcContext(c), &walletpb.ProposeBalanceAdjustmentRequest{
+			WalletId: int32(walletId),
+			Amount:   req.Amount,
+			Reason:   req.Reason,
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+		c.JSON(http.StatusCreated, gin.H{"adjustment": resp})
+	})
+
+	r.GET("/adjustments", func(c *gin.Context) {
+		pageSize, pageToken, ok := pageQuery(c)
+		if !ok {
+			return
+		}
+		resp, err := walletClient.ListBalanceAdjustments(rpcContext(c), &walletpb.ListBalanceAdjustmentsRequest{
+			Status:    c.Query("status"),
+			PageSize:  pageSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"adjustments": resp.Adjustments, "page": resp.Page})
+	})
+
+	r.POST("/adjustments/:id/approve", reviewAdjustmentHandler(walletClient, true))
+	r.POST("/adjustments/:id/reject", reviewAdjustmentHandler(walletClient, false))
+
+	r.PUT("/wallets/:id/owner", func(c *gin.Context) {
+		walletId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		var req struct {
+			UserId int    `json:"user_id" binding:"required"`
+			Reason string `json:"reason" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		resp, err := walletClient.TransferWalletOwnership(rpcContext(c), &walletpb.TransferWalletOwnershipRequest{
+			WalletId:  int32(walletId),
+			NewUserId: int32(req.UserId),
+			Reason:    req.Reason,
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"wallet": resp})
+	})
+}
+
+func reviewAdjustmentHandler(walletClient walletpb.WalletServiceClient, approve bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		adjustmentId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		resp, err := walletClient.ReviewBalanceAdjustment(rpcContext(c), &walletpb.ReviewBalanceAdjustmentRequest{
+			AdjustmentId: int32(adjustmentId),
+			Approve:      approve,
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"adjustment": resp})
+	}
+}
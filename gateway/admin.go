@@ -0,0 +1,69 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	userpb "github.com/susilo001/simple-wallet-system/user/proto/user/v1"
+)
+
+// registerAdminRoutes registers the user management routes. They are
+// restricted to admins by routeRoles.
+func registerAdminRoutes(r *gin.Engine, userClient userpb.UserServiceClient) {
+	r.GET("/admin/users", func(c *gin.Context) {
+		pageSize, pageToken, ok := pageQuery(c)
+		if !ok {
+			return
+		}
+		resp, err := userClient.GetUsers(rpcContext(c), &userpb.GetUsersRequest{
+			PageSize:  pageSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"users": resp.User, "page": resp.Page})
+	})
+
+	r.DELETE("/admin/users/:id", func(c *gin.Context) {
+		userId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		resp, err := userClient.DeleteUser(rpcContext(c), &userpb.DeleteUserRequest{Id: int32(userId)})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"message": resp.Message})
+	})
+
+	r.PUT("/admin/users/:id/role", func(c *gin.Context) {
+		userId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		var req struct {
+			Role string `json:"role" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		resp, err := userClient.AssignRole(rpcContext(c), &userpb.AssignRoleRequest{
+			Id:   int32(userId),
+			Role: req.Role,
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"user": resp})
+	})
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	userpb "github.com/susilo001/simple-wallet-system/user/proto/user/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// apiKeyHeader carries an API key for server-to-server integrations.
+const apiKeyHeader = "X-API-Key"
+
+// API key scopes, matching the user service.
+const (
+	scopeBalanceRead      = "balance:read"
+	scopeTransactionsRead = "transactions:read"
+	scopeTransfersCreate  = "transfers:create"
+)
+
+// routeScopes maps the routes callable with an API key to the scope the key
+// needs. Every other route, including managing API keys, needs a password
+// or an access token.
+var routeScopes = map[string]string{
+	"GET /wallets/:id":                 scopeBalanceRead,
+	"GET /wallets/:id/balance":         scopeBalanceRead,
+	"GET /wallets/:id/balance/history": scopeBalanceRead,
+	"GET /wallets/:id/limits":          scopeBalanceRead,
+	"GET /users/:id/transactions":      scopeTransactionsRead,
+	"GET /wallets/:id/statement":       scopeTransactionsRead,
+	"POST /wallets/:id/transfers":      scopeTransfersCreate,
+
+	"GET /v1/users/:id/wallets":                  scopeBalanceRead,
+	"GET /v1/wallets/:wallet_id":                 scopeBalanceRead,
+	"GET /v1/wallets/:wallet_id/balance":         scopeBalanceRead,
+	"GET /v1/wallets/:wallet_id/balance/history": scopeBalanceRead,
+	"GET /v1/wallets/:wallet_id/limits":          scopeBalanceRead,
+	"GET /v1/wallets/:wallet_id/transactions":    scopeTransactionsRead,
+	"GET /v1/wallets/:wallet_id/statement":       scopeTransactionsRead,
+	"POST /v1/wallets/:wallet_id/transfers":      scopeTransfersCreate,
+}
+
+// apiKeyAllows rejects requests made with an API key that lacks the
+// matched route's scope. The caller's role is checked as well afterwards.
+func apiKeyAllows(c *gin.Context) bool {
+	value, ok := c.Get(apiKeyKey)
+	if !ok {
+		return true
+	}
+	apiKey := value.(*userpb.APIKey)
+	route := c.Request.Method + " " + c.FullPath()
+	scope, ok := routeScopes[route]
+	if !ok {
+		abortWithError(c, http.StatusForbidden, fmt.Sprintf("%s cannot be called with an API key", route))
+		return false
+	}
+	for _, granted := range apiKey.GetScopes() {
+		if granted == scope {
+			return true
+		}
+	}
+	abortWithError(c, http.StatusForbidden, fmt.Sprintf("API key %s lacks the %s scope", apiKey.GetPrefix(), scope))
+	return false
+}
+
+func registerAPIKeyRoutes(r *gin.Engine, userClient userpb.UserServiceClient) {
+	// Creates a key. A key that may transfer more than the caller could with
+	// a password alone needs a second factor, since transfers made with it
+	// do not.
+	r.POST("/users/:id/api-keys", func(c *gin.Context) {
+		userId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		var req struct {
+			Name          string   `json:"name" binding:"required"`
+			Scopes        []string `json:"scopes" binding:"required,min=1"`
+			TransferLimit float64  `json:"transfer_limit"`
+			ExpiresAt     string   `json:"expires_at"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		createReq := &userpb.CreateAPIKeyRequest{
+			UserId:        int32(userId),
+			Name:          req.Name,
+			Scopes:        req.Scopes,
+			TransferLimit: req.TransferLimit,
+		}
+		if req.ExpiresAt != "" {
+			expiresAt, err := time.Parse(time.RFC3339, req.ExpiresAt)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid expires_at, expected RFC 3339"})
+				return
+			}
+			createReq.ExpiresAt = timestamppb.New(expiresAt)
+		}
+		if !requireSecondFactor(c, userClient, req.TransferLimit) {
+			return
+		}
+
+		resp, err := userClient.CreateAPIKey(rpcContext(c), createReq)
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+		c.JSON(http.StatusCreated, gin.H{"api_key": resp.ApiKey, "key": resp.Key})
+	})
+
+	r.GET("/users/:id/api-keys", func(c *gin.Context) {
+		userId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		pageSize, pageToken, ok := pageQuery(c)
+		if !ok {
+			return
+		}
+		resp, err := userClient.ListAPIKeys(rpcContext(c), &userpb.ListAPIKeysRequest{
+			UserId:    int32(userId),
+			PageSize:  pageSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"api_keys": resp.ApiKeys, "page": resp.Page})
+	})
+
+	r.DELETE("/users/:id/api-keys/:key_id", func(c *gin.Context) {
+		userId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		keyId, err := strconv.Atoi(c.Param("key_id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		resp, err := userClient.RevokeAPIKey(rpcContext(c), &userpb.RevokeAPIKeyRequest{
+			UserId: int32(userId),
+			Id:     int32(keyId),
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"api_key": resp})
+	})
+
+	// Issues a replacement key. The old key keeps working for a grace period
+	// so that the integration can switch over.
+	r.POST("/users/:id/api-keys/:key_id/rotate", func(c *gin.Context) {
+		userId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		keyId, err := strconv.Atoi(c.Param("key_id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		resp, err := userClient.RotateAPIKey(rpcContext(c), &userpb.RotateAPIKeyRequest{
+			UserId: int32(userId),
+			Id:     int32(keyId),
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+		c.JSON(http.StatusCreated, gin.H{"api_key": resp.ApiKey, "key": resp.Key})
+	})
+}
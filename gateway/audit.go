@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	userpb "github.com/susilo001/simple-wallet-system/user/proto/user/v1"
+	walletpb "github.com/susilo001/simple-wallet-system/wallet/proto/wallet/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func registerAuditRoutes(r *gin.Engine, userClient userpb.UserServiceClient, walletClient walletpb.WalletServiceClient) {
+	// Filters: actor, action, target, request_id, from and to (RFC 3339),
+	// after_sequence and limit.
+	r.GET("/audit/users", func(c *gin.Context) {
+		filter, err := auditQueryFrom(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		resp, err := userClient.ListAuditEntries(rpcContext(c), &userpb.ListAuditEntriesRequest{
+			Actor:         filter.actor,
+			Action:        filter.action,
+			Target:        filter.target,
+			RequestId:     filter.requestID,
+			From:          filter.from,
+			To:            filter.to,
+			AfterSequence: filter.afterSequence,
+			Limit:         filter.limit,
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"entries": resp.Entries})
+	})
+
+	r.GET("/audit/wallets", func(c *gin.Context) {
+		filter, err := auditQueryFrom(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		resp, err := walletClient.ListAuditEntries(rpcContext(c), &walletpb.ListAuditEntriesRequest{
+			Actor:         filter.actor,
+			Action:        filter.action,
+			Target:        filter.target,
+			RequestId:     filter.requestID,
+			From:          filter.from,
+			To:            filter.to,
+			AfterSequence: filter.afterSequence,
+			Limit:         filter.limit,
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"entries": resp.Entries})
+	})
+}
+
+type auditQuery struct {
+	actor, action, target, requestID string
+	from, to                         *timestamppb.Timestamp
+	afterSequence                    int64
+	limit                            int32
+}
+
+func auditQueryFrom(c *gin.Context) (auditQuery, error) {
+	q := auditQuery{
+		actor:     c.Query("actor"),
+		action:    c.Query("action"),
+		target:    c.Query("target"),
+		requestID: c.Query("request_id"),
+	}
+	for _, bound := range []struct {
+		name string
+		dst  **timestamppb.Timestamp
+	}{{"from", &q.from}, {"to", &q.to}} {
+		if value := c.Query(bound.name); value != "" {
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return auditQuery{}, fmt.Errorf("invalid %s %q, expected an RFC 3339 time", bound.name, value)
+			}
+			*bound.dst = timestamppb.New(t)
+		}
+	}
+	if value := c.Query("after_sequence"); value != "" {
+		afterSequence, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return auditQuery{}, fmt.Errorf("invalid after_sequence %q", value)
+		}
+		q.afterSequence = afterSequence
+	}
+	if value := c.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil {
+			return auditQuery{}, fmt.Errorf("invalid limit %q", value)
+		}
+		q.limit = int32(limit)
+	}
+	return q, nil
+}
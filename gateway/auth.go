@@ -0,0 +1,248 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	userpb "github.com/susilo001/simple-wallet-system/user/proto/user/v1"
+)
+
+// Roles are assigned to users by the user service.
+const (
+	roleAnonymous = "anonymous"
+	roleCustomer  = "customer"
+	roleSupport   = "support"
+	roleFinance   = "finance"
+	roleAdmin     = "admin"
+)
+
+// Context keys set by authMiddleware.
+const (
+	userIDKey = "user_id"
+	roleKey   = "role"
+	// sessionIDKey is only set for callers using an access token.
+	sessionIDKey = "session_id"
+	// apiKeyKey holds the *userpb.APIKey of callers using an API key.
+	apiKeyKey = "api_key"
+)
+
+var (
+	// everyone includes callers who have not logged in, for signing up and
+	// recovering an account.
+	everyone   = []string{roleAnonymous, roleCustomer, roleSupport, roleFinance, roleAdmin}
+	anyUser    = []string{roleCustomer, roleSupport, roleFinance, roleAdmin}
+	staffOnly  = []string{roleSupport, roleFinance, roleAdmin}
+	supportOps = []string{roleSupport, roleAdmin}
+	financeOps = []string{roleFinance, roleAdmin}
+	adminOnly  = []string{roleAdmin}
+	// Customers may only move money out of their own wallets; the wallet
+	// service checks ownership.
+	payments          = []string{roleCustomer, roleAdmin}
+	customerOrSupport = []string{roleCustomer, roleSupport, roleAdmin}
+)
+
+// routeRoles maps every route, as "METHOD /path", to the roles that may
+// call it. Routes not listed here are forbidden for everyone. The services
+// check the same roles again per RPC.
+var routeRoles = map[string][]string{
+	"POST /users":                       everyone,
+	"POST /auth/email-verification":     everyone,
+	"GET /auth/email-verification":      everyone,
+	"POST /auth/password-reset":         everyone,
+	"POST /auth/password-reset/confirm": everyone,
+	"POST /auth/login":                  everyone,
+	"POST /auth/refresh":                everyone,
+	"POST /auth/logout":                 anyUser,
+
+	"GET /users/:id":              anyUser,
+	"GET /users/:id/transactions": anyUser,
+	"POST /wallets/:id/topup":     payments,
+	"POST /wallets/:id/transfers": payments,
+
+	"POST /wallets/:id/payment-requests": payments,
+	"GET /wallets/:id/payment-requests":  anyUser,
+	"POST /wallets/:id/payment-links":    payments,
+	"GET /payment-requests/:id":          anyUser,
+	"POST /payment-requests/:id/accept":  payments,
+	"POST /payment-requests/:id/decline": payments,
+	"POST /payment-requests/:id/cancel":  customerOrSupport,
+	"GET /payment-links/:code":           anyUser,
+	"POST /payment-links/:code/pay":      payments,
+
+	"POST /batch-transfers":    payments,
+	"GET /batch-transfers/:id": anyUser,
+
+	"GET /wallets/:id":                 anyUser,
+	"GET /wallets/:id/statement":       anyUser,
+	"GET /wallets/:id/balance":         anyUser,
+	"GET /wallets/:id/balance/history": anyUser,
+	"GET /wallets/:id/balance/check":   staffOnly,
+	"GET /wallets/:id/limits":          anyUser,
+
+	"POST /users/:id/kyc":               anyUser,
+	"GET /users/:id/kyc":                anyUser,
+	"GET /kyc-submissions":              staffOnly,
+	"POST /kyc-submissions/:id/approve": supportOps,
+	"POST /kyc-submissions/:id/reject":  supportOps,
+
+	"POST /users/:id/2fa/totp":           anyUser,
+	"POST /users/:id/2fa/totp/confirm":   anyUser,
+	"DELETE /users/:id/2fa/totp":         anyUser,
+	"POST /users/:id/2fa/recovery-codes": anyUser,
+
+	"GET /users/:id/sessions":                anyUser,
+	"DELETE /users/:id/sessions":             anyUser,
+	"DELETE /users/:id/sessions/:session_id": anyUser,
+
+	"POST /users/:id/api-keys":                anyUser,
+	"GET /users/:id/api-keys":                 anyUser,
+	"DELETE /users/:id/api-keys/:key_id":      anyUser,
+	"POST /users/:id/api-keys/:key_id/rotate": anyUser,
+
+	"POST /reconciliations":    financeOps,
+	"GET /reconciliations/:id": financeOps,
+	"GET /adjustments":         financeOps,
+	"GET /audit/users":         supportOps,
+	"GET /audit/wallets":       financeOps,
+
+	"POST /wallets/:id/freeze":   customerOrSupport,
+	"POST /wallets/:id/unfreeze": supportOps,
+	"POST /wallets/:id/close":    adminOnly,
+
+	"GET /held-transfers":              staffOnly,
+	"POST /held-transfers/:id/release": supportOps,
+	"POST /held-transfers/:id/reject":  supportOps,
+	"GET /risk/metrics":                staffOnly,
+
+	"POST /wallets/:id/adjustments": adminOnly,
+	"POST /adjustments/:id/approve": adminOnly,
+	"POST /adjustments/:id/reject":  adminOnly,
+	"PUT /wallets/:id/owner":        adminOnly,
+	"GET /admin/users":              adminOnly,
+	"DELETE /admin/users/:id":       adminOnly,
+	"PUT /admin/users/:id/role":     adminOnly,
+
+	// The /v1 routes are added by registerRESTRoutes.
+	"GET /v1/openapi.json": everyone,
+}
+
+// authMiddleware identifies the caller from an API key, a bearer access
+// token issued by POST /auth/login, or HTTP basic credentials (email and
+// password), all checked by the user service. Requests without any are
+// anonymous.
+func authMiddleware(userClient userpb.UserServiceClient) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if key := c.GetHeader(apiKeyHeader); key != "" {
+			resp, err := userClient.AuthenticateAPIKey(rpcContext(c), &userpb.AuthenticateAPIKeyRequest{
+				Key: key,
+			})
+			if err != nil {
+				abortWithError(c, httpStatusFromError(err), backendErrorMessage(err))
+				return
+			}
+			setCaller(c, resp.User)
+			c.Set(apiKeyKey, resp.ApiKey)
+			c.Set(actorKey, fmt.Sprintf("user:%d/api-key:%d", resp.User.GetId(), resp.ApiKey.GetId()))
+			c.Next()
+			return
+		}
+
+		if accessToken, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer "); ok {
+			resp, err := userClient.AuthenticateAccessToken(rpcContext(c), &userpb.AuthenticateAccessTokenRequest{
+				AccessToken: accessToken,
+			})
+			if err != nil {
+				c.Header("WWW-Authenticate", `Bearer realm="wallet"`)
+				abortWithError(c, httpStatusFromError(err), backendErrorMessage(err))
+				return
+			}
+			c.Set(sessionIDKey, int(resp.SessionId))
+			setCaller(c, resp.User)
+			c.Next()
+			return
+		}
+
+		email, password, ok := c.Request.BasicAuth()
+		if !ok {
+			c.Set(roleKey, roleAnonymous)
+			c.Next()
+			return
+		}
+
+		resp, err := userClient.Authenticate(rpcContext(c), &userpb.AuthenticateRequest{
+			Email:    email,
+			Password: password,
+		})
+		if err != nil {
+			c.Header("WWW-Authenticate", `Basic realm="wallet"`)
+			abortWithError(c, httpStatusFromError(err), backendErrorMessage(err))
+			return
+		}
+		setCaller(c, resp.User)
+		c.Next()
+	}
+}
+
+func setCaller(c *gin.Context, user *userpb.User) {
+	c.Set(userIDKey, int(user.GetId()))
+	c.Set(roleKey, user.GetRole())
+	c.Set(actorKey, fmt.Sprintf("user:%d", user.GetId()))
+}
+
+// permissionMiddleware rejects requests whose caller's role may not call
+// the matched route.
+func permissionMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Unmatched paths are left to the router's 404.
+		if c.FullPath() == "" {
+			c.Next()
+			return
+		}
+		if !apiKeyAllows(c) {
+			return
+		}
+		role := c.GetString(roleKey)
+		for _, allowed := range routeRoles[c.Request.Method+" "+c.FullPath()] {
+			if allowed == role {
+				c.Next()
+				return
+			}
+		}
+		if role == roleAnonymous {
+			c.Header("WWW-Authenticate", `Basic realm="wallet"`)
+			abortWithError(c, http.StatusUnauthorized, "authentication required")
+			return
+		}
+		abortWithError(c, http.StatusForbidden, fmt.Sprintf("role %s may not call %s %s", role, c.Request.Method, c.FullPath()))
+	}
+}
+
+// The gateway's credentials for the backend services, matching
+// AuthBasicUsername and AuthBasicPassword in their config.
+const (
+	serviceUsername = "user"
+	servicePassword = "pass"
+)
+
+// serviceCredentials authenticates the gateway to a backend service with
+// HTTP basic credentials, which the service requires before it trusts the
+// caller the gateway passes on.
+type serviceCredentials struct {
+	username string
+	password string
+}
+
+func (s serviceCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	auth := base64.StdEncoding.EncodeToString([]byte(s.username + ":" + s.password))
+	return map[string]string{"authorization": "Basic " + auth}, nil
+}
+
+// RequireTransportSecurity is false because the services are reached over
+// the local network without TLS.
+func (s serviceCredentials) RequireTransportSecurity() bool {
+	return false
+}
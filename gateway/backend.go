@@ -0,0 +1,215 @@
+package main
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultRouteTimeout bounds the backend calls of routes without an entry
+// in routeTimeouts.
+const defaultRouteTimeout = 5 * time.Second
+
+// routeTimeouts maps routes, as "METHOD /path", that need longer than
+// defaultRouteTimeout to their deadline.
+var routeTimeouts = map[string]time.Duration{
+	"POST /batch-transfers":          30 * time.Second,
+	"POST /reconciliations":          60 * time.Second,
+	"GET /reconciliations/:id":       15 * time.Second,
+	"GET /wallets/:id/statement":     15 * time.Second,
+	"GET /wallets/:id/balance/check": 15 * time.Second,
+	"GET /audit/users":               15 * time.Second,
+	"GET /audit/wallets":             15 * time.Second,
+
+	"POST /v1/batch-transfers":                 30 * time.Second,
+	"POST /v1/reconciliations":                 60 * time.Second,
+	"GET /v1/reconciliations/:run_id":          15 * time.Second,
+	"GET /v1/wallets/:wallet_id/statement":     15 * time.Second,
+	"GET /v1/wallets/:wallet_id/balance/check": 15 * time.Second,
+	"GET /v1/audit/users":                      15 * time.Second,
+	"GET /v1/audit/wallets":                    15 * time.Second,
+}
+
+// deadlineMiddleware gives each request a deadline for its backend calls,
+// derived from the request's context so that they are also cancelled when
+// the client goes away. rpcContext passes it on to every call.
+func deadlineMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		timeout, ok := routeTimeouts[c.Request.Method+" "+c.FullPath()]
+		if !ok {
+			timeout = defaultRouteTimeout
+		}
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// idempotentMethods are the backend RPCs that only read, and so may be
+// retried without the risk of, say, transferring twice.
+var idempotentMethods = map[string]bool{
+	"/proto.user.v1.UserService/Authenticate":            true,
+	"/proto.user.v1.UserService/AuthenticateAPIKey":      true,
+	"/proto.user.v1.UserService/AuthenticateAccessToken": true,
+	"/proto.user.v1.UserService/GetUser":                 true,
+	"/proto.user.v1.UserService/GetUsers":                true,
+	"/proto.user.v1.UserService/GetKYCLevel":             true,
+	"/proto.user.v1.UserService/ListKYCSubmissions":      true,
+	"/proto.user.v1.UserService/ListAuditEntries":        true,
+	"/proto.user.v1.UserService/ListSessions":            true,
+	"/proto.user.v1.UserService/ListAPIKeys":             true,
+
+	"/proto.wallet.v1.WalletService/GetWallet":               true,
+	"/proto.wallet.v1.WalletService/GetBalance":              true,
+	"/proto.wallet.v1.WalletService/GetBalanceAsOf":          true,
+	"/proto.wallet.v1.WalletService/GetBalanceHistory":       true,
+	"/proto.wallet.v1.WalletService/VerifyBalance":           true,
+	"/proto.wallet.v1.WalletService/GetWalletLimits":         true,
+	"/proto.wallet.v1.WalletService/GetTransactions":         true,
+	"/proto.wallet.v1.WalletService/GetStatement":            true,
+	"/proto.wallet.v1.WalletService/GetTransferBatch":        true,
+	"/proto.wallet.v1.WalletService/GetPaymentRequest":       true,
+	"/proto.wallet.v1.WalletService/ListPaymentRequests":     true,
+	"/proto.wallet.v1.WalletService/GetReconciliationReport": true,
+	"/proto.wallet.v1.WalletService/ListBalanceAdjustments":  true,
+	"/proto.wallet.v1.WalletService/ListAuditEntries":        true,
+	"/proto.wallet.v1.WalletService/ListHeldTransfers":       true,
+	"/proto.wallet.v1.WalletService/GetRiskMetrics":          true,
+	"/proto.wallet.v1.WalletService/GetFeeRules":             true,
+	"/proto.wallet.v1.WalletService/QuoteTransfer":           true,
+	"/proto.wallet.v1.WalletService/QuoteWithdrawal":         true,
+	"/proto.wallet.v1.WalletService/ListScheduledTransfers":  true,
+}
+
+const (
+	retryAttempts = 3
+	retryBackoff  = 100 * time.Millisecond
+)
+
+// retryInterceptor retries idempotent RPCs that failed because the backend
+// was unavailable, with jittered exponential backoff, for as long as the
+// call's deadline allows.
+func retryInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if !idempotentMethods[method] {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		backoff := retryBackoff
+		for attempt := 1; ; attempt++ {
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			if status.Code(err) != codes.Unavailable || attempt == retryAttempts {
+				return err
+			}
+			wait := backoff/2 + rand.N(backoff/2)
+			if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+				return err
+			}
+			select {
+			case <-ctx.Done():
+				return err
+			case <-time.After(wait):
+			}
+			backoff *= 2
+		}
+	}
+}
+
+const (
+	// breakerThreshold is the number of consecutive failed calls that opens
+	// a backend's circuit.
+	breakerThreshold = 5
+	// breakerCooldown is how long an open circuit fails calls fast before
+	// letting one through to probe the backend.
+	breakerCooldown = 10 * time.Second
+)
+
+// circuitBreaker fails calls to a backend fast after it has failed
+// breakerThreshold calls in a row, instead of tying up a request for the
+// whole deadline. After breakerCooldown it lets a single call through and
+// closes again if that call succeeds.
+type circuitBreaker struct {
+	name string
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	probing   bool
+}
+
+func newCircuitBreaker(name string) *circuitBreaker {
+	return &circuitBreaker{name: name}
+}
+
+// Interceptor returns the client interceptor applying the breaker to a
+// backend's connection.
+func (b *circuitBreaker) Interceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if !b.allow(time.Now()) {
+			return status.Errorf(codes.Unavailable, "%s service is unavailable, retry later", b.name)
+		}
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		b.record(backendFailed(ctx, err), time.Now())
+		return err
+	}
+}
+
+func (b *circuitBreaker) allow(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.failures < breakerThreshold {
+		return true
+	}
+	if now.Before(b.openUntil) || b.probing {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+func (b *circuitBreaker) record(failed bool, now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+	if !failed {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.failures >= breakerThreshold {
+		b.openUntil = now.Add(breakerCooldown)
+	}
+}
+
+// backendFailed tells whether err shows the backend to be down or too slow,
+// as opposed to rejecting the call. Calls cancelled by the client do not
+// count.
+func backendFailed(ctx context.Context, err error) bool {
+	if ctx.Err() == context.Canceled {
+		return false
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
+
+// backendInterceptors returns the dial option installing the interceptors
+// for a backend's connection. Metrics come first to record what handlers
+// see, then the breaker so that it counts each call once, after its
+// retries.
+func backendInterceptors(name string) grpc.DialOption {
+	return grpc.WithChainUnaryInterceptor(
+		metricsInterceptor(),
+		newCircuitBreaker(name).Interceptor(),
+		retryInterceptor(),
+	)
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	walletpb "github.com/susilo001/simple-wallet-system/wallet/proto/wallet/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func registerBalanceRoutes(r *gin.Engine, walletClient walletpb.WalletServiceClient) {
+	// The limits in force for the wallet, including the caps of its owner's
+	// KYC level, and how much of them is used.
+	r.GET("/wallets/:id/limits", func(c *gin.Context) {
+		walletId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		resp, err := walletClient.GetWalletLimits(rpcContext(c), &walletpb.GetWalletLimitsRequest{WalletId: int32(walletId)})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"limits": resp.Limits, "source": resp.Source, "kyc_level": resp.KycLevel, "usage": resp.Usage})
+	})
+
+	// ?at=<RFC 3339 time> returns the balance at that time, derived from
+	// the transaction history; without it the live balance is returned.
+	r.GET("/wallets/:id/balance", func(c *gin.Context) {
+		walletId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if c.Query("at") == "" {
+			resp, err := walletClient.GetBalance(rpcContext(c), &walletpb.GetBalanceRequest{WalletId: int32(walletId)})
+			if err != nil {
+				abortWithBackendError(c, err)
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"wallet_id": walletId, "balance": resp.Balance})
+			return
+		}
+
+		at, err := time.Parse(time.RFC3339, c.Query("at"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid at %q, expected an RFC 3339 time", c.Query("at"))})
+			return
+		}
+		resp, err := walletClient.GetBalanceAsOf(rpcContext(c), &walletpb.GetBalanceAsOfRequest{
+			WalletId: int32(walletId),
+			At:       timestamppb.New(at),
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"wallet_id": resp.WalletId, "at": resp.At.AsTime(), "balance": resp.Balance})
+	})
+
+	// Daily end-of-day balances for ?from=YYYY-MM-DD&to=YYYY-MM-DD, both
+	// days included.
+	r.GET("/wallets/:id/balance/history", func(c *gin.Context) {
+		walletId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		from, err := time.Parse("2006-01-02", c.Query("from"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid from %q, expected YYYY-MM-DD", c.Query("from"))})
+			return
+		}
+		to, err := time.Parse("2006-01-02", c.Query("to"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid to %q, expected YYYY-MM-DD", c.Query("to"))})
+			return
+		}
+
+		resp, err := walletClient.GetBalanceHistory(rpcContext(c), &walletpb.GetBalanceHistoryRequest{
+			WalletId: int32(walletId),
+			From:     timestamppb.New(from),
+			To:       timestamppb.New(to),
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+
+		points := make([]gin.H, 0, len(resp.Points))
+		for _, point := range resp.Points {
+			points = append(points, gin.H{"date": point.Date.AsTime().Format("2006-01-02"), "balance": point.Balance})
+		}
+		c.JSON(http.StatusOK, gin.H{"wallet_id": resp.WalletId, "points": points, "check": resp.Check})
+	})
+
+	r.GET("/wallets/:id/balance/check", func(c *gin.Context) {
+		walletId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		resp, err := walletClient.VerifyBalance(rpcContext(c), &walletpb.GetBalanceRequest{WalletId: int32(walletId)})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"check": resp})
+	})
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	userpb "github.com/susilo001/simple-wallet-system/user/proto/user/v1"
+	walletpb "github.com/susilo001/simple-wallet-system/wallet/proto/wallet/v1"
+)
+
+func registerBatchTransferRoutes(r *gin.Engine, userClient userpb.UserServiceClient, walletClient walletpb.WalletServiceClient) {
+	r.POST("/batch-transfers", func(c *gin.Context) {
+		var req struct {
+			Items []struct {
+				SenderId    int     `json:"sender_id" binding:"required"`
+				RecipientId int     `json:"recipient_id" binding:"required"`
+				Amount      float64 `json:"amount" binding:"required"`
+			} `json:"items" binding:"required,min=1,dive"`
+			Atomic bool `json:"atomic"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		var items []*walletpb.BatchTransferItem
+		var total float64
+		for _, item := range req.Items {
+			items = append(items, &walletpb.BatchTransferItem{
+				SenderId:    int32(item.SenderId),
+				RecipientId: int32(item.RecipientId),
+				Amount:      item.Amount,
+			})
+			total += item.Amount
+		}
+		// A batch is stepped up on its total, so that a large transfer
+		// cannot be split into items below the threshold.
+		if !requireSecondFactor(c, userClient, total) {
+			return
+		}
+
+		// Call Wallet service to perform the batch transfer
+		resp, err := walletClient.BatchTransfer(rpcContext(c), &walletpb.BatchTransferRequest{
+			Items:          items,
+			Atomic:         req.Atomic,
+			IdempotencyKey: c.GetHeader("Idempotency-Key"),
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"batch": resp.Batch,
+			"items": resp.Items,
+		})
+	})
+
+	r.GET("/batch-transfers/:id", func(c *gin.Context) {
+		batchId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		resp, err := walletClient.GetTransferBatch(rpcContext(c), &walletpb.GetTransferBatchRequest{BatchId: int32(batchId)})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"batch":        resp.Batch,
+			"transactions": resp.Transactions,
+		})
+	})
+}
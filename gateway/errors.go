@@ -0,0 +1,105 @@
+package main
+
+import (
+	"maps"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// httpStatusFromError maps the gRPC status code of a backend error to the
+// matching HTTP status code.
+func httpStatusFromError(err error) int {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.FailedPrecondition:
+		return http.StatusUnprocessableEntity
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// abortWithBackendError writes a backend error using its mapped status code.
+func abortWithBackendError(c *gin.Context, err error) {
+	if status.Code(err) == codes.Unavailable {
+		c.Header("Retry-After", strconv.Itoa(int(breakerCooldown/time.Second)))
+	}
+	abortWithError(c, httpStatusFromError(err), backendErrorMessage(err))
+}
+
+// problemContentType is the media type of errors on the /v1 API, which are
+// problem details (RFC 9457).
+const problemContentType = "application/problem+json"
+
+// isRESTPath reports whether path belongs to the /v1 API.
+func isRESTPath(path string) bool {
+	return strings.HasPrefix(path, "/v1/")
+}
+
+// abortWithError writes an error and stops the request: problem details on
+// the /v1 API and {"error": message} on the older routes.
+func abortWithError(c *gin.Context, code int, message string) {
+	abortWithErrorFields(c, code, message, nil)
+}
+
+// abortWithErrorFields is abortWithError with more members in the body,
+// such as step_up_required.
+func abortWithErrorFields(c *gin.Context, code int, message string, fields gin.H) {
+	if !isRESTPath(c.Request.URL.Path) {
+		body := gin.H{"error": message}
+		maps.Copy(body, fields)
+		c.AbortWithStatusJSON(code, body)
+		return
+	}
+	body := problem(c.Request, c.GetString(requestIDKey), code, message)
+	maps.Copy(body, fields)
+	c.Header("Content-Type", problemContentType)
+	c.AbortWithStatusJSON(code, body)
+}
+
+// problem returns the problem details of an error on the /v1 API. The
+// request ID lets clients quote the request when reporting the error.
+func problem(r *http.Request, requestID string, code int, detail string) gin.H {
+	return gin.H{
+		"type":       "about:blank",
+		"title":      http.StatusText(code),
+		"status":     code,
+		"detail":     detail,
+		"instance":   r.URL.Path,
+		"request_id": requestID,
+	}
+}
+
+// backendErrorMessage returns the message of a backend error for the
+// client. Connection failures and timeouts get a generic message, since
+// theirs describe the gateway's own network.
+func backendErrorMessage(err error) string {
+	switch status.Code(err) {
+	case codes.Unavailable:
+		return "service is temporarily unavailable, retry later"
+	case codes.DeadlineExceeded:
+		return "service did not respond in time"
+	default:
+		return status.Convert(err).Message()
+	}
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	userpb "github.com/susilo001/simple-wallet-system/user/proto/user/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func registerKYCRoutes(r *gin.Engine, userClient userpb.UserServiceClient) {
+	// Applies for the next KYC level. The response tells whether the
+	// document was verified right away or waits for a manual review.
+	r.POST("/users/:id/kyc", func(c *gin.Context) {
+		userId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		var req struct {
+			Level           string `json:"level" binding:"required"`
+			DocumentType    string `json:"document_type" binding:"required"`
+			DocumentNumber  string `json:"document_number" binding:"required"`
+			DocumentCountry string `json:"document_country" binding:"required"`
+			DocumentExpiry  string `json:"document_expiry" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		expiry, err := time.Parse("2006-01-02", req.DocumentExpiry)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid document_expiry, expected YYYY-MM-DD"})
+			return
+		}
+
+		resp, err := userClient.SubmitKYC(rpcContext(c), &userpb.SubmitKYCRequest{
+			UserId:          int32(userId),
+			Level:           req.Level,
+			DocumentType:    req.DocumentType,
+			DocumentNumber:  req.DocumentNumber,
+			DocumentCountry: req.DocumentCountry,
+			DocumentExpiry:  timestamppb.New(expiry),
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+		c.JSON(http.StatusCreated, gin.H{"submission": resp})
+	})
+
+	r.GET("/users/:id/kyc", func(c *gin.Context) {
+		userId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		pageSize, pageToken, ok := pageQuery(c)
+		if !ok {
+			return
+		}
+		resp, err := userClient.ListKYCSubmissions(rpcContext(c), &userpb.ListKYCSubmissionsRequest{
+			UserId:    int32(userId),
+			Status:    c.Query("status"),
+			PageSize:  pageSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"submissions": resp.Submissions, "page": resp.Page})
+	})
+
+	// The review queue: submissions the verifier could not decide.
+	r.GET("/kyc-submissions", func(c *gin.Context) {
+		pageSize, pageToken, ok := pageQuery(c)
+		if !ok {
+			return
+		}
+		resp, err := userClient.ListKYCSubmissions(rpcContext(c), &userpb.ListKYCSubmissionsRequest{
+			Status:    c.DefaultQuery("status", "pending"),
+			PageSize:  pageSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"submissions": resp.Submissions, "page": resp.Page})
+	})
+
+	r.POST("/kyc-submissions/:id/approve", reviewKYCSubmissionHandler(userClient, true))
+	r.POST("/kyc-submissions/:id/reject", reviewKYCSubmissionHandler(userClient, false))
+}
+
+func reviewKYCSubmissionHandler(userClient userpb.UserServiceClient, approve bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		submissionId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		var req struct {
+			Reason string `json:"reason"`
+		}
+		if c.Request.ContentLength > 0 {
+			if err := c.ShouldBindJSON(&req); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+		}
+
+		resp, err := userClient.ReviewKYCSubmission(rpcContext(c), &userpb.ReviewKYCSubmissionRequest{
+			Id:       int32(submissionId),
+			Approve:  approve,
+			Reviewer: c.GetString(actorKey),
+			Reason:   req.Reason,
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"submission": resp})
+	}
+}
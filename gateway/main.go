@@ -2,33 +2,73 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"log/slog"
 	"net/http"
+	"os"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 	userpb "github.com/susilo001/simple-wallet-system/user/proto/user/v1"
 	walletpb "github.com/susilo001/simple-wallet-system/wallet/proto/wallet/v1"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
 func main() {
-	userConn, err := grpc.NewClient("localhost:50051", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	logger := newLogger(os.Stderr, logLevel, logJSON)
+	slog.SetDefault(logger)
+
+	shutdownTracing, err := setupTracing(context.Background(), "gateway", traceExporter, traceOTLPEndpoint, traceFile)
+	if err != nil {
+		log.Fatalf("Failed to set up tracing: %v", err)
+	}
+	defer shutdownTracing(context.Background())
+
+	userConn, err := grpc.NewClient("localhost:50051",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithPerRPCCredentials(serviceCredentials{username: serviceUsername, password: servicePassword}),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		backendInterceptors("user"),
+	)
 	if err != nil {
 		log.Fatalf("Failed to connect to User service: %v", err)
 	}
 	defer userConn.Close()
 	userClient := userpb.NewUserServiceClient(userConn)
 
-	walletConn, err := grpc.NewClient("localhost:50052", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	walletConn, err := grpc.NewClient("localhost:50052",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithPerRPCCredentials(serviceCredentials{username: serviceUsername, password: servicePassword}),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		backendInterceptors("wallet"),
+	)
 	if err != nil {
 		log.Fatalf("Failed to connect to Wallet service: %v", err)
 	}
 	defer walletConn.Close()
 	walletClient := walletpb.NewWalletServiceClient(walletConn)
 
-	r := gin.Default()
+	r := gin.New()
+	if err := r.SetTrustedProxies(trustedProxies); err != nil {
+		log.Fatalf("Failed to set trusted proxies: %v", err)
+	}
+	rateLimits := newMemoryRateLimitStore()
+	r.Use(
+		gin.Recovery(),
+		otelgin.Middleware("gateway"),
+		metricsMiddleware(),
+		requestIDMiddleware(),
+		accessLogMiddleware(logger),
+		deadlineMiddleware(),
+		ipRateLimitMiddleware(rateLimits),
+		authMiddleware(userClient),
+		routeRateLimitMiddleware(rateLimits),
+		permissionMiddleware(),
+	)
 
 	r.GET("/users/:id", func(c *gin.Context) {
 		id := c.Param("id")
@@ -40,16 +80,16 @@ func main() {
 		}
 
 		// Call User service
-		userResp, err := userClient.GetUser(context.Background(), &userpb.GetUserRequest{Id: int32(userId)})
+		userResp, err := userClient.GetUser(rpcContext(c), &userpb.GetUserRequest{Id: int32(userId)})
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			abortWithBackendError(c, err)
 			return
 		}
 
 		// Call Wallet service
-		walletResp, err := walletClient.GetWallet(context.Background(), &walletpb.GetWalletRequest{WalletId: int32(userId)})
+		walletResp, err := walletClient.GetWallet(rpcContext(c), &walletpb.GetWalletRequest{WalletId: int32(userId)})
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			abortWithBackendError(c, err)
 			return
 		}
 
@@ -68,15 +108,25 @@ func main() {
 			return
 		}
 
+		pageSize, pageToken, ok := pageQuery(c)
+		if !ok {
+			return
+		}
+
 		// Call Wallet service to get transaction history
-		walletResp, err := walletClient.GetTransactions(context.Background(), &walletpb.GetTransactionsRequest{WalletId: int32(userId)})
+		walletResp, err := walletClient.GetTransactions(rpcContext(c), &walletpb.GetTransactionsRequest{
+			WalletId:  int32(userId),
+			PageSize:  pageSize,
+			PageToken: pageToken,
+		})
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			abortWithBackendError(c, err)
 			return
 		}
 
 		c.JSON(http.StatusOK, gin.H{
 			"transactions": walletResp.Transactions,
+			"page":         walletResp.Page,
 		})
 	})
 
@@ -98,9 +148,9 @@ func main() {
 		}
 
 		// Call Wallet service to perform top-up
-		_, err = walletClient.TopUpWallet(context.Background(), &walletpb.TopupRequest{WalletId: int32(walletId), Amount: req.Amount})
+		_, err = walletClient.TopUpWallet(rpcContext(c), &walletpb.TopupRequest{WalletId: int32(walletId), Amount: req.Amount})
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			abortWithBackendError(c, err)
 			return
 		}
 
@@ -124,21 +174,61 @@ func main() {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
+		if !requireSecondFactor(c, userClient, req.Amount) {
+			return
+		}
 
 		// Call Wallet service to perform transfer
-		_, err = walletClient.Transfer(context.Background(), &walletpb.TransferRequest{
+		_, err = walletClient.Transfer(rpcContext(c), &walletpb.TransferRequest{
 			SenderId:    int32(senderId),
 			RecipientId: int32(req.RecipientId),
 			Amount:      req.Amount,
 		})
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			abortWithBackendError(c, err)
 			return
 		}
 
 		c.JSON(http.StatusOK, gin.H{"message": "Wallet transfer successful"})
 	})
 
+	registerPaymentRequestRoutes(r, userClient, walletClient)
+	registerBatchTransferRoutes(r, userClient, walletClient)
+	registerStatementRoutes(r, walletClient)
+	registerBalanceRoutes(r, walletClient)
+	registerReconciliationRoutes(r, walletClient)
+	registerAdjustmentRoutes(r, walletClient)
+	registerAuditRoutes(r, userClient, walletClient)
+	registerAdminRoutes(r, userClient)
+	registerWalletStatusRoutes(r, walletClient)
+	registerRiskRoutes(r, walletClient)
+	registerKYCRoutes(r, userClient)
+	registerAccountRoutes(r, userClient)
+	registerTwoFactorRoutes(r, userClient)
+	registerSessionRoutes(r, userClient)
+	registerAPIKeyRoutes(r, userClient)
+
+	restMux, err := newRESTMux(context.Background(), userConn, walletConn)
+	if err != nil {
+		log.Fatalf("Failed to set up the REST API: %v", err)
+	}
+	registerRESTRoutes(r, restMux, restHooks(userClient, walletClient))
+	openAPI, err := openAPIDocument()
+	if err != nil {
+		log.Fatalf("Failed to build the OpenAPI document: %v", err)
+	}
+	r.GET(openAPIPath, func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/json", openAPI)
+	})
+	// Unknown /v1 paths get problem details like the rest of the API; the
+	// older routes keep gin's plain 404.
+	r.NoRoute(func(c *gin.Context) {
+		if isRESTPath(c.Request.URL.Path) {
+			abortWithError(c, http.StatusNotFound, fmt.Sprintf("no route for %s %s", c.Request.Method, c.Request.URL.Path))
+		}
+	})
+
+	go serveMetrics()
 	r.Run(":8080")
 
 }
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	requestIDHeader = "X-Request-ID"
+	// Context keys set by the gateway's middleware.
+	requestIDKey = "request_id"
+	actorKey     = "actor"
+)
+
+// requestIDMiddleware gives every request an ID, taken from the
+// X-Request-ID header when the client sent one, and echoes it back.
+func requestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(requestIDHeader)
+		if requestID == "" {
+			buf := make([]byte, 16)
+			_, _ = rand.Read(buf)
+			requestID = hex.EncodeToString(buf)
+		}
+		c.Set(requestIDKey, requestID)
+		c.Header(requestIDHeader, requestID)
+		c.Next()
+	}
+}
+
+// rpcContext returns the context for backend calls made on behalf of c. It
+// passes the request ID and the caller on to the services' audit logs, and
+// the authenticated user and role on to their permission checks.
+func rpcContext(c *gin.Context) context.Context {
+	actor := c.GetString(actorKey)
+	if actor == "" {
+		actor = "anonymous@" + c.ClientIP()
+	}
+	ctx := metadata.AppendToOutgoingContext(c.Request.Context(),
+		"x-actor", actor,
+		"x-request-id", c.GetString(requestIDKey),
+	)
+	if userID := c.GetInt(userIDKey); userID != 0 {
+		ctx = metadata.AppendToOutgoingContext(ctx,
+			"x-user-id", strconv.Itoa(userID),
+			"x-role", c.GetString(roleKey),
+		)
+	}
+	if sessionID := c.GetInt(sessionIDKey); sessionID != 0 {
+		ctx = metadata.AppendToOutgoingContext(ctx, "x-session-id", strconv.Itoa(sessionID))
+	}
+	return ctx
+}
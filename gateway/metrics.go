@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// metricsAddress is where Prometheus metrics are served on /metrics. It is
+// kept off the public router so that it needs no route roles.
+const metricsAddress = ":9090"
+
+// The gRPC client metrics use the names and labels of the services' server
+// metrics, so that both sides of a call can be compared.
+var (
+	httpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "HTTP requests handled, by route and status code.",
+	}, []string{"method", "route", "code"})
+	httpRequestSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Time taken to handle HTTP requests, by route.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route"})
+	grpcClientHandled = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_client_handled_total",
+		Help: "gRPC calls made to the backends, by method and status code.",
+	}, []string{"grpc_service", "grpc_method", "grpc_code"})
+	grpcClientHandlingSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_client_handling_seconds",
+		Help:    "Time taken by gRPC calls to the backends, including retries, by method.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"grpc_service", "grpc_method"})
+)
+
+// metricsMiddleware records the rate, errors and duration of requests per
+// route. Unmatched paths share one label value so that scanners cannot
+// create a series per path.
+func metricsMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		httpRequests.WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).Inc()
+		httpRequestSeconds.WithLabelValues(c.Request.Method, route).Observe(time.Since(start).Seconds())
+	}
+}
+
+// metricsInterceptor records the rate, errors and duration of backend
+// calls.
+func metricsInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		service, name, _ := strings.Cut(strings.TrimPrefix(method, "/"), "/")
+		grpcClientHandled.WithLabelValues(service, name, status.Code(err).String()).Inc()
+		grpcClientHandlingSeconds.WithLabelValues(service, name).Observe(time.Since(start).Seconds())
+		return err
+	}
+}
+
+// serveMetrics serves /metrics on metricsAddress until the process exits.
+func serveMetrics() {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	slog.Info("Serving metrics", "address", metricsAddress)
+	if err := http.ListenAndServe(metricsAddress, mux); err != nil {
+		slog.Error("Error serving metrics", "error", err)
+	}
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// pageQuery reads the page_size and page_token query parameters of a
+// hand-written list route. The backends page every list like the /v1 API
+// does, so these routes return one page and its "page" too. It writes the
+// response and returns false when page_size is not a number.
+func pageQuery(c *gin.Context) (int32, string, bool) {
+	var pageSize int64
+	if value := c.Query("page_size"); value != "" {
+		var err error
+		if pageSize, err = strconv.ParseInt(value, 10, 32); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "page_size must be a number"})
+			return 0, "", false
+		}
+	}
+	return int32(pageSize), c.Query("page_token"), true
+}
@@ -0,0 +1,262 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	userpb "github.com/susilo001/simple-wallet-system/user/proto/user/v1"
+	walletpb "github.com/susilo001/simple-wallet-system/wallet/proto/wallet/v1"
+)
+
+func registerPaymentRequestRoutes(r *gin.Engine, userClient userpb.UserServiceClient, walletClient walletpb.WalletServiceClient) {
+	r.POST("/wallets/:id/payment-requests", func(c *gin.Context) {
+		walletId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		var req struct {
+			PayerWalletId    int     `json:"payer_wallet_id" binding:"required"`
+			Amount           float64 `json:"amount" binding:"required"`
+			Note             string  `json:"note"`
+			ExpiresInSeconds int     `json:"expires_in_seconds"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		// Call Wallet service to create the payment request
+		paymentRequest, err := walletClient.RequestPayment(rpcContext(c), &walletpb.RequestPaymentRequest{
+			RequesterWalletId: int32(walletId),
+			PayerWalletId:     int32(req.PayerWalletId),
+			Amount:            req.Amount,
+			Note:              req.Note,
+			ExpiresInSeconds:  int32(req.ExpiresInSeconds),
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{"payment_request": paymentRequest})
+	})
+
+	r.GET("/wallets/:id/payment-requests", func(c *gin.Context) {
+		walletId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		pageSize, pageToken, ok := pageQuery(c)
+		if !ok {
+			return
+		}
+
+		// Call Wallet service to list incoming and outgoing payment requests
+		resp, err := walletClient.ListPaymentRequests(rpcContext(c), &walletpb.ListPaymentRequestsRequest{
+			WalletId:  int32(walletId),
+			PageSize:  pageSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"payment_requests": resp.PaymentRequests, "page": resp.Page})
+	})
+
+	r.POST("/wallets/:id/payment-links", func(c *gin.Context) {
+		walletId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		var req struct {
+			Amount           float64 `json:"amount" binding:"required"`
+			Note             string  `json:"note"`
+			ExpiresInSeconds int     `json:"expires_in_seconds"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		// Call Wallet service to create the payment link
+		paymentLink, err := walletClient.CreatePaymentLink(rpcContext(c), &walletpb.CreatePaymentLinkRequest{
+			RequesterWalletId: int32(walletId),
+			Amount:            req.Amount,
+			Note:              req.Note,
+			ExpiresInSeconds:  int32(req.ExpiresInSeconds),
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{"payment_link": paymentLink})
+	})
+
+	r.GET("/payment-requests/:id", func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		paymentRequest, err := walletClient.GetPaymentRequest(rpcContext(c), &walletpb.GetPaymentRequestRequest{
+			Lookup: &walletpb.GetPaymentRequestRequest_Id{Id: int32(id)},
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"payment_request": paymentRequest})
+	})
+
+	r.POST("/payment-requests/:id/accept", func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		var req struct {
+			PayerWalletId int `json:"payer_wallet_id" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if !requirePaymentSecondFactor(c, userClient, walletClient, &walletpb.GetPaymentRequestRequest{
+			Lookup: &walletpb.GetPaymentRequestRequest_Id{Id: int32(id)},
+		}) {
+			return
+		}
+
+		// Call Wallet service to pay the request
+		paymentRequest, err := walletClient.AcceptPaymentRequest(rpcContext(c), &walletpb.AcceptPaymentRequestRequest{
+			Lookup:        &walletpb.AcceptPaymentRequestRequest_Id{Id: int32(id)},
+			PayerWalletId: int32(req.PayerWalletId),
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"payment_request": paymentRequest})
+	})
+
+	r.POST("/payment-requests/:id/decline", func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		var req struct {
+			PayerWalletId int `json:"payer_wallet_id" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		paymentRequest, err := walletClient.DeclinePaymentRequest(rpcContext(c), &walletpb.DeclinePaymentRequestRequest{
+			Id:            int32(id),
+			PayerWalletId: int32(req.PayerWalletId),
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"payment_request": paymentRequest})
+	})
+
+	r.POST("/payment-requests/:id/cancel", func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		var req struct {
+			RequesterWalletId int `json:"requester_wallet_id" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		paymentRequest, err := walletClient.CancelPaymentRequest(rpcContext(c), &walletpb.CancelPaymentRequestRequest{
+			Id:                int32(id),
+			RequesterWalletId: int32(req.RequesterWalletId),
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"payment_request": paymentRequest})
+	})
+
+	r.GET("/payment-links/:code", func(c *gin.Context) {
+		paymentLink, err := walletClient.GetPaymentRequest(rpcContext(c), &walletpb.GetPaymentRequestRequest{
+			Lookup: &walletpb.GetPaymentRequestRequest_Code{Code: c.Param("code")},
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"payment_link": paymentLink})
+	})
+
+	r.POST("/payment-links/:code/pay", func(c *gin.Context) {
+		var req struct {
+			PayerWalletId int `json:"payer_wallet_id" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if !requirePaymentSecondFactor(c, userClient, walletClient, &walletpb.GetPaymentRequestRequest{
+			Lookup: &walletpb.GetPaymentRequestRequest_Code{Code: c.Param("code")},
+		}) {
+			return
+		}
+
+		// Call Wallet service to pay the link
+		paymentLink, err := walletClient.AcceptPaymentRequest(rpcContext(c), &walletpb.AcceptPaymentRequestRequest{
+			Lookup:        &walletpb.AcceptPaymentRequestRequest_Code{Code: c.Param("code")},
+			PayerWalletId: int32(req.PayerWalletId),
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"payment_link": paymentLink})
+	})
+}
+
+// requirePaymentSecondFactor checks the caller's second factor for the
+// amount of the payment request or link that lookup names, before it is
+// paid.
+func requirePaymentSecondFactor(c *gin.Context, userClient userpb.UserServiceClient, walletClient walletpb.WalletServiceClient, lookup *walletpb.GetPaymentRequestRequest) bool {
+	paymentRequest, err := walletClient.GetPaymentRequest(rpcContext(c), lookup)
+	if err != nil {
+		abortWithBackendError(c, err)
+		return false
+	}
+	return requireSecondFactor(c, userClient, paymentRequest.GetAmount())
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	userpb "github.com/susilo001/simple-wallet-system/user/proto/user/v1"
+)
+
+// rateLimitPolicy is a token bucket holding up to Limit requests, refilled
+// at Limit requests per Window. Routes sharing a policy share its buckets.
+type rateLimitPolicy struct {
+	Name   string
+	Limit  int
+	Window time.Duration
+}
+
+// rateLimitResult is the state of a bucket after taking a request from it.
+type rateLimitResult struct {
+	Allowed   bool
+	Remaining int
+	// Reset is how long until the bucket is full again.
+	Reset time.Duration
+	// RetryAfter is how long until the next request is allowed, when this
+	// one was not.
+	RetryAfter time.Duration
+}
+
+// rateLimitStore keeps the token buckets. memoryRateLimitStore only limits
+// the gateway instance it runs in; running several instances behind a load
+// balancer needs a store shared between them, such as one backed by Redis.
+type rateLimitStore interface {
+	Take(ctx context.Context, key string, policy rateLimitPolicy, now time.Time) (rateLimitResult, error)
+}
+
+// trustedProxies are the proxies whose X-Forwarded-For header gin believes
+// when working out the client IP. The gateway is reached directly, so it
+// trusts none and the client IP is the address of the connection; list the
+// load balancer's addresses here when one is put in front of it. Trusting
+// every proxy would let clients pick their own IP and dodge the per-IP
+// limits.
+var trustedProxies []string
+
+var (
+	// ipRateLimit applies to every request before authentication, so that
+	// floods and password guessing are limited before reaching the user
+	// service.
+	ipRateLimit = rateLimitPolicy{Name: "ip", Limit: 300, Window: time.Minute}
+	// defaultRateLimit applies per caller to routes without a policy in
+	// routeRateLimits.
+	defaultRateLimit = rateLimitPolicy{Name: "default", Limit: 120, Window: time.Minute}
+)
+
+// routeRateLimits maps routes, as "METHOD /path", to their policy per
+// caller.
+var routeRateLimits = map[string]rateLimitPolicy{
+	"POST /wallets/:id/transfers":       {Name: "transfers", Limit: 10, Window: time.Minute},
+	"POST /batch-transfers":             {Name: "batch-transfers", Limit: 5, Window: time.Minute},
+	"POST /payment-links/:code/pay":     {Name: "payments", Limit: 10, Window: time.Minute},
+	"POST /payment-requests/:id/accept": {Name: "payments", Limit: 10, Window: time.Minute},
+
+	"POST /auth/login":                  {Name: "login", Limit: 10, Window: 15 * time.Minute},
+	"POST /auth/refresh":                {Name: "refresh", Limit: 30, Window: time.Minute},
+	"POST /users":                       {Name: "signup", Limit: 5, Window: time.Hour},
+	"POST /auth/email-verification":     {Name: "account-email", Limit: 5, Window: time.Hour},
+	"POST /auth/password-reset":         {Name: "account-email", Limit: 5, Window: time.Hour},
+	"POST /auth/password-reset/confirm": {Name: "password-reset", Limit: 10, Window: time.Hour},
+
+	// The /v1 routes share the buckets of the routes they duplicate.
+	"POST /v1/wallets/:wallet_id/transfers": {Name: "transfers", Limit: 10, Window: time.Minute},
+	"POST /v1/batch-transfers":              {Name: "batch-transfers", Limit: 5, Window: time.Minute},
+	"POST /v1/payment-links/:code/pay":      {Name: "payments", Limit: 10, Window: time.Minute},
+	"POST /v1/payment-requests/:id/accept":  {Name: "payments", Limit: 10, Window: time.Minute},
+
+	"POST /v1/auth/login":                  {Name: "login", Limit: 10, Window: 15 * time.Minute},
+	"POST /v1/auth/refresh":                {Name: "refresh", Limit: 30, Window: time.Minute},
+	"POST /v1/users":                       {Name: "signup", Limit: 5, Window: time.Hour},
+	"POST /v1/auth/email-verification":     {Name: "account-email", Limit: 5, Window: time.Hour},
+	"POST /v1/auth/password-reset":         {Name: "account-email", Limit: 5, Window: time.Hour},
+	"POST /v1/auth/password-reset/confirm": {Name: "password-reset", Limit: 10, Window: time.Hour},
+}
+
+// ipRateLimitMiddleware limits requests per client IP with ipRateLimit.
+func ipRateLimitMiddleware(store rateLimitStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rateLimit(c, store, ipRateLimit, "ip:"+c.ClientIP())
+	}
+}
+
+// routeRateLimitMiddleware limits requests per route policy and caller: the
+// API key, else the user, else the client IP. It runs after authMiddleware.
+func routeRateLimitMiddleware(store rateLimitStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		policy, ok := routeRateLimits[c.Request.Method+" "+c.FullPath()]
+		if !ok {
+			policy = defaultRateLimit
+		}
+		rateLimit(c, store, policy, rateLimitCaller(c))
+	}
+}
+
+func rateLimitCaller(c *gin.Context) string {
+	if value, ok := c.Get(apiKeyKey); ok {
+		return fmt.Sprintf("api-key:%d", value.(*userpb.APIKey).GetId())
+	}
+	if userID := c.GetInt(userIDKey); userID != 0 {
+		return fmt.Sprintf("user:%d", userID)
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// rateLimit takes a request from the caller's bucket, sets the RateLimit
+// headers and rejects the request with 429 if the bucket is empty. If the
+// store fails the request is let through, so that an outage of a shared
+// store does not take the gateway down with it.
+func rateLimit(c *gin.Context, store rateLimitStore, policy rateLimitPolicy, caller string) {
+	result, err := store.Take(c.Request.Context(), policy.Name+"|"+caller, policy, time.Now())
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "Error taking from rate limit bucket", "request_id", c.GetString(requestIDKey), "policy", policy.Name, "caller", caller, "error", err)
+		c.Next()
+		return
+	}
+
+	c.Header("RateLimit-Policy", fmt.Sprintf("%d;w=%d", policy.Limit, int(policy.Window/time.Second)))
+	c.Header("RateLimit-Limit", strconv.Itoa(policy.Limit))
+	c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	c.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+	if !result.Allowed {
+		c.Header("Retry-After", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+		abortWithError(c, http.StatusTooManyRequests, "rate limit exceeded, retry later")
+		return
+	}
+	c.Next()
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+// memoryRateLimitStore keeps token buckets in memory.
+type memoryRateLimitStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+	window  time.Duration
+}
+
+func newMemoryRateLimitStore() *memoryRateLimitStore {
+	return &memoryRateLimitStore{buckets: map[string]*tokenBucket{}}
+}
+
+func (s *memoryRateLimitStore) Take(ctx context.Context, key string, policy rateLimitPolicy, now time.Time) (rateLimitResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sweep(now)
+
+	limit := float64(policy.Limit)
+	perToken := policy.Window / time.Duration(policy.Limit)
+	bucket, ok := s.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: limit, updated: now, window: policy.Window}
+		s.buckets[key] = bucket
+	}
+	elapsed := now.Sub(bucket.updated)
+	bucket.tokens = math.Min(limit, bucket.tokens+elapsed.Seconds()/perToken.Seconds())
+	bucket.updated = now
+
+	result := rateLimitResult{Allowed: bucket.tokens >= 1}
+	if result.Allowed {
+		bucket.tokens--
+	} else {
+		result.RetryAfter = time.Duration((1 - bucket.tokens) * float64(perToken))
+	}
+	result.Remaining = int(bucket.tokens)
+	result.Reset = time.Duration((limit - bucket.tokens) * float64(perToken))
+	return result, nil
+}
+
+// sweep drops buckets that have refilled completely, at most once a minute,
+// so that callers seen once do not stay in memory.
+func (s *memoryRateLimitStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < time.Minute {
+		return
+	}
+	s.lastSweep = now
+	for key, bucket := range s.buckets {
+		if now.Sub(bucket.updated) >= bucket.window {
+			delete(s.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryRateLimitStoreTake(t *testing.T) {
+	ctx := context.Background()
+	policy := rateLimitPolicy{Name: "test", Limit: 3, Window: 3 * time.Second}
+	store := newMemoryRateLimitStore()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	steps := []struct {
+		name string
+		key  string
+		at   time.Duration
+		want rateLimitResult
+	}{
+		{name: "first request", key: "a", at: 0, want: rateLimitResult{Allowed: true, Remaining: 2, Reset: time.Second}},
+		{name: "second request", key: "a", at: 0, want: rateLimitResult{Allowed: true, Remaining: 1, Reset: 2 * time.Second}},
+		{name: "last token", key: "a", at: 0, want: rateLimitResult{Allowed: true, Remaining: 0, Reset: 3 * time.Second}},
+		{name: "empty bucket", key: "a", at: 0, want: rateLimitResult{Remaining: 0, Reset: 3 * time.Second, RetryAfter: time.Second}},
+		{name: "other caller has its own bucket", key: "b", at: 0, want: rateLimitResult{Allowed: true, Remaining: 2, Reset: time.Second}},
+		{name: "partly refilled", key: "a", at: 500 * time.Millisecond, want: rateLimitResult{Remaining: 0, Reset: 2500 * time.Millisecond, RetryAfter: 500 * time.Millisecond}},
+		{name: "one token refilled", key: "a", at: time.Second, want: rateLimitResult{Allowed: true, Remaining: 0, Reset: 3 * time.Second}},
+		{name: "refill stops at the limit", key: "a", at: time.Minute, want: rateLimitResult{Allowed: true, Remaining: 2, Reset: time.Second}},
+	}
+	for _, step := range steps {
+		got, err := store.Take(ctx, step.key, policy, start.Add(step.at))
+		if err != nil {
+			t.Fatalf("%s: Take() error = %v", step.name, err)
+		}
+		if got != step.want {
+			t.Errorf("%s: Take() = %+v, want %+v", step.name, got, step.want)
+		}
+	}
+}
+
+func TestMemoryRateLimitStoreSweepsFullBuckets(t *testing.T) {
+	ctx := context.Background()
+	policy := rateLimitPolicy{Name: "test", Limit: 3, Window: 3 * time.Second}
+	store := newMemoryRateLimitStore()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if _, err := store.Take(ctx, "a", policy, start); err != nil {
+		t.Fatalf("Take() error = %v", err)
+	}
+	if _, err := store.Take(ctx, "b", policy, start.Add(2*time.Minute)); err != nil {
+		t.Fatalf("Take() error = %v", err)
+	}
+	if _, ok := store.buckets["a"]; ok {
+		t.Error("bucket a was not swept after refilling")
+	}
+	if _, ok := store.buckets["b"]; !ok {
+		t.Error("bucket b was swept while in use")
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	walletpb "github.com/susilo001/simple-wallet-system/wallet/proto/wallet/v1"
+)
+
+func registerReconciliationRoutes(r *gin.Engine, walletClient walletpb.WalletServiceClient) {
+	// ?format=json or ?format=csv downloads the report as a document.
+	r.POST("/reconciliations", func(c *gin.Context) {
+		var req struct {
+			ProposeAdjustments bool `json:"propose_adjustments"`
+		}
+		if c.Request.ContentLength > 0 {
+			if err := c.ShouldBindJSON(&req); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+		}
+
+		resp, err := walletClient.Reconcile(rpcContext(c), &walletpb.ReconcileRequest{
+			ProposeAdjustments: req.ProposeAdjustments,
+			Format:             c.Query("format"),
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+		writeReconciliationReport(c, resp)
+	})
+
+	r.GET("/reconciliations/:id", func(c *gin.Context) {
+		runId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		resp, err := walletClient.GetReconciliationReport(rpcContext(c), &walletpb.GetReconciliationReportRequest{
+			RunId:  int32(runId),
+			Format: c.Query("format"),
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+		writeReconciliationReport(c, resp)
+	})
+}
+
+func writeReconciliationReport(c *gin.Context, resp *walletpb.ReconciliationReportResponse) {
+	if resp.Filename != "" {
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", resp.Filename))
+		c.Data(http.StatusOK, resp.ContentType, resp.Document)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"run":           resp.Run,
+		"discrepancies": resp.Discrepancies,
+	})
+}
@@ -0,0 +1,420 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	userpb "github.com/susilo001/simple-wallet-system/user/proto/user/v1"
+	walletpb "github.com/susilo001/simple-wallet-system/wallet/proto/wallet/v1"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// The /v1 API is generated from the HTTP rules in user.proto and
+// wallet.proto: adding a rule to an RPC is enough to serve it. Every rule
+// becomes a gin route, so that the gateway's middleware (auth, rate limits,
+// deadlines, metrics) applies to it as to the hand-written routes, and the
+// route is then handled by the grpc-gateway handlers generated with the
+// protos. Requests and responses are the RPCs' messages, fields marked
+// REQUIRED in the protos are checked by requiredFieldsConn, and errors are
+// problem details.
+
+// openAPIPath serves the OpenAPI document of the /v1 API.
+const openAPIPath = "/v1/openapi.json"
+
+// restJSON encodes the /v1 API's bodies with the proto field names, like
+// the hand-written routes.
+var restJSON = &runtime.JSONPb{
+	MarshalOptions:   protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true},
+	UnmarshalOptions: protojson.UnmarshalOptions{DiscardUnknown: true},
+}
+
+// restHook checks or completes the request of an RPC before it is sent,
+// like the hand-written route for the same RPC does. It writes the response
+// and returns false when the request must not go ahead. Hooks are only set
+// for RPCs whose HTTP rule maps the whole body to the request.
+type restHook func(c *gin.Context, req proto.Message) bool
+
+// restHooks maps RPCs, by full method name, to their hook.
+func restHooks(userClient userpb.UserServiceClient, walletClient walletpb.WalletServiceClient) map[string]restHook {
+	setReviewer := func(c *gin.Context, req proto.Message) bool {
+		switch r := req.(type) {
+		case *userpb.ReviewKYCSubmissionRequest:
+			r.Reviewer = c.GetString(actorKey)
+		case *walletpb.ReviewHeldTransferRequest:
+			r.Reviewer = c.GetString(actorKey)
+		}
+		return true
+	}
+	return map[string]restHook{
+		// Sessions record the client IP as gin works it out with
+		// trustedProxies, not an IP the client claims in the body.
+		"/proto.user.v1.UserService/Login": func(c *gin.Context, req proto.Message) bool {
+			r := req.(*userpb.LoginRequest)
+			r.Ip, r.UserAgent = c.ClientIP(), c.Request.UserAgent()
+			return true
+		},
+		"/proto.user.v1.UserService/RefreshSession": func(c *gin.Context, req proto.Message) bool {
+			r := req.(*userpb.RefreshSessionRequest)
+			r.Ip, r.UserAgent = c.ClientIP(), c.Request.UserAgent()
+			return true
+		},
+
+		"/proto.user.v1.UserService/ReviewKYCSubmission":    setReviewer,
+		"/proto.wallet.v1.WalletService/ReviewHeldTransfer": setReviewer,
+
+		"/proto.user.v1.UserService/CreateAPIKey": func(c *gin.Context, req proto.Message) bool {
+			return requireSecondFactor(c, userClient, req.(*userpb.CreateAPIKeyRequest).GetTransferLimit())
+		},
+		"/proto.wallet.v1.WalletService/Transfer": func(c *gin.Context, req proto.Message) bool {
+			return requireSecondFactor(c, userClient, req.(*walletpb.TransferRequest).GetAmount())
+		},
+		"/proto.wallet.v1.WalletService/WithdrawWallet": func(c *gin.Context, req proto.Message) bool {
+			return requireSecondFactor(c, userClient, req.(*walletpb.WithdrawRequest).GetAmount())
+		},
+		"/proto.wallet.v1.WalletService/BatchTransfer": func(c *gin.Context, req proto.Message) bool {
+			r := req.(*walletpb.BatchTransferRequest)
+			if r.IdempotencyKey == "" {
+				r.IdempotencyKey = c.GetHeader("Idempotency-Key")
+			}
+			var total float64
+			for _, item := range r.GetItems() {
+				total += item.GetAmount()
+			}
+			return requireSecondFactor(c, userClient, total)
+		},
+		"/proto.wallet.v1.WalletService/ScheduleTransfer": func(c *gin.Context, req proto.Message) bool {
+			return requireSecondFactor(c, userClient, req.(*walletpb.ScheduleTransferRequest).GetAmount())
+		},
+		// The id or code is in the path, not in the body.
+		"/proto.wallet.v1.WalletService/AcceptPaymentRequest": func(c *gin.Context, req proto.Message) bool {
+			lookup := &walletpb.GetPaymentRequestRequest{}
+			if code := c.Param("code"); code != "" {
+				lookup.Lookup = &walletpb.GetPaymentRequestRequest_Code{Code: code}
+			} else {
+				id, err := strconv.Atoi(c.Param("id"))
+				if err != nil {
+					abortWithError(c, http.StatusBadRequest, err.Error())
+					return false
+				}
+				lookup.Lookup = &walletpb.GetPaymentRequestRequest_Id{Id: int32(id)}
+			}
+			return requirePaymentSecondFactor(c, userClient, walletClient, lookup)
+		},
+	}
+}
+
+// newRESTMux returns the generated handlers of both services. They call the
+// backends through the gateway's own connections, so that their calls carry
+// the service credentials and go through the retries and circuit breakers.
+func newRESTMux(ctx context.Context, userConn, walletConn grpc.ClientConnInterface) (*runtime.ServeMux, error) {
+	mux := runtime.NewServeMux(
+		// No header is passed on as metadata: the caller is passed on by
+		// rpcContext, and clients must not be able to set x-user-id or
+		// x-role themselves with Grpc-Metadata- headers.
+		runtime.WithIncomingHeaderMatcher(func(string) (string, bool) { return "", false }),
+		runtime.WithMetadata(func(ctx context.Context, r *http.Request) metadata.MD {
+			md, _ := metadata.FromOutgoingContext(ctx)
+			return md
+		}),
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, restJSON),
+		runtime.WithErrorHandler(restErrorHandler),
+	)
+	if err := userpb.RegisterUserServiceHandlerClient(ctx, mux, userpb.NewUserServiceClient(requiredFieldsConn{userConn})); err != nil {
+		return nil, err
+	}
+	if err := walletpb.RegisterWalletServiceHandlerClient(ctx, mux, walletpb.NewWalletServiceClient(requiredFieldsConn{walletConn})); err != nil {
+		return nil, err
+	}
+	return mux, nil
+}
+
+// restErrorHandler writes errors like abortWithBackendError. The field
+// violations of an invalid request are listed in the problem's errors.
+func restErrorHandler(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	if status.Code(err) == codes.Unavailable {
+		w.Header().Set("Retry-After", strconv.Itoa(int(breakerCooldown/time.Second)))
+	}
+	code := httpStatusFromError(err)
+	body := problem(r, w.Header().Get(requestIDHeader), code, backendErrorMessage(err))
+	var fieldErrors []gin.H
+	for _, detail := range status.Convert(err).Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			for _, violation := range badRequest.GetFieldViolations() {
+				fieldErrors = append(fieldErrors, gin.H{"field": violation.GetField(), "detail": violation.GetDescription()})
+			}
+		}
+	}
+	if len(fieldErrors) > 0 {
+		body["errors"] = fieldErrors
+	}
+	w.Header().Set("Content-Type", problemContentType)
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// requiredFieldsConn rejects calls whose request leaves a field marked
+// REQUIRED with google.api.field_behavior unset, before they reach the
+// backend. It only wraps the /v1 API's calls: the hand-written routes check
+// their own input.
+type requiredFieldsConn struct {
+	grpc.ClientConnInterface
+}
+
+func (c requiredFieldsConn) Invoke(ctx context.Context, method string, args any, reply any, opts ...grpc.CallOption) error {
+	if req, ok := args.(proto.Message); ok {
+		if violations := missingFields(req.ProtoReflect(), ""); len(violations) > 0 {
+			fields := make([]string, len(violations))
+			for i, violation := range violations {
+				fields[i] = violation.GetField()
+			}
+			st := status.New(codes.InvalidArgument, "missing required fields: "+strings.Join(fields, ", "))
+			if detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations}); err == nil {
+				st = detailed
+			}
+			return st.Err()
+		}
+	}
+	return c.ClientConnInterface.Invoke(ctx, method, args, reply, opts...)
+}
+
+// missingFields returns a violation for every REQUIRED field of msg, and of
+// the messages it holds, that is unset. Without field presence a scalar is
+// unset when it is zero, so a required amount of 0 is missing too.
+func missingFields(msg protoreflect.Message, prefix string) []*errdetails.BadRequest_FieldViolation {
+	var violations []*errdetails.BadRequest_FieldViolation
+	fields := msg.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		name := prefix + string(field.Name())
+		if !msg.Has(field) {
+			behaviors, _ := proto.GetExtension(field.Options(), annotations.E_FieldBehavior).([]annotations.FieldBehavior)
+			if slices.Contains(behaviors, annotations.FieldBehavior_REQUIRED) {
+				violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: name, Description: "is required"})
+			}
+			continue
+		}
+		switch {
+		case field.IsMap() || field.Message() == nil:
+		case field.IsList():
+			list := msg.Get(field).List()
+			for j := 0; j < list.Len(); j++ {
+				violations = append(violations, missingFields(list.Get(j).Message(), fmt.Sprintf("%s[%d].", name, j))...)
+			}
+		default:
+			violations = append(violations, missingFields(msg.Get(field).Message(), name+".")...)
+		}
+	}
+	return violations
+}
+
+// registerRESTRoutes adds a route for every HTTP rule of the services,
+// handled by mux. The services check who may call each RPC, so the routes
+// are open to every role here.
+func registerRESTRoutes(r *gin.Engine, mux http.Handler, hooks map[string]restHook) {
+	wildcards := map[string]string{}
+	for _, file := range []protoreflect.FileDescriptor{userpb.File_proto_user_v1_user_proto, walletpb.File_proto_wallet_v1_wallet_proto} {
+		services := file.Services()
+		for i := 0; i < services.Len(); i++ {
+			methods := services.Get(i).Methods()
+			for j := 0; j < methods.Len(); j++ {
+				method := methods.Get(j)
+				rule, _ := proto.GetExtension(method.Options(), annotations.E_Http).(*annotations.HttpRule)
+				if rule == nil {
+					continue
+				}
+				fullMethod := fmt.Sprintf("/%s/%s", method.Parent().FullName(), method.Name())
+				handler := restHandler(mux, method, hooks[fullMethod])
+				for _, binding := range append([]*annotations.HttpRule{rule}, rule.GetAdditionalBindings()...) {
+					httpMethod, template := httpRulePattern(binding)
+					route := ginPath(template, wildcards)
+					r.Handle(httpMethod, route, handler)
+					routeRoles[httpMethod+" "+route] = everyone
+				}
+			}
+		}
+	}
+}
+
+func restHandler(mux http.Handler, method protoreflect.MethodDescriptor, hook restHook) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if hook != nil && !applyRESTHook(c, method, hook) {
+			return
+		}
+		// The client's credentials were checked by authMiddleware and stay
+		// at the gateway; grpc-gateway would otherwise pass Authorization on.
+		c.Request.Header.Del("Authorization")
+		c.Request.Header.Del(apiKeyHeader)
+		mux.ServeHTTP(c.Writer, c.Request.WithContext(rpcContext(c)))
+	}
+}
+
+// applyRESTHook decodes the request body of method, runs hook on it and
+// replaces the body with the request the hook may have changed.
+func applyRESTHook(c *gin.Context, method protoreflect.MethodDescriptor, hook restHook) bool {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		abortWithError(c, http.StatusBadRequest, err.Error())
+		return false
+	}
+	msgType, err := protoregistry.GlobalTypes.FindMessageByName(method.Input().FullName())
+	if err != nil {
+		abortWithError(c, http.StatusInternalServerError, err.Error())
+		return false
+	}
+	req := msgType.New().Interface()
+	if len(body) > 0 {
+		if err := restJSON.Unmarshal(body, req); err != nil {
+			abortWithError(c, http.StatusBadRequest, err.Error())
+			return false
+		}
+	}
+	if !hook(c, req) {
+		return false
+	}
+	body, err = restJSON.Marshal(req)
+	if err != nil {
+		abortWithError(c, http.StatusInternalServerError, err.Error())
+		return false
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	c.Request.ContentLength = int64(len(body))
+	return true
+}
+
+func httpRulePattern(rule *annotations.HttpRule) (string, string) {
+	switch pattern := rule.GetPattern().(type) {
+	case *annotations.HttpRule_Get:
+		return http.MethodGet, pattern.Get
+	case *annotations.HttpRule_Post:
+		return http.MethodPost, pattern.Post
+	case *annotations.HttpRule_Put:
+		return http.MethodPut, pattern.Put
+	case *annotations.HttpRule_Patch:
+		return http.MethodPatch, pattern.Patch
+	case *annotations.HttpRule_Delete:
+		return http.MethodDelete, pattern.Delete
+	case *annotations.HttpRule_Custom:
+		return pattern.Custom.GetKind(), pattern.Custom.GetPath()
+	}
+	return "", ""
+}
+
+// ginPath turns an HTTP rule path, such as /v1/wallets/{sender_id}/transfers,
+// into a gin route. gin needs routes sharing a prefix to use the same
+// wildcard name after it, so a variable is named after the first variable
+// registered at its position: with GetWallet registered first, the route
+// above is /v1/wallets/:wallet_id/transfers. The keys of routeRoles and the
+// other route maps use these names.
+func ginPath(template string, wildcards map[string]string) string {
+	segments := strings.Split(template, "/")
+	for i, segment := range segments {
+		if !strings.HasPrefix(segment, "{") {
+			continue
+		}
+		prefix := strings.Join(segments[:i], "/")
+		name, ok := wildcards[prefix]
+		if !ok {
+			name = strings.Trim(segment, "{}")
+			wildcards[prefix] = name
+		}
+		segments[i] = ":" + name
+	}
+	return strings.Join(segments, "/")
+}
+
+// openAPIDocument merges the OpenAPI documents generated from user.proto
+// and wallet.proto into one describing the whole /v1 API, and documents
+// the problem details every operation may respond with.
+func openAPIDocument() ([]byte, error) {
+	paths := map[string]map[string]map[string]any{}
+	definitions := map[string]any{}
+	var tags []json.RawMessage
+	for _, spec := range [][]byte{userpb.OpenAPI, walletpb.OpenAPI} {
+		var doc struct {
+			Tags        []json.RawMessage                    `json:"tags"`
+			Paths       map[string]map[string]map[string]any `json:"paths"`
+			Definitions map[string]json.RawMessage           `json:"definitions"`
+		}
+		if err := json.Unmarshal(spec, &doc); err != nil {
+			return nil, err
+		}
+		tags = append(tags, doc.Tags...)
+		maps.Copy(paths, doc.Paths)
+		for name, definition := range doc.Definitions {
+			definitions[name] = definition
+		}
+	}
+	for _, item := range paths {
+		for _, operation := range item {
+			responses, _ := operation["responses"].(map[string]any)
+			if responses == nil {
+				responses = map[string]any{}
+				operation["responses"] = responses
+			}
+			responses["default"] = gin.H{
+				"description": "An error, as problem details.",
+				"schema":      gin.H{"$ref": "#/definitions/Problem"},
+			}
+		}
+	}
+	definitions["Problem"] = gin.H{
+		"type":        "object",
+		"description": "Problem details (RFC 9457), served as " + problemContentType + ".",
+		"properties": gin.H{
+			"type":       gin.H{"type": "string"},
+			"title":      gin.H{"type": "string", "description": "The HTTP status text."},
+			"status":     gin.H{"type": "integer", "format": "int32"},
+			"detail":     gin.H{"type": "string"},
+			"instance":   gin.H{"type": "string", "description": "The request path."},
+			"request_id": gin.H{"type": "string", "description": "The X-Request-ID of the request."},
+			"errors": gin.H{
+				"type":        "array",
+				"description": "The invalid fields of a 400 response.",
+				"items":       gin.H{"$ref": "#/definitions/ProblemFieldError"},
+			},
+		},
+	}
+	definitions["ProblemFieldError"] = gin.H{
+		"type": "object",
+		"properties": gin.H{
+			"field":  gin.H{"type": "string", "description": "The proto name of the field, e.g. items[0].amount."},
+			"detail": gin.H{"type": "string"},
+		},
+	}
+	return json.Marshal(gin.H{
+		"swagger":  "2.0",
+		"info":     gin.H{"title": "Simple Wallet API", "version": "v1"},
+		"tags":     tags,
+		"consumes": []string{"application/json"},
+		"produces": []string{"application/json", problemContentType},
+		"securityDefinitions": gin.H{
+			"basic":   gin.H{"type": "basic"},
+			"bearer":  gin.H{"type": "apiKey", "in": "header", "name": "Authorization", "description": "Bearer access token from POST /v1/auth/login."},
+			"api_key": gin.H{"type": "apiKey", "in": "header", "name": apiKeyHeader},
+		},
+		// Routes open to anonymous callers need no credentials.
+		"security":    []gin.H{{"bearer": []string{}}, {"basic": []string{}}, {"api_key": []string{}}, {}},
+		"paths":       paths,
+		"definitions": definitions,
+	})
+}
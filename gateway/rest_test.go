@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TestOpenAPIDocumentMatchesRoutes keeps the served OpenAPI document in
+// step with the /v1 routes generated from the protos.
+func TestOpenAPIDocumentMatchesRoutes(t *testing.T) {
+	doc, err := openAPIDocument()
+	if err != nil {
+		t.Fatalf("openAPIDocument() error = %v", err)
+	}
+	r := newRESTTestEngine()
+	if err := checkOpenAPI(r.Routes(), doc); err != nil {
+		t.Fatalf("the OpenAPI document does not match the /v1 routes: %v", err)
+	}
+}
+
+// TestCheckOpenAPIReportsUndocumentedRoutes makes sure the check above
+// would catch a route added without its HTTP rule.
+func TestCheckOpenAPIReportsUndocumentedRoutes(t *testing.T) {
+	doc, err := openAPIDocument()
+	if err != nil {
+		t.Fatalf("openAPIDocument() error = %v", err)
+	}
+	r := newRESTTestEngine()
+	r.GET("/v1/undocumented", func(c *gin.Context) {})
+	err = checkOpenAPI(r.Routes(), doc)
+	if err == nil || !strings.Contains(err.Error(), "GET /v1/undocumented is not documented") {
+		t.Fatalf("checkOpenAPI() error = %v, want the undocumented route reported", err)
+	}
+}
+
+// newRESTTestEngine registers the /v1 routes as main does, without
+// backends behind them.
+func newRESTTestEngine() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	registerRESTRoutes(r, http.NotFoundHandler(), nil)
+	r.GET(openAPIPath, func(c *gin.Context) {})
+	return r
+}
+
+// checkOpenAPI returns an error listing the /v1 routes missing from the
+// OpenAPI document and the operations of the document that no route
+// serves.
+func checkOpenAPI(routes gin.RoutesInfo, doc []byte) error {
+	var spec struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(doc, &spec); err != nil {
+		return err
+	}
+	documented := map[string]bool{}
+	for path, item := range spec.Paths {
+		for method := range item {
+			documented[strings.ToUpper(method)+" "+routeShape(path)] = true
+		}
+	}
+	var problems []string
+	served := map[string]bool{}
+	for _, route := range routes {
+		if !isRESTPath(route.Path) || route.Path == openAPIPath {
+			continue
+		}
+		key := route.Method + " " + routeShape(route.Path)
+		served[key] = true
+		if !documented[key] {
+			problems = append(problems, fmt.Sprintf("%s %s is not documented", route.Method, route.Path))
+		}
+	}
+	for key := range documented {
+		if !served[key] {
+			problems = append(problems, fmt.Sprintf("%s is documented but not served", key))
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.Strings(problems)
+	return errors.New(strings.Join(problems, "; "))
+}
+
+// routeShape replaces the variables of a gin route or OpenAPI path with
+// "{}", since the two may name them differently (see ginPath).
+func routeShape(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "{") {
+			segments[i] = "{}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
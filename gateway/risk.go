@@ -0,0 +1,62 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	walletpb "github.com/susilo001/simple-wallet-system/wallet/proto/wallet/v1"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func registerRiskRoutes(r *gin.Engine, walletClient walletpb.WalletServiceClient) {
+	// Transfers the risk checks held for review. Releasing one executes it.
+	r.GET("/held-transfers", func(c *gin.Context) {
+		pageSize, pageToken, ok := pageQuery(c)
+		if !ok {
+			return
+		}
+		resp, err := walletClient.ListHeldTransfers(rpcContext(c), &walletpb.ListHeldTransfersRequest{
+			Status:    c.Query("status"),
+			PageSize:  pageSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"held_transfers": resp.HeldTransfers, "page": resp.Page})
+	})
+
+	r.POST("/held-transfers/:id/release", reviewHeldTransferHandler(walletClient, true))
+	r.POST("/held-transfers/:id/reject", reviewHeldTransferHandler(walletClient, false))
+
+	r.GET("/risk/metrics", func(c *gin.Context) {
+		resp, err := walletClient.GetRiskMetrics(rpcContext(c), &emptypb.Empty{})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"rules": resp.Rules, "decisions": resp.Decisions})
+	})
+}
+
+func reviewHeldTransferHandler(walletClient walletpb.WalletServiceClient, approve bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		heldTransferId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		resp, err := walletClient.ReviewHeldTransfer(rpcContext(c), &walletpb.ReviewHeldTransferRequest{
+			HeldTransferId: int32(heldTransferId),
+			Approve:        approve,
+			Reviewer:       c.GetString(actorKey),
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"held_transfer": resp})
+	}
+}
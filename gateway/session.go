@@ -0,0 +1,145 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	userpb "github.com/susilo001/simple-wallet-system/user/proto/user/v1"
+)
+
+func registerSessionRoutes(r *gin.Engine, userClient userpb.UserServiceClient) {
+	// Starts a session. The access token goes in an "Authorization: Bearer"
+	// header; when it expires, POST /auth/refresh swaps the refresh token
+	// for new ones.
+	r.POST("/auth/login", func(c *gin.Context) {
+		var req struct {
+			Email    string `json:"email" binding:"required"`
+			Password string `json:"password" binding:"required"`
+			Device   string `json:"device"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		resp, err := userClient.Login(rpcContext(c), &userpb.LoginRequest{
+			Email:     req.Email,
+			Password:  req.Password,
+			Device:    req.Device,
+			Ip:        c.ClientIP(),
+			UserAgent: c.Request.UserAgent(),
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+		c.JSON(http.StatusCreated, resp)
+	})
+
+	// Every refresh token works once. Presenting one again logs the session
+	// out, since it may have been stolen.
+	r.POST("/auth/refresh", func(c *gin.Context) {
+		var req struct {
+			RefreshToken string `json:"refresh_token" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		resp, err := userClient.RefreshSession(rpcContext(c), &userpb.RefreshSessionRequest{
+			RefreshToken: req.RefreshToken,
+			Ip:           c.ClientIP(),
+			UserAgent:    c.Request.UserAgent(),
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, resp)
+	})
+
+	// Ends the caller's own session.
+	r.POST("/auth/logout", func(c *gin.Context) {
+		sessionId := c.GetInt(sessionIDKey)
+		if sessionId == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "logout needs an access token; basic credentials have no session"})
+			return
+		}
+		resp, err := userClient.RevokeSession(rpcContext(c), &userpb.RevokeSessionRequest{
+			UserId:    int32(c.GetInt(userIDKey)),
+			SessionId: int32(sessionId),
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"message": resp.Message})
+	})
+
+	r.GET("/users/:id/sessions", func(c *gin.Context) {
+		userId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		pageSize, pageToken, ok := pageQuery(c)
+		if !ok {
+			return
+		}
+		resp, err := userClient.ListSessions(rpcContext(c), &userpb.ListSessionsRequest{
+			UserId:    int32(userId),
+			PageSize:  pageSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"sessions": resp.Sessions, "page": resp.Page, "current_session_id": c.GetInt(sessionIDKey)})
+	})
+
+	// Logs the user out everywhere. With keep_current=true the caller's own
+	// session stays signed in.
+	r.DELETE("/users/:id/sessions", func(c *gin.Context) {
+		userId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		var exceptSessionId int
+		if c.Query("keep_current") == "true" {
+			exceptSessionId = c.GetInt(sessionIDKey)
+		}
+		resp, err := userClient.RevokeAllSessions(rpcContext(c), &userpb.RevokeAllSessionsRequest{
+			UserId:          int32(userId),
+			ExceptSessionId: int32(exceptSessionId),
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"message": resp.Message})
+	})
+
+	r.DELETE("/users/:id/sessions/:session_id", func(c *gin.Context) {
+		userId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		sessionId, err := strconv.Atoi(c.Param("session_id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		resp, err := userClient.RevokeSession(rpcContext(c), &userpb.RevokeSessionRequest{
+			UserId:    int32(userId),
+			SessionId: int32(sessionId),
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"message": resp.Message})
+	})
+}
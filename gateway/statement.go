@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	walletpb "github.com/susilo001/simple-wallet-system/wallet/proto/wallet/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func registerStatementRoutes(r *gin.Engine, walletClient walletpb.WalletServiceClient) {
+	// The period is either ?month=YYYY-MM or ?from=YYYY-MM-DD&to=YYYY-MM-DD
+	// (end exclusive), in UTC. ?format=csv or ?format=pdf downloads the
+	// rendered document; otherwise the statement is returned as JSON.
+	r.GET("/wallets/:id/statement", func(c *gin.Context) {
+		walletId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		from, to, err := statementPeriod(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		format := c.Query("format")
+		if format == "json" {
+			format = ""
+		}
+
+		// Call Wallet service to build the statement
+		resp, err := walletClient.GetStatement(rpcContext(c), &walletpb.GetStatementRequest{
+			WalletId: int32(walletId),
+			From:     timestamppb.New(from),
+			To:       timestamppb.New(to),
+			Format:   format,
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+
+		if format == "" {
+			c.JSON(http.StatusOK, gin.H{"statement": resp.Statement})
+			return
+		}
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", resp.Filename))
+		c.Data(http.StatusOK, resp.ContentType, resp.Document)
+	})
+}
+
+func statementPeriod(c *gin.Context) (time.Time, time.Time, error) {
+	if month := c.Query("month"); month != "" {
+		from, err := time.Parse("2006-01", month)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid month %q, expected YYYY-MM", month)
+		}
+		return from, from.AddDate(0, 1, 0), nil
+	}
+
+	from, err := time.Parse("2006-01-02", c.Query("from"))
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid from %q, expected YYYY-MM-DD", c.Query("from"))
+	}
+	to, err := time.Parse("2006-01-02", c.Query("to"))
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid to %q, expected YYYY-MM-DD", c.Query("to"))
+	}
+	return from, to, nil
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	userpb "github.com/susilo001/simple-wallet-system/user/proto/user/v1"
+)
+
+// stepUpTransferAmount is the most, in rupiah, a user may move with a
+// password alone within stepUpWindow. A request that would take the user's
+// total in the window above it needs a second factor in the otpHeader, so
+// splitting a large transfer into smaller requests does not avoid step-up.
+// The total is kept per gateway instance, like the rate limits.
+const (
+	stepUpTransferAmount = 5_000_000
+	stepUpWindow         = 24 * time.Hour
+)
+
+// otpHeader carries a TOTP code or a recovery code for step-up auth.
+const otpHeader = "X-OTP"
+
+// passwordOnlyTransfers counts the amounts let through without a second
+// factor, per user.
+var passwordOnlyTransfers = newStepUpTally()
+
+// requireSecondFactor checks the caller's second factor before a transfer
+// of amount. It writes the response and returns false when the transfer
+// must not go ahead. Each code is accepted only once, so a retried request
+// needs a new one.
+//
+// Amounts let through without a code count towards stepUpTransferAmount
+// even if the wallet service then refuses the transfer. API keys have no
+// second factor. Their transfer limit applies instead, and setting it above
+// stepUpTransferAmount needed one.
+func requireSecondFactor(c *gin.Context, userClient userpb.UserServiceClient, amount float64) bool {
+	if value, ok := c.Get(apiKeyKey); ok {
+		apiKey := value.(*userpb.APIKey)
+		if amount > apiKey.GetTransferLimit() {
+			abortWithError(c, http.StatusForbidden, fmt.Sprintf("amount exceeds the transfer limit of API key %s", apiKey.GetPrefix()))
+			return false
+		}
+		return true
+	}
+	userID := c.GetInt(userIDKey)
+	if passwordOnlyTransfers.Add(userID, amount, stepUpTransferAmount, stepUpWindow, time.Now()) {
+		return true
+	}
+	code := c.GetHeader(otpHeader)
+	if code == "" {
+		abortWithErrorFields(c, http.StatusUnauthorized,
+			fmt.Sprintf("transfers above %d within %s need a two-factor code in the %s header", stepUpTransferAmount, stepUpWindow, otpHeader),
+			gin.H{"step_up_required": true})
+		return false
+	}
+	_, err := userClient.VerifySecondFactor(rpcContext(c), &userpb.VerifySecondFactorRequest{
+		UserId: int32(userID),
+		Code:   code,
+	})
+	if err != nil {
+		abortWithBackendError(c, err)
+		return false
+	}
+	return true
+}
+
+// stepUpTally keeps, per user, the amounts transferred with a password
+// alone that are still within the step-up window.
+type stepUpTally struct {
+	mu        sync.Mutex
+	transfers map[int][]stepUpTransfer
+	lastSweep time.Time
+}
+
+type stepUpTransfer struct {
+	amount float64
+	at     time.Time
+}
+
+func newStepUpTally() *stepUpTally {
+	return &stepUpTally{transfers: map[int][]stepUpTransfer{}}
+}
+
+// Add records amount for userID and returns true if the user's total within
+// window stays at or below limit. Otherwise nothing is recorded.
+func (t *stepUpTally) Add(userID int, amount, limit float64, window time.Duration, now time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.sweep(window, now)
+
+	var recent []stepUpTransfer
+	total := amount
+	for _, transfer := range t.transfers[userID] {
+		if now.Sub(transfer.at) < window {
+			recent = append(recent, transfer)
+			total += transfer.amount
+		}
+	}
+	if total > limit {
+		t.transfers[userID] = recent
+		return false
+	}
+	t.transfers[userID] = append(recent, stepUpTransfer{amount: amount, at: now})
+	return true
+}
+
+// sweep drops users with no transfer left in the window, at most once a
+// minute, so that users seen once do not stay in memory.
+func (t *stepUpTally) sweep(window time.Duration, now time.Time) {
+	if now.Sub(t.lastSweep) < time.Minute {
+		return
+	}
+	t.lastSweep = now
+	for userID, transfers := range t.transfers {
+		if len(transfers) == 0 || now.Sub(transfers[len(transfers)-1].at) >= window {
+			delete(t.transfers, userID)
+		}
+	}
+}
+
+func registerTwoFactorRoutes(r *gin.Engine, userClient userpb.UserServiceClient) {
+	// Starts enrollment. The secret only protects the account once it has
+	// been confirmed with a code from the authenticator app.
+	r.POST("/users/:id/2fa/totp", func(c *gin.Context) {
+		userId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		resp, err := userClient.EnrollTOTP(rpcContext(c), &userpb.EnrollTOTPRequest{
+			UserId: int32(userId),
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+		c.JSON(http.StatusCreated, gin.H{"secret": resp.Secret, "otpauth_uri": resp.OtpauthUri})
+	})
+
+	// Enables two-factor auth and returns the recovery codes, which are not
+	// shown again.
+	r.POST("/users/:id/2fa/totp/confirm", func(c *gin.Context) {
+		userId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		var req struct {
+			Code string `json:"code" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		resp, err := userClient.ConfirmTOTP(rpcContext(c), &userpb.ConfirmTOTPRequest{
+			UserId: int32(userId),
+			Code:   req.Code,
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"recovery_codes": resp.RecoveryCodes})
+	})
+
+	r.DELETE("/users/:id/2fa/totp", func(c *gin.Context) {
+		userId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		var req struct {
+			Code string `json:"code" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		resp, err := userClient.DisableTOTP(rpcContext(c), &userpb.DisableTOTPRequest{
+			UserId: int32(userId),
+			Code:   req.Code,
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"message": resp.Message})
+	})
+
+	r.POST("/users/:id/2fa/recovery-codes", func(c *gin.Context) {
+		userId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		var req struct {
+			Code string `json:"code" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		resp, err := userClient.RegenerateRecoveryCodes(rpcContext(c), &userpb.RegenerateRecoveryCodesRequest{
+			UserId: int32(userId),
+			Code:   req.Code,
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"recovery_codes": resp.RecoveryCodes})
+	})
+}
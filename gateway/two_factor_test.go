@@ -0,0 +1,35 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStepUpTallyAdd(t *testing.T) {
+	tally := newStepUpTally()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	const limit, window = 100, time.Hour
+
+	steps := []struct {
+		name   string
+		userID int
+		amount float64
+		at     time.Duration
+		want   bool
+	}{
+		{name: "first transfer", userID: 1, amount: 60, at: 0, want: true},
+		{name: "up to the limit", userID: 1, amount: 40, at: time.Minute, want: true},
+		{name: "over the limit in total", userID: 1, amount: 1, at: 2 * time.Minute, want: false},
+		{name: "other user has their own total", userID: 2, amount: 100, at: 2 * time.Minute, want: true},
+		{name: "refused transfer is not counted", userID: 1, amount: 60, at: time.Hour, want: true},
+		{name: "older transfers leave the window", userID: 1, amount: 40, at: time.Hour + time.Minute, want: true},
+		{name: "recent transfers still count", userID: 1, amount: 1, at: time.Hour + time.Minute, want: false},
+		{name: "single transfer over the limit", userID: 3, amount: 101, at: 0, want: false},
+	}
+	for _, step := range steps {
+		got := tally.Add(step.userID, step.amount, limit, window, start.Add(step.at))
+		if got != step.want {
+			t.Errorf("%s: Add() = %v, want %v", step.name, got, step.want)
+		}
+	}
+}
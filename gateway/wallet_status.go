@@ -0,0 +1,109 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	walletpb "github.com/susilo001/simple-wallet-system/wallet/proto/wallet/v1"
+)
+
+func registerWalletStatusRoutes(r *gin.Engine, walletClient walletpb.WalletServiceClient) {
+	r.GET("/wallets/:id", func(c *gin.Context) {
+		walletId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		resp, err := walletClient.GetWallet(rpcContext(c), &walletpb.GetWalletRequest{WalletId: int32(walletId)})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"wallet": resp.Wallet})
+	})
+
+	// status is FROZEN_DEBIT to stop outgoing money only, or FROZEN_ALL
+	// (the default) to stop money in both directions.
+	r.POST("/wallets/:id/freeze", func(c *gin.Context) {
+		walletId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		var req struct {
+			Status string `json:"status"`
+			Reason string `json:"reason" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if req.Status == "" {
+			req.Status = "FROZEN_ALL"
+		}
+
+		resp, err := walletClient.FreezeWallet(rpcContext(c), &walletpb.FreezeWalletRequest{
+			WalletId: int32(walletId),
+			Status:   req.Status,
+			Reason:   req.Reason,
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"wallet": resp})
+	})
+
+	r.POST("/wallets/:id/unfreeze", func(c *gin.Context) {
+		walletId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		var req struct {
+			Reason string `json:"reason" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		resp, err := walletClient.UnfreezeWallet(rpcContext(c), &walletpb.UnfreezeWalletRequest{
+			WalletId: int32(walletId),
+			Reason:   req.Reason,
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"wallet": resp})
+	})
+
+	// Closing is final and only possible once the balance is zero.
+	r.POST("/wallets/:id/close", func(c *gin.Context) {
+		walletId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		var req struct {
+			Reason string `json:"reason" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		resp, err := walletClient.CloseWallet(rpcContext(c), &walletpb.CloseWalletRequest{
+			WalletId: int32(walletId),
+			Reason:   req.Reason,
+		})
+		if err != nil {
+			abortWithBackendError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"wallet": resp})
+	})
+}
@@ -3,4 +3,49 @@ package config
 const (
 	AuthBasicUsername = "user"
 	AuthBasicPassword = "pass"
-)
\ No newline at end of file
+)
+
+// Server SMTP untuk email ke pengguna. Jika SMTPHost kosong, email ditulis
+// sebagai file .eml di MailDir.
+const (
+	SMTPHost     = ""
+	SMTPPort     = 587
+	SMTPUsername = ""
+	SMTPPassword = ""
+	MailFrom     = "Simple Wallet <no-reply@simple-wallet.local>"
+	MailDir      = "outbox"
+)
+
+// Link di email verifikasi dan reset password, dengan %s untuk tokennya.
+// Link verifikasi langsung ke gateway; link reset ke halaman web yang
+// meminta password baru lalu mengirimnya ke POST /auth/password-reset/confirm.
+const (
+	EmailVerificationURL = "http://localhost:8080/auth/email-verification?token=%s"
+	PasswordResetURL     = "http://localhost:3000/reset-password?token=%s"
+)
+
+// TOTPIssuer adalah nama layanan yang tampil di aplikasi authenticator.
+const TOTPIssuer = "Simple Wallet"
+
+// SessionStore menentukan tempat sesi login disimpan: "postgres", atau
+// "memory" untuk development dengan satu instance user service.
+const SessionStore = "postgres"
+
+// Exporter span OpenTelemetry: "none", "otlp" ke collector di
+// TraceOTLPEndpoint, "stdout", atau "file" yang menulis ke TraceFile untuk
+// development.
+const (
+	TraceExporter     = "none"
+	TraceOTLPEndpoint = "localhost:4317"
+	TraceFile         = "traces.json"
+)
+
+// MetricsAddress adalah alamat untuk metrik Prometheus di /metrics.
+const MetricsAddress = ":9091"
+
+// LogLevel adalah level log terendah yang dicatat: "debug", "info", "warn",
+// atau "error". LogJSON menulis log sebagai baris JSON, bukan teks.
+const (
+	LogLevel = "info"
+	LogJSON  = true
+)
@@ -0,0 +1,70 @@
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+// Scope API key, yaitu route gateway yang boleh dipanggil dengan key
+// tersebut.
+const (
+	ScopeBalanceRead      = "balance:read"
+	ScopeTransactionsRead = "transactions:read"
+	// ScopeTransfersCreate hanya berlaku sampai TransferLimit per transfer.
+	ScopeTransfersCreate = "transfers:create"
+)
+
+// IsValidScope mengembalikan apakah scope dikenal.
+func IsValidScope(scope string) bool {
+	switch scope {
+	case ScopeBalanceRead, ScopeTransactionsRead, ScopeTransfersCreate:
+		return true
+	}
+	return false
+}
+
+// APIKey adalah kredensial untuk integrasi server ke server atas nama
+// pemiliknya. Seperti UserToken, yang disimpan hanya hash-nya; Prefix
+// disimpan agar pemiliknya bisa mengenali key di daftar.
+type APIKey struct {
+	ID      int    `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID  int    `gorm:"not null;index" json:"user_id"`
+	Name    string `gorm:"type:varchar;not null" json:"name"`
+	Prefix  string `gorm:"type:varchar;not null" json:"prefix"`
+	KeyHash string `gorm:"type:varchar;not null;uniqueIndex" json:"-"`
+	// Scopes dipisahkan koma, lihat HasScope.
+	Scopes string `gorm:"type:varchar;not null" json:"scopes"`
+	// TransferLimit adalah nominal terbesar satu transfer dengan key ini.
+	TransferLimit float64    `gorm:"not null;default:0" json:"transfer_limit"`
+	ExpiresAt     *time.Time `json:"expires_at"`
+	LastUsedAt    *time.Time `json:"last_used_at"`
+	RevokedAt     *time.Time `json:"revoked_at"`
+	// RotatedFromID adalah key yang digantikan key ini, jika dibuat lewat
+	// rotasi.
+	RotatedFromID *int      `json:"rotated_from_id"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// ScopeList mengembalikan scope key sebagai slice.
+func (k APIKey) ScopeList() []string {
+	if k.Scopes == "" {
+		return nil
+	}
+	return strings.Split(k.Scopes, ",")
+}
+
+func (k APIKey) HasScope(scope string) bool {
+	for _, s := range k.ScopeList() {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Active mengembalikan apakah key belum dicabut dan belum kedaluwarsa pada
+// waktu at.
+func (k APIKey) Active(at time.Time) bool {
+	return k.ID != 0 && k.RevokedAt == nil && (k.ExpiresAt == nil || at.Before(*k.ExpiresAt))
+}
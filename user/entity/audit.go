@@ -0,0 +1,46 @@
+package entity
+
+import "time"
+
+// AuditEntry mencatat satu panggilan RPC yang mengubah data. Entri membentuk
+// rantai hash: Hash mencakup semua field lain termasuk PrevHash, yaitu Hash
+// entri sebelumnya, sehingga perubahan, sisipan atau penghapusan entri
+// memutus rantai mulai dari entri tersebut.
+type AuditEntry struct {
+	ID       int    `gorm:"primaryKey;autoIncrement" json:"id"`
+	Sequence int64  `gorm:"not null;uniqueIndex" json:"sequence"`
+	Actor    string `gorm:"type:varchar;index" json:"actor"`
+	// Action adalah nama lengkap method gRPC.
+	Action string `gorm:"type:varchar;index" json:"action"`
+	// Target adalah objek yang diubah, misalnya "user:12".
+	Target string `gorm:"type:varchar;index" json:"target"`
+	// Request, Before dan After berisi dokumen JSON.
+	Request   string    `gorm:"type:text" json:"request"`
+	Before    string    `gorm:"type:text" json:"before"`
+	After     string    `gorm:"type:text" json:"after"`
+	Outcome   string    `gorm:"type:varchar" json:"outcome"`
+	Error     string    `gorm:"type:text" json:"error"`
+	RequestID string    `gorm:"type:varchar;index" json:"request_id"`
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+	PrevHash  string    `gorm:"type:varchar" json:"prev_hash"`
+	Hash      string    `gorm:"type:varchar;uniqueIndex" json:"hash"`
+}
+
+// TableName memisahkan log audit layanan user dari log audit layanan wallet
+// karena keduanya bisa memakai database yang sama.
+func (AuditEntry) TableName() string {
+	return "user_audit_entries"
+}
+
+// AuditChainHead adalah satu-satunya baris yang menyimpan sequence dan hash
+// entri audit terakhir.
+type AuditChainHead struct {
+	ID        int       `gorm:"primaryKey" json:"id"`
+	Sequence  int64     `json:"sequence"`
+	Hash      string    `gorm:"type:varchar" json:"hash"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (AuditChainHead) TableName() string {
+	return "user_audit_chain_heads"
+}
@@ -0,0 +1,63 @@
+package entity
+
+import "time"
+
+// Level KYC user, dari yang paling rendah. Level menentukan batas saldo dan
+// transfer wallet milik user; batasnya diatur di wallet service.
+const (
+	// KYCLevelNone adalah level user yang belum pernah diverifikasi.
+	KYCLevelNone = "none"
+	// KYCLevelBasic membutuhkan satu dokumen identitas.
+	KYCLevelBasic = "basic"
+	// KYCLevelFull membutuhkan KTP atau paspor dan level basic.
+	KYCLevelFull = "full"
+)
+
+// Status pengajuan KYC.
+const (
+	KYCStatusPending  = "pending"
+	KYCStatusVerified = "verified"
+	KYCStatusRejected = "rejected"
+)
+
+// Jenis dokumen identitas yang diterima.
+const (
+	DocumentNationalID    = "national_id"
+	DocumentPassport      = "passport"
+	DocumentDriverLicense = "driver_license"
+)
+
+// KYCLevelRank mengembalikan urutan level KYC, atau -1 untuk level yang
+// tidak dikenal.
+func KYCLevelRank(level string) int {
+	switch level {
+	case KYCLevelNone:
+		return 0
+	case KYCLevelBasic:
+		return 1
+	case KYCLevelFull:
+		return 2
+	}
+	return -1
+}
+
+// KYCSubmission adalah pengajuan kenaikan level KYC beserta metadata
+// dokumennya. File dokumennya sendiri tidak disimpan di sini.
+type KYCSubmission struct {
+	ID              int       `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID          int       `gorm:"not null;index" json:"user_id"`
+	Level           string    `gorm:"type:varchar;not null" json:"level"`
+	Status          string    `gorm:"type:varchar;not null;index" json:"status"`
+	DocumentType    string    `gorm:"type:varchar;not null" json:"document_type"`
+	DocumentNumber  string    `gorm:"type:varchar;not null" json:"document_number"`
+	DocumentCountry string    `gorm:"type:varchar(2);not null" json:"document_country"`
+	DocumentExpiry  time.Time `gorm:"type:date" json:"document_expiry"`
+	// Reason adalah alasan dari verifier atau reviewer.
+	Reason string `gorm:"type:varchar" json:"reason"`
+	// ReviewedBy adalah nama verifier, atau actor yang memeriksanya secara
+	// manual.
+	ReviewedBy string     `gorm:"type:varchar" json:"reviewed_by"`
+	ReviewedAt *time.Time `json:"reviewed_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
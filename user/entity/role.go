@@ -0,0 +1,25 @@
+package entity
+
+// Role yang bisa dimiliki user. User baru selalu mendapat RoleCustomer;
+// role lain hanya bisa diberikan oleh admin.
+const (
+	// RoleCustomer hanya boleh mengakses data dan wallet miliknya sendiri.
+	RoleCustomer = "customer"
+	// RoleSupport boleh melihat data semua user dan wallet untuk membantu
+	// customer, tetapi tidak boleh memindahkan uang.
+	RoleSupport = "support"
+	// RoleFinance mengelola biaya, limit dan rekonsiliasi saldo.
+	RoleFinance = "finance"
+	// RoleAdmin boleh memanggil semua RPC, termasuk menghapus user,
+	// mengubah role dan mengoreksi saldo.
+	RoleAdmin = "admin"
+)
+
+// IsValidRole melaporkan apakah role adalah salah satu role di atas.
+func IsValidRole(role string) bool {
+	switch role {
+	case RoleCustomer, RoleSupport, RoleFinance, RoleAdmin:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+package entity
+
+import "time"
+
+// Alasan sesi dicabut.
+const (
+	SessionRevokedLogout      = "logout"
+	SessionRevokedByUser      = "revoked"
+	SessionRevokedAll         = "logout_everywhere"
+	SessionRevokedTokenReused = "refresh_token_reused"
+)
+
+// Session adalah login user dari satu perangkat. Sesi memegang satu access
+// token yang berlaku singkat, dan keluarga refresh token yang dirotasi
+// setiap kali dipakai untuk membuat access token baru.
+type Session struct {
+	ID        int    `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID    int    `gorm:"not null;index" json:"user_id"`
+	Device    string `gorm:"type:varchar" json:"device"`
+	IP        string `gorm:"type:varchar" json:"ip"`
+	UserAgent string `gorm:"type:varchar" json:"user_agent"`
+	// AccessTokenHash adalah hash SHA-256 access token yang berlaku saat ini.
+	AccessTokenHash string    `gorm:"type:varchar;not null;uniqueIndex" json:"-"`
+	AccessExpiresAt time.Time `gorm:"not null" json:"access_expires_at"`
+	// ExpiresAt adalah batas berlaku refresh token terakhir; sesi yang tidak
+	// di-refresh sampai saat itu berakhir.
+	ExpiresAt     time.Time  `gorm:"not null" json:"expires_at"`
+	LastSeenAt    time.Time  `gorm:"not null" json:"last_seen_at"`
+	RevokedAt     *time.Time `json:"revoked_at"`
+	RevokedReason string     `gorm:"type:varchar" json:"revoked_reason"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// Active mengembalikan apakah sesi belum dicabut dan belum kedaluwarsa pada
+// waktu at.
+func (s Session) Active(at time.Time) bool {
+	return s.ID != 0 && s.RevokedAt == nil && at.Before(s.ExpiresAt)
+}
+
+// RefreshToken adalah satu refresh token dalam keluarga token sebuah sesi.
+// Token yang sudah dirotasi (UsedAt terisi) tidak boleh dipakai lagi; jika
+// dipakai, tokennya kemungkinan dicuri dan seluruh sesi dicabut.
+type RefreshToken struct {
+	ID        int        `gorm:"primaryKey;autoIncrement" json:"id"`
+	SessionID int        `gorm:"not null;index" json:"session_id"`
+	TokenHash string     `gorm:"type:varchar;not null;uniqueIndex" json:"-"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
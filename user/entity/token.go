@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+// Kegunaan token yang dikirim lewat email.
+const (
+	TokenPurposeEmailVerification = "email_verification"
+	TokenPurposePasswordReset     = "password_reset"
+)
+
+// UserToken adalah token sekali pakai yang dikirim ke email user. Yang
+// disimpan hanya hash SHA-256-nya sehingga token tidak bisa dipakai oleh
+// siapa pun yang bisa membaca database.
+type UserToken struct {
+	ID        int        `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID    int        `gorm:"not null;index" json:"user_id"`
+	Purpose   string     `gorm:"type:varchar;not null" json:"purpose"`
+	TokenHash string     `gorm:"type:varchar;not null;uniqueIndex" json:"-"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
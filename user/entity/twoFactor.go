@@ -0,0 +1,37 @@
+package entity
+
+import "time"
+
+// Cara user membuktikan faktor kedua.
+const (
+	SecondFactorTOTP         = "totp"
+	SecondFactorRecoveryCode = "recovery_code"
+)
+
+// TOTPSecret adalah secret authenticator milik user. Secret yang belum
+// dikonfirmasi dengan kode pertama (ConfirmedAt kosong) belum melindungi
+// apa pun dan diganti jika user mendaftar ulang.
+type TOTPSecret struct {
+	UserID      int        `gorm:"primaryKey;autoIncrement:false" json:"user_id"`
+	Secret      string     `gorm:"type:varchar;not null" json:"-"`
+	ConfirmedAt *time.Time `json:"confirmed_at"`
+	// LastStep adalah periode kode terakhir yang diterima, agar kode yang
+	// sama tidak bisa dipakai dua kali.
+	LastStep int64 `gorm:"not null;default:0" json:"-"`
+	// FailedAttempts menghitung kode salah berturut-turut; setelah terlalu
+	// banyak, faktor kedua dikunci sampai LockedUntil.
+	FailedAttempts int        `gorm:"not null;default:0" json:"-"`
+	LockedUntil    *time.Time `json:"locked_until"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// RecoveryCode adalah kode cadangan sekali pakai untuk user yang kehilangan
+// authenticator-nya. Seperti UserToken, yang disimpan hanya hash-nya.
+type RecoveryCode struct {
+	ID        int        `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID    int        `gorm:"not null;index" json:"user_id"`
+	CodeHash  string     `gorm:"type:varchar;not null" json:"-"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
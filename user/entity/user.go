@@ -9,6 +9,15 @@ type User struct {
 	Name      string    `gorm:"type:varchar;not null" json:"name" binding:"required"`                    
 	Email     string    `gorm:"type:varchar;uniqueIndex;not null" json:"email" binding:"required,email"` 
 	Password  string    `gorm:"type:varchar;not null" json:"password"`                                   
+	// Role menentukan RPC dan route yang boleh dipanggil user, lihat role.go.
+	Role      string    `gorm:"type:varchar;not null;default:customer" json:"role"`
+	// KYCLevel adalah level KYC terakhir yang terverifikasi, lihat kyc.go.
+	KYCLevel  string    `gorm:"type:varchar;not null;default:none" json:"kyc_level"`
+	// EmailVerifiedAt kosong selama user belum memverifikasi emailnya; user
+	// tersebut belum bisa login.
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	// TwoFactorEnabledAt terisi sejak user mengonfirmasi authenticator-nya.
+	TwoFactorEnabledAt *time.Time `json:"two_factor_enabled_at"`
 	CreatedAt time.Time `json:"created_at"`                                                              
 	UpdatedAt time.Time `json:"updated_at"`                                                              
 }
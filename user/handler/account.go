@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"context"
+
+	pb "github.com/susilo001/simple-wallet-system/user/proto/user/v1"
+)
+
+func (u *UserHandler) SendEmailVerification(ctx context.Context, req *pb.SendEmailVerificationRequest) (*pb.MutationResponse, error) {
+	if err := u.accountService.SendEmailVerification(ctx, req.GetEmail()); err != nil {
+		u.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return &pb.MutationResponse{
+		Message: "Jika email terdaftar dan belum diverifikasi, link verifikasi sudah dikirim",
+	}, nil
+}
+
+func (u *UserHandler) VerifyEmail(ctx context.Context, req *pb.VerifyEmailRequest) (*pb.MutationResponse, error) {
+	if err := u.accountService.VerifyEmail(ctx, req.GetToken()); err != nil {
+		u.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return &pb.MutationResponse{
+		Message: "Email berhasil diverifikasi",
+	}, nil
+}
+
+func (u *UserHandler) RequestPasswordReset(ctx context.Context, req *pb.RequestPasswordResetRequest) (*pb.MutationResponse, error) {
+	if err := u.accountService.RequestPasswordReset(ctx, req.GetEmail()); err != nil {
+		u.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return &pb.MutationResponse{
+		Message: "Jika email terdaftar, link reset password sudah dikirim",
+	}, nil
+}
+
+func (u *UserHandler) ResetPassword(ctx context.Context, req *pb.ResetPasswordRequest) (*pb.MutationResponse, error) {
+	userID, err := u.accountService.ResetPassword(ctx, req.GetToken(), req.GetNewPassword())
+	if err != nil {
+		u.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	// Sesi yang dibuat dengan password lama mungkin milik orang lain.
+	// Passwordnya sudah diganti, jadi kegagalan ini hanya di-log.
+	if _, err := u.sessionService.RevokeAllSessions(ctx, userID, 0); err != nil {
+		u.logger.ErrorContext(ctx, "Error revoking sessions after password reset", "user_id", userID, "error", err)
+	}
+	return &pb.MutationResponse{
+		Message: "Password berhasil diganti",
+	}, nil
+}
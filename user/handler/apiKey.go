@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/susilo001/simple-wallet-system/user/entity"
+	"github.com/susilo001/simple-wallet-system/user/pagination"
+	pb "github.com/susilo001/simple-wallet-system/user/proto/user/v1"
+	"github.com/susilo001/simple-wallet-system/user/service"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (u *UserHandler) CreateAPIKey(ctx context.Context, req *pb.CreateAPIKeyRequest) (*pb.CreateAPIKeyResponse, error) {
+	newKey := service.NewAPIKey{
+		Name:          req.GetName(),
+		Scopes:        req.GetScopes(),
+		TransferLimit: req.GetTransferLimit(),
+	}
+	if req.ExpiresAt != nil {
+		expiresAt := req.ExpiresAt.AsTime()
+		newKey.ExpiresAt = &expiresAt
+	}
+	apiKey, key, err := u.apiKeyService.CreateAPIKey(ctx, int(req.GetUserId()), newKey)
+	if err != nil {
+		u.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return &pb.CreateAPIKeyResponse{
+		ApiKey: toAPIKeyProto(apiKey),
+		Key:    key,
+	}, nil
+}
+
+func (u *UserHandler) ListAPIKeys(ctx context.Context, req *pb.ListAPIKeysRequest) (*pb.ListAPIKeysResponse, error) {
+	page, err := pagination.Parse(req.GetPageSize(), req.GetPageToken())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	keys, total, err := u.apiKeyService.GetAPIKeys(ctx, int(req.GetUserId()), page)
+	if err != nil {
+		u.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+
+	var keysProto []*pb.APIKey
+	for _, key := range keys {
+		keysProto = append(keysProto, toAPIKeyProto(key))
+	}
+	return &pb.ListAPIKeysResponse{
+		ApiKeys: keysProto,
+		Page:    toPageProto(page, total),
+	}, nil
+}
+
+func (u *UserHandler) RevokeAPIKey(ctx context.Context, req *pb.RevokeAPIKeyRequest) (*pb.APIKey, error) {
+	apiKey, err := u.apiKeyService.RevokeAPIKey(ctx, int(req.GetUserId()), int(req.GetId()))
+	if err != nil {
+		u.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return toAPIKeyProto(apiKey), nil
+}
+
+func (u *UserHandler) RotateAPIKey(ctx context.Context, req *pb.RotateAPIKeyRequest) (*pb.CreateAPIKeyResponse, error) {
+	apiKey, key, err := u.apiKeyService.RotateAPIKey(ctx, int(req.GetUserId()), int(req.GetId()))
+	if err != nil {
+		u.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return &pb.CreateAPIKeyResponse{
+		ApiKey: toAPIKeyProto(apiKey),
+		Key:    key,
+	}, nil
+}
+
+func (u *UserHandler) AuthenticateAPIKey(ctx context.Context, req *pb.AuthenticateAPIKeyRequest) (*pb.AuthenticateAPIKeyResponse, error) {
+	user, apiKey, err := u.apiKeyService.AuthenticateAPIKey(ctx, req.GetKey())
+	if err != nil {
+		u.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return &pb.AuthenticateAPIKeyResponse{
+		User:   toUserProto(user),
+		ApiKey: toAPIKeyProto(apiKey),
+	}, nil
+}
+
+func toAPIKeyProto(apiKey entity.APIKey) *pb.APIKey {
+	res := &pb.APIKey{
+		Id:            int32(apiKey.ID),
+		UserId:        int32(apiKey.UserID),
+		Name:          apiKey.Name,
+		Prefix:        apiKey.Prefix,
+		Scopes:        apiKey.ScopeList(),
+		TransferLimit: apiKey.TransferLimit,
+		CreatedAt:     timestamppb.New(apiKey.CreatedAt),
+	}
+	if apiKey.ExpiresAt != nil {
+		res.ExpiresAt = timestamppb.New(*apiKey.ExpiresAt)
+	}
+	if apiKey.LastUsedAt != nil {
+		res.LastUsedAt = timestamppb.New(*apiKey.LastUsedAt)
+	}
+	if apiKey.RevokedAt != nil {
+		res.RevokedAt = timestamppb.New(*apiKey.RevokedAt)
+	}
+	if apiKey.RotatedFromID != nil {
+		res.RotatedFromId = int32(*apiKey.RotatedFromID)
+	}
+	return res
+}
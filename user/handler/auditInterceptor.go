@@ -0,0 +1,270 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"path"
+
+	"github.com/susilo001/simple-wallet-system/user/entity"
+	pb "github.com/susilo001/simple-wallet-system/user/proto/user/v1"
+	"github.com/susilo001/simple-wallet-system/user/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Key metadata yang dipakai gateway untuk meneruskan siapa pemanggilnya.
+const (
+	actorMetadataKey     = "x-actor"
+	requestIDMetadataKey = "x-request-id"
+)
+
+const redacted = "[REDACTED]"
+
+// auditTargets memetakan setiap RPC yang mengubah data ke ID user yang
+// diubahnya (0 jika belum ada). RPC yang tidak ada di sini tidak diaudit.
+var auditTargets = map[string]func(req any) int{
+	"CreateUser": func(req any) int { return 0 },
+	"UpdateUser": func(req any) int { return int(req.(*pb.UpdateUserRequest).GetUser().GetId()) },
+	"DeleteUser": func(req any) int { return int(req.(*pb.DeleteUserRequest).GetId()) },
+	"AssignRole": func(req any) int { return int(req.(*pb.AssignRoleRequest).GetId()) },
+	"SubmitKYC":  func(req any) int { return int(req.(*pb.SubmitKYCRequest).GetUserId()) },
+	// Pengajuannya tercatat di response dengan nomor dokumen yang disamarkan;
+	// level user yang berubah tidak tercatat sebagai before/after karena ID
+	// user tidak ada di request.
+	"ReviewKYCSubmission": func(req any) int { return 0 },
+	// Pemilik token baru diketahui service setelah tokennya diperiksa.
+	"VerifyEmail":   func(req any) int { return 0 },
+	"ResetPassword": func(req any) int { return 0 },
+	// Response EnrollTOTP, ConfirmTOTP dan RegenerateRecoveryCodes berisi
+	// secret dan kode cadangan, jadi targetnya harus user agar response
+	// tidak dicatat.
+	"EnrollTOTP":              func(req any) int { return int(req.(*pb.EnrollTOTPRequest).GetUserId()) },
+	"ConfirmTOTP":             func(req any) int { return int(req.(*pb.ConfirmTOTPRequest).GetUserId()) },
+	"DisableTOTP":             func(req any) int { return int(req.(*pb.DisableTOTPRequest).GetUserId()) },
+	"RegenerateRecoveryCodes": func(req any) int { return int(req.(*pb.RegenerateRecoveryCodesRequest).GetUserId()) },
+	"VerifySecondFactor":      func(req any) int { return int(req.(*pb.VerifySecondFactorRequest).GetUserId()) },
+	// Login dan RefreshSession tidak diaudit, seperti Authenticate; sesinya
+	// sendiri mencatat perangkat dan waktu terakhir dipakai.
+	"RevokeSession":     func(req any) int { return int(req.(*pb.RevokeSessionRequest).GetUserId()) },
+	"RevokeAllSessions": func(req any) int { return int(req.(*pb.RevokeAllSessionsRequest).GetUserId()) },
+	// Response CreateAPIKey dan RotateAPIKey berisi key, jadi targetnya
+	// harus user agar response tidak dicatat.
+	"CreateAPIKey": func(req any) int { return int(req.(*pb.CreateAPIKeyRequest).GetUserId()) },
+	"RotateAPIKey": func(req any) int { return int(req.(*pb.RotateAPIKeyRequest).GetUserId()) },
+	"RevokeAPIKey": func(req any) int { return int(req.(*pb.RevokeAPIKeyRequest).GetUserId()) },
+}
+
+// auditedUser adalah data user yang dicatat di log audit, tanpa password.
+type auditedUser struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Role  string `json:"role"`
+	// KYCLevel dicatat agar kenaikan level KYC terlihat di log audit.
+	KYCLevel string `json:"kyc_level"`
+	// TwoFactorEnabled dicatat agar 2FA yang dimatikan terlihat di log audit.
+	TwoFactorEnabled bool `json:"two_factor_enabled"`
+}
+
+// NewAuditInterceptor mencatat setiap panggilan RPC yang mengubah data ke log
+// audit, baik berhasil maupun gagal. Kegagalan mencatat hanya di-log karena
+// perubahannya sudah terjadi.
+func NewAuditInterceptor(auditService service.IAuditService, userService service.IUserService, logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		targetOf, ok := auditTargets[path.Base(info.FullMethod)]
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		userID := targetOf(req)
+		entry := entity.AuditEntry{
+			Action:  info.FullMethod,
+			Target:  "user",
+			Request: auditJSON(redactRequest(req)),
+		}
+		entry.Actor, entry.RequestID = callerFromContext(ctx)
+		if userID != 0 {
+			entry.Target = fmt.Sprintf("user:%d", userID)
+			entry.Before = userState(ctx, userService, logger, userID)
+		}
+
+		resp, err := handler(ctx, req)
+
+		entry.Outcome = status.Code(err).String()
+		if err != nil {
+			entry.Error = status.Convert(err).Message()
+		}
+		if userID != 0 {
+			entry.After = userState(ctx, userService, logger, userID)
+		} else if err == nil {
+			entry.After = auditJSON(redactResponse(resp))
+		}
+		if _, auditErr := auditService.Record(context.WithoutCancel(ctx), entry); auditErr != nil {
+			logger.ErrorContext(ctx, "Error recording audit entry", "error", auditErr)
+		}
+		return resp, err
+	}
+}
+
+func (u *UserHandler) ListAuditEntries(ctx context.Context, req *pb.ListAuditEntriesRequest) (*pb.ListAuditEntriesResponse, error) {
+	filter := service.AuditFilter{
+		Actor:         req.GetActor(),
+		Action:        req.GetAction(),
+		Target:        req.GetTarget(),
+		RequestID:     req.GetRequestId(),
+		AfterSequence: req.GetAfterSequence(),
+		Limit:         int(req.GetLimit()),
+	}
+	if req.From != nil {
+		filter.From = req.GetFrom().AsTime()
+	}
+	if req.To != nil {
+		filter.To = req.GetTo().AsTime()
+	}
+
+	entries, err := u.auditService.ListAuditEntries(ctx, filter)
+	if err != nil {
+		u.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, err
+	}
+
+	var entriesProto []*pb.AuditEntry
+	for _, entry := range entries {
+		entriesProto = append(entriesProto, &pb.AuditEntry{
+			Sequence:  entry.Sequence,
+			Actor:     entry.Actor,
+			Action:    entry.Action,
+			Target:    entry.Target,
+			Request:   entry.Request,
+			Before:    entry.Before,
+			After:     entry.After,
+			Outcome:   entry.Outcome,
+			Error:     entry.Error,
+			RequestId: entry.RequestID,
+			CreatedAt: timestamppb.New(entry.CreatedAt),
+			PrevHash:  entry.PrevHash,
+			Hash:      entry.Hash,
+		})
+	}
+	return &pb.ListAuditEntriesResponse{
+		Entries: entriesProto,
+	}, nil
+}
+
+func callerFromContext(ctx context.Context) (actor string, requestID string) {
+	actor = "anonymous"
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return actor, ""
+	}
+	if values := md.Get(actorMetadataKey); len(values) > 0 && values[0] != "" {
+		actor = values[0]
+	}
+	if values := md.Get(requestIDMetadataKey); len(values) > 0 {
+		requestID = values[0]
+	}
+	return actor, requestID
+}
+
+// redactRequest mengembalikan salinan request tanpa password, token dan
+// kode 2FA, dan hanya dengan empat karakter terakhir nomor dokumen KYC.
+func redactRequest(req any) any {
+	switch r := req.(type) {
+	case *pb.CreateUserRequest:
+		r = proto.Clone(r).(*pb.CreateUserRequest)
+		if r.Password != "" {
+			r.Password = redacted
+		}
+		return r
+	case *pb.UpdateUserRequest:
+		r = proto.Clone(r).(*pb.UpdateUserRequest)
+		if r.User != nil && r.User.Password != "" {
+			r.User.Password = redacted
+		}
+		return r
+	case *pb.SubmitKYCRequest:
+		r = proto.Clone(r).(*pb.SubmitKYCRequest)
+		r.DocumentNumber = maskDocumentNumber(r.DocumentNumber)
+		return r
+	case *pb.VerifyEmailRequest:
+		r = proto.Clone(r).(*pb.VerifyEmailRequest)
+		r.Token = redacted
+		return r
+	case *pb.ResetPasswordRequest:
+		r = proto.Clone(r).(*pb.ResetPasswordRequest)
+		r.Token = redacted
+		r.NewPassword = redacted
+		return r
+	case *pb.ConfirmTOTPRequest:
+		r = proto.Clone(r).(*pb.ConfirmTOTPRequest)
+		r.Code = redacted
+		return r
+	case *pb.DisableTOTPRequest:
+		r = proto.Clone(r).(*pb.DisableTOTPRequest)
+		r.Code = redacted
+		return r
+	case *pb.RegenerateRecoveryCodesRequest:
+		r = proto.Clone(r).(*pb.RegenerateRecoveryCodesRequest)
+		r.Code = redacted
+		return r
+	case *pb.VerifySecondFactorRequest:
+		r = proto.Clone(r).(*pb.VerifySecondFactorRequest)
+		r.Code = redacted
+		return r
+	}
+	return req
+}
+
+// redactResponse mengembalikan salinan response yang dicatat sebagai after
+// dengan nomor dokumen KYC yang disamarkan seperti di redactRequest.
+func redactResponse(resp any) any {
+	switch r := resp.(type) {
+	case *pb.KYCSubmission:
+		r = proto.Clone(r).(*pb.KYCSubmission)
+		r.DocumentNumber = maskDocumentNumber(r.DocumentNumber)
+		return r
+	}
+	return resp
+}
+
+// maskDocumentNumber hanya menyisakan empat karakter terakhir nomor dokumen.
+func maskDocumentNumber(number string) string {
+	if len(number) <= 4 {
+		return number
+	}
+	return redacted + number[len(number)-4:]
+}
+
+func userState(ctx context.Context, userService service.IUserService, logger *slog.Logger, id int) string {
+	user, err := userService.GetUserByID(ctx, id)
+	if err != nil {
+		logger.ErrorContext(ctx, "Error loading user for audit", "user_id", id, "error", err)
+		return ""
+	}
+	if user.ID == 0 {
+		return "null"
+	}
+	data, err := json.Marshal(auditedUser{ID: user.ID, Name: user.Name, Email: user.Email, Role: user.Role, KYCLevel: user.KYCLevel, TwoFactorEnabled: user.TwoFactorEnabledAt != nil})
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+func auditJSON(v any) string {
+	message, ok := v.(proto.Message)
+	if !ok {
+		return ""
+	}
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(message)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
@@ -0,0 +1,228 @@
+package handler
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/base64"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/susilo001/simple-wallet-system/user/entity"
+	pb "github.com/susilo001/simple-wallet-system/user/proto/user/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Key metadata yang dipakai gateway untuk meneruskan identitas pemanggil
+// yang sudah diautentikasi. Metadata ini hanya dipercaya jika panggilannya
+// membawa kredensial layanan di authorization.
+const (
+	authorizationMetadataKey = "authorization"
+	userIDMetadataKey        = "x-user-id"
+	roleMetadataKey          = "x-role"
+	// sessionIDMetadataKey adalah sesi pemanggil, jika login dengan access
+	// token.
+	sessionIDMetadataKey = "x-session-id"
+)
+
+// roleAnonymous adalah role pemanggil yang belum login.
+const roleAnonymous = "anonymous"
+
+var (
+	everyone = []string{roleAnonymous, entity.RoleCustomer, entity.RoleSupport, entity.RoleFinance, entity.RoleAdmin}
+	staff    = []string{entity.RoleSupport, entity.RoleFinance, entity.RoleAdmin}
+)
+
+// caller adalah identitas pemanggil sebuah RPC.
+type caller struct {
+	UserID int
+	Role   string
+}
+
+// permission menentukan siapa yang boleh memanggil sebuah RPC. Pemanggil
+// dengan salah satu Roles selalu boleh. Jika Self diisi, user yang login juga
+// boleh memanggilnya untuk dirinya sendiri, yaitu jika Self(req) sama dengan
+// ID-nya.
+type permission struct {
+	Roles []string
+	Self  func(req any) int
+}
+
+// permissions memetakan setiap RPC ke siapa yang boleh memanggilnya. RPC
+// yang tidak ada di sini tidak boleh dipanggil siapa pun.
+var permissions = map[string]permission{
+	"Authenticate": {Roles: everyone},
+	"CreateUser":   {Roles: everyone},
+	"GetUser": {
+		Roles: staff,
+		Self:  func(req any) int { return int(req.(*pb.GetUserRequest).GetId()) },
+	},
+	"UpdateUser": {
+		Roles: []string{entity.RoleAdmin},
+		Self:  func(req any) int { return int(req.(*pb.UpdateUserRequest).GetUser().GetId()) },
+	},
+	"GetUsers":         {Roles: []string{entity.RoleSupport, entity.RoleAdmin}},
+	"ListAuditEntries": {Roles: []string{entity.RoleSupport, entity.RoleAdmin}},
+	"DeleteUser":       {Roles: []string{entity.RoleAdmin}},
+	"AssignRole":       {Roles: []string{entity.RoleAdmin}},
+	"SubmitKYC": {
+		Self: func(req any) int { return int(req.(*pb.SubmitKYCRequest).GetUserId()) },
+	},
+	"ListKYCSubmissions": {
+		Roles: staff,
+		Self:  func(req any) int { return int(req.(*pb.ListKYCSubmissionsRequest).GetUserId()) },
+	},
+	"ReviewKYCSubmission": {Roles: []string{entity.RoleSupport, entity.RoleAdmin}},
+	// GetKYCLevel dipanggil wallet service tanpa pemanggil, tetapi tetap
+	// membutuhkan kredensial layanan.
+	"GetKYCLevel": {Roles: everyone},
+	// Verifikasi email dan reset password dipakai user yang belum bisa
+	// login; tokennya yang membuktikan pemilik email.
+	"SendEmailVerification": {Roles: everyone},
+	"VerifyEmail":           {Roles: everyone},
+	"RequestPasswordReset":  {Roles: everyone},
+	"ResetPassword":         {Roles: everyone},
+	// 2FA hanya bisa diatur pemiliknya, karena staf tidak boleh memegang
+	// secret atau kode cadangan user.
+	"EnrollTOTP": {
+		Self: func(req any) int { return int(req.(*pb.EnrollTOTPRequest).GetUserId()) },
+	},
+	"ConfirmTOTP": {
+		Self: func(req any) int { return int(req.(*pb.ConfirmTOTPRequest).GetUserId()) },
+	},
+	"DisableTOTP": {
+		Self: func(req any) int { return int(req.(*pb.DisableTOTPRequest).GetUserId()) },
+	},
+	"RegenerateRecoveryCodes": {
+		Self: func(req any) int { return int(req.(*pb.RegenerateRecoveryCodesRequest).GetUserId()) },
+	},
+	"VerifySecondFactor": {
+		Self: func(req any) int { return int(req.(*pb.VerifySecondFactorRequest).GetUserId()) },
+	},
+	// Login dan refresh dipanggil sebelum pemanggil punya sesi; gateway
+	// memanggil AuthenticateAccessToken untuk mengenali pemanggilnya.
+	"Login":                   {Roles: everyone},
+	"RefreshSession":          {Roles: everyone},
+	"AuthenticateAccessToken": {Roles: everyone},
+	"ListSessions": {
+		Roles: []string{entity.RoleSupport, entity.RoleAdmin},
+		Self:  func(req any) int { return int(req.(*pb.ListSessionsRequest).GetUserId()) },
+	},
+	"RevokeSession": {
+		Roles: []string{entity.RoleSupport, entity.RoleAdmin},
+		Self:  func(req any) int { return int(req.(*pb.RevokeSessionRequest).GetUserId()) },
+	},
+	"RevokeAllSessions": {
+		Roles: []string{entity.RoleSupport, entity.RoleAdmin},
+		Self:  func(req any) int { return int(req.(*pb.RevokeAllSessionsRequest).GetUserId()) },
+	},
+	// API key hanya dibuat dan dirotasi pemiliknya, karena nilai key-nya
+	// dikembalikan ke pemanggil. Support boleh melihat dan mencabutnya.
+	"CreateAPIKey": {
+		Self: func(req any) int { return int(req.(*pb.CreateAPIKeyRequest).GetUserId()) },
+	},
+	"RotateAPIKey": {
+		Self: func(req any) int { return int(req.(*pb.RotateAPIKeyRequest).GetUserId()) },
+	},
+	"ListAPIKeys": {
+		Roles: []string{entity.RoleSupport, entity.RoleAdmin},
+		Self:  func(req any) int { return int(req.(*pb.ListAPIKeysRequest).GetUserId()) },
+	},
+	"RevokeAPIKey": {
+		Roles: []string{entity.RoleSupport, entity.RoleAdmin},
+		Self:  func(req any) int { return int(req.(*pb.RevokeAPIKeyRequest).GetUserId()) },
+	},
+	"AuthenticateAPIKey": {Roles: everyone},
+}
+
+func (p permission) allows(c caller, req any) bool {
+	for _, role := range p.Roles {
+		if role == c.Role {
+			return true
+		}
+	}
+	return p.Self != nil && c.Role != roleAnonymous && p.Self(req) == c.UserID
+}
+
+// NewAuthInterceptor hanya menerima panggilan yang membawa kredensial
+// layanan username dan password, lalu memeriksa apakah pemanggil yang
+// diteruskan gateway boleh memanggil RPC tersebut.
+func NewAuthInterceptor(username string, password string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if !hasServiceCredentials(md, username, password) {
+			return nil, status.Error(codes.Unauthenticated, "kredensial layanan tidak valid")
+		}
+		c, err := callerFromMetadata(md)
+		if err != nil {
+			return nil, err
+		}
+
+		if perm, ok := permissions[path.Base(info.FullMethod)]; !ok || !perm.allows(c, req) {
+			if c.Role == roleAnonymous {
+				return nil, status.Error(codes.Unauthenticated, "login diperlukan")
+			}
+			return nil, status.Errorf(codes.PermissionDenied, "role %s tidak boleh memanggil %s", c.Role, path.Base(info.FullMethod))
+		}
+		return handler(ctx, req)
+	}
+}
+
+func hasServiceCredentials(md metadata.MD, username string, password string) bool {
+	values := md.Get(authorizationMetadataKey)
+	if len(values) == 0 {
+		return false
+	}
+	encoded, ok := strings.CutPrefix(values[0], "Basic ")
+	if !ok {
+		return false
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return false
+	}
+	gotUsername, gotPassword, ok := strings.Cut(string(decoded), ":")
+	if !ok {
+		return false
+	}
+	usernameMatch := subtle.ConstantTimeCompare([]byte(gotUsername), []byte(username))
+	passwordMatch := subtle.ConstantTimeCompare([]byte(gotPassword), []byte(password))
+	return usernameMatch&passwordMatch == 1
+}
+
+// callerFromMetadata membaca pemanggil dari metadata. Panggilan tanpa
+// x-role dianggap anonim.
+func callerFromMetadata(md metadata.MD) (caller, error) {
+	c := caller{Role: roleAnonymous}
+	if values := md.Get(roleMetadataKey); len(values) > 0 && values[0] != "" {
+		if !entity.IsValidRole(values[0]) {
+			return caller{}, status.Errorf(codes.Unauthenticated, "role %q tidak dikenal", values[0])
+		}
+		c.Role = values[0]
+	}
+	if values := md.Get(userIDMetadataKey); len(values) > 0 && values[0] != "" {
+		userID, err := strconv.Atoi(values[0])
+		if err != nil || userID <= 0 {
+			return caller{}, status.Errorf(codes.Unauthenticated, "user ID %q tidak valid", values[0])
+		}
+		c.UserID = userID
+	}
+	if c.Role != roleAnonymous && c.UserID == 0 {
+		return caller{}, status.Error(codes.Unauthenticated, "user ID diperlukan untuk role "+c.Role)
+	}
+	return c, nil
+}
+
+// callerSessionID mengembalikan sesi pemanggil yang diteruskan gateway, atau
+// 0 jika pemanggil tidak login dengan access token.
+func callerSessionID(ctx context.Context) int {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(sessionIDMetadataKey); len(values) > 0 {
+		sessionID, _ := strconv.Atoi(values[0])
+		return sessionID
+	}
+	return 0
+}
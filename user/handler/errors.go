@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/susilo001/simple-wallet-system/user/pagination"
+	"github.com/susilo001/simple-wallet-system/user/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// toStatusError memetakan error dari service ke kode status gRPC.
+func toStatusError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrUserNotFound), errors.Is(err, service.ErrKYCSubmissionNotFound),
+		errors.Is(err, service.ErrSessionNotFound), errors.Is(err, service.ErrAPIKeyNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrInvalidRole), errors.Is(err, service.ErrInvalidKYCSubmission),
+		errors.Is(err, service.ErrInvalidToken), errors.Is(err, service.ErrWeakPassword),
+		errors.Is(err, service.ErrInvalidAPIKey), errors.Is(err, pagination.ErrInvalidPageSize),
+		errors.Is(err, pagination.ErrInvalidPageToken):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrKYCSubmissionPending), errors.Is(err, service.ErrKYCSubmissionClosed),
+		errors.Is(err, service.ErrTwoFactorEnabled), errors.Is(err, service.ErrTwoFactorNotEnabled):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, service.ErrEmailNotVerified):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, service.ErrSecondFactorLocked):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, service.ErrInvalidCredentials), errors.Is(err, service.ErrInvalidSecondFactor),
+		errors.Is(err, service.ErrInvalidSession), errors.Is(err, service.ErrAPIKeyRejected):
+		return status.Error(codes.Unauthenticated, err.Error())
+	default:
+		return err
+	}
+}
@@ -3,70 +3,73 @@ package handler
 import (
 	"context"
 	"fmt"
-
-	"log"
+	"log/slog"
 
 	"github.com/susilo001/simple-wallet-system/user/entity"
+	"github.com/susilo001/simple-wallet-system/user/pagination"
 	pb "github.com/susilo001/simple-wallet-system/user/proto/user/v1"
 	"github.com/susilo001/simple-wallet-system/user/service"
 
-	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // UserHandler is used to implement UnimplementedUserServiceServer
 type UserHandler struct {
 	pb.UnimplementedUserServiceServer
-	userService service.IUserService
+	userService      service.IUserService
+	auditService     service.IAuditService
+	kycService       service.IKYCService
+	accountService   service.IAccountService
+	twoFactorService service.ITwoFactorService
+	sessionService   service.ISessionService
+	apiKeyService    service.IAPIKeyService
+	logger           *slog.Logger
 }
 
 // NewUserHandler membuat instance baru dari UserHandler
-func NewUserHandler(userService service.IUserService) *UserHandler {
+func NewUserHandler(userService service.IUserService, auditService service.IAuditService, kycService service.IKYCService, accountService service.IAccountService, twoFactorService service.ITwoFactorService, sessionService service.ISessionService, apiKeyService service.IAPIKeyService, logger *slog.Logger) *UserHandler {
 	return &UserHandler{
-		userService: userService,
+		userService:      userService,
+		auditService:     auditService,
+		kycService:       kycService,
+		accountService:   accountService,
+		twoFactorService: twoFactorService,
+		sessionService:   sessionService,
+		apiKeyService:    apiKeyService,
+		logger:           logger,
 	}
 }
 
-func (u *UserHandler) GetUsers(ctx context.Context, _ *emptypb.Empty) (*pb.GetUsersResponse, error) {
-	users, err := u.userService.GetAllUsers(ctx)
+func (u *UserHandler) GetUsers(ctx context.Context, req *pb.GetUsersRequest) (*pb.GetUsersResponse, error) {
+	page, err := pagination.Parse(req.GetPageSize(), req.GetPageToken())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	users, total, err := u.userService.GetUsers(ctx, page)
 	if err != nil {
-		log.Println(err)
+		u.logger.WarnContext(ctx, "request failed", "error", err)
 		return nil, err
 	}
 
 	var usersProto []*pb.User
 	for _, user := range users {
-		usersProto = append(usersProto, &pb.User{
-			Id:        int32(user.ID),
-			Name:      user.Name,
-			Email:     user.Email,
-			Password:  user.Password,
-			CreatedAt: timestamppb.New(user.CreatedAt),
-			UpdatedAt: timestamppb.New(user.UpdatedAt),
-		})
+		usersProto = append(usersProto, toUserProto(user))
 	}
 
 	return &pb.GetUsersResponse{
 		User: usersProto,
+		Page: toPageProto(page, total),
 	}, nil
 }
 func (u *UserHandler) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.GetUserResponse, error) {
 	user, err := u.userService.GetUserByID(ctx, int(req.GetId()))
 	if err != nil {
-		log.Println(err)
+		u.logger.WarnContext(ctx, "request failed", "error", err)
 		return nil, err
 	}
-	res := &pb.GetUserResponse{
-		User: &pb.User{
-			Id:        int32(user.ID),
-			Name:      user.Name,
-			Email:     user.Email,
-			Password:  user.Password,
-			CreatedAt: timestamppb.New(user.CreatedAt),
-			UpdatedAt: timestamppb.New(user.UpdatedAt),
-		},
-	}
-	return res, nil
+	return &pb.GetUserResponse{
+		User: toUserProto(user),
+	}, nil
 }
 
 func (u *UserHandler) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.MutationResponse, error) {
@@ -76,21 +79,41 @@ func (u *UserHandler) CreateUser(ctx context.Context, req *pb.CreateUserRequest)
 		Password: req.GetPassword(),
 	})
 	if err != nil {
-		log.Println(err)
+		u.logger.WarnContext(ctx, "request failed", "error", err)
 		return nil, err
 	}
+	// User tetap terdaftar walaupun email verifikasinya gagal dikirim; link
+	// baru bisa diminta lewat SendEmailVerification.
+	if err := u.accountService.SendEmailVerification(ctx, createdUser.Email); err != nil {
+		u.logger.ErrorContext(ctx, "Error sending email verification", "user_id", createdUser.ID, "error", err)
+	}
 	return &pb.MutationResponse{
 		Message: fmt.Sprintf("Success created user with ID %d", createdUser.ID),
 	}, nil
 }
 func (u *UserHandler) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.MutationResponse, error) {
+	updatedUser, err := u.userService.UpdateUser(ctx, int(req.GetUser().GetId()), entity.User{
+		Name:     req.GetUser().GetName(),
+		Email:    req.GetUser().GetEmail(),
+		Password: req.GetUser().GetPassword(),
+	})
+	if err != nil {
+		u.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	// Email baru harus diverifikasi seperti saat mendaftar.
+	if updatedUser.EmailVerifiedAt == nil {
+		if err := u.accountService.SendEmailVerification(ctx, updatedUser.Email); err != nil {
+			u.logger.ErrorContext(ctx, "Error sending email verification", "user_id", updatedUser.ID, "error", err)
+		}
+	}
 	return &pb.MutationResponse{
-		Message: fmt.Sprintf("Success update user with ID %d", req.User.Id),
+		Message: fmt.Sprintf("Success update user with ID %d", updatedUser.ID),
 	}, nil
 }
 func (u *UserHandler) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest) (*pb.MutationResponse, error) {
 	if err := u.userService.DeleteUser(ctx, int(req.GetId())); err != nil {
-		log.Println(err)
+		u.logger.WarnContext(ctx, "request failed", "error", err)
 		return nil, err
 	}
 
@@ -98,3 +121,43 @@ func (u *UserHandler) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest)
 		Message: fmt.Sprintf("Success deleted user with ID %d", req.GetId()),
 	}, nil
 }
+
+func (u *UserHandler) Authenticate(ctx context.Context, req *pb.AuthenticateRequest) (*pb.AuthenticateResponse, error) {
+	user, err := u.userService.Authenticate(ctx, req.GetEmail(), req.GetPassword())
+	if err != nil {
+		u.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return &pb.AuthenticateResponse{
+		User: toUserProto(user),
+	}, nil
+}
+
+func (u *UserHandler) AssignRole(ctx context.Context, req *pb.AssignRoleRequest) (*pb.User, error) {
+	user, err := u.userService.AssignRole(ctx, int(req.GetId()), req.GetRole())
+	if err != nil {
+		u.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return toUserProto(user), nil
+}
+
+// toUserProto mengubah user ke proto tanpa password.
+func toUserProto(user entity.User) *pb.User {
+	res := &pb.User{
+		Id:        int32(user.ID),
+		Name:      user.Name,
+		Email:     user.Email,
+		Role:      user.Role,
+		KycLevel:  user.KYCLevel,
+		CreatedAt: timestamppb.New(user.CreatedAt),
+		UpdatedAt: timestamppb.New(user.UpdatedAt),
+	}
+	if user.EmailVerifiedAt != nil {
+		res.EmailVerifiedAt = timestamppb.New(*user.EmailVerifiedAt)
+	}
+	if user.TwoFactorEnabledAt != nil {
+		res.TwoFactorEnabledAt = timestamppb.New(*user.TwoFactorEnabledAt)
+	}
+	return res
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/susilo001/simple-wallet-system/user/entity"
+	"github.com/susilo001/simple-wallet-system/user/pagination"
+	pb "github.com/susilo001/simple-wallet-system/user/proto/user/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (u *UserHandler) SubmitKYC(ctx context.Context, req *pb.SubmitKYCRequest) (*pb.KYCSubmission, error) {
+	submission := entity.KYCSubmission{
+		UserID:          int(req.GetUserId()),
+		Level:           req.GetLevel(),
+		DocumentType:    req.GetDocumentType(),
+		DocumentNumber:  req.GetDocumentNumber(),
+		DocumentCountry: req.GetDocumentCountry(),
+	}
+	if req.DocumentExpiry != nil {
+		submission.DocumentExpiry = req.GetDocumentExpiry().AsTime()
+	}
+
+	submission, err := u.kycService.SubmitKYC(ctx, submission)
+	if err != nil {
+		u.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return toKYCSubmissionProto(submission), nil
+}
+
+func (u *UserHandler) ListKYCSubmissions(ctx context.Context, req *pb.ListKYCSubmissionsRequest) (*pb.ListKYCSubmissionsResponse, error) {
+	page, err := pagination.Parse(req.GetPageSize(), req.GetPageToken())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	submissions, total, err := u.kycService.GetKYCSubmissions(ctx, int(req.GetUserId()), req.GetStatus(), page)
+	if err != nil {
+		u.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+
+	var submissionsProto []*pb.KYCSubmission
+	for _, submission := range submissions {
+		submissionsProto = append(submissionsProto, toKYCSubmissionProto(submission))
+	}
+	return &pb.ListKYCSubmissionsResponse{
+		Submissions: submissionsProto,
+		Page:        toPageProto(page, total),
+	}, nil
+}
+
+func (u *UserHandler) ReviewKYCSubmission(ctx context.Context, req *pb.ReviewKYCSubmissionRequest) (*pb.KYCSubmission, error) {
+	submission, err := u.kycService.ReviewKYCSubmission(ctx, int(req.GetId()), req.GetApprove(), req.GetReviewer(), req.GetReason())
+	if err != nil {
+		u.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return toKYCSubmissionProto(submission), nil
+}
+
+func (u *UserHandler) GetKYCLevel(ctx context.Context, req *pb.GetKYCLevelRequest) (*pb.GetKYCLevelResponse, error) {
+	level, err := u.kycService.GetKYCLevel(ctx, int(req.GetUserId()))
+	if err != nil {
+		u.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return &pb.GetKYCLevelResponse{
+		UserId: req.GetUserId(),
+		Level:  level,
+	}, nil
+}
+
+func toKYCSubmissionProto(submission entity.KYCSubmission) *pb.KYCSubmission {
+	res := &pb.KYCSubmission{
+		Id:              int32(submission.ID),
+		UserId:          int32(submission.UserID),
+		Level:           submission.Level,
+		Status:          submission.Status,
+		DocumentType:    submission.DocumentType,
+		DocumentNumber:  submission.DocumentNumber,
+		DocumentCountry: submission.DocumentCountry,
+		DocumentExpiry:  timestamppb.New(submission.DocumentExpiry),
+		Reason:          submission.Reason,
+		ReviewedBy:      submission.ReviewedBy,
+		CreatedAt:       timestamppb.New(submission.CreatedAt),
+	}
+	if submission.ReviewedAt != nil {
+		res.ReviewedAt = timestamppb.New(*submission.ReviewedAt)
+	}
+	return res
+}
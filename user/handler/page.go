@@ -0,0 +1,14 @@
+package handler
+
+import (
+	"github.com/susilo001/simple-wallet-system/user/pagination"
+	pb "github.com/susilo001/simple-wallet-system/user/proto/user/v1"
+)
+
+// toPageProto menjelaskan halaman page dari list berisi total item.
+func toPageProto(page pagination.Page, total int) *pb.Page {
+	return &pb.Page{
+		NextPageToken: page.NextPageToken(total),
+		TotalSize:     int32(total),
+	}
+}
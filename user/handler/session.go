@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/susilo001/simple-wallet-system/user/entity"
+	"github.com/susilo001/simple-wallet-system/user/pagination"
+	pb "github.com/susilo001/simple-wallet-system/user/proto/user/v1"
+	"github.com/susilo001/simple-wallet-system/user/service"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (u *UserHandler) Login(ctx context.Context, req *pb.LoginRequest) (*pb.SessionTokens, error) {
+	tokens, err := u.sessionService.Login(ctx, req.GetEmail(), req.GetPassword(), service.SessionInfo{
+		Device:    req.GetDevice(),
+		IP:        req.GetIp(),
+		UserAgent: req.GetUserAgent(),
+	})
+	if err != nil {
+		u.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return toSessionTokensProto(tokens), nil
+}
+
+func (u *UserHandler) RefreshSession(ctx context.Context, req *pb.RefreshSessionRequest) (*pb.SessionTokens, error) {
+	tokens, err := u.sessionService.Refresh(ctx, req.GetRefreshToken(), service.SessionInfo{
+		IP:        req.GetIp(),
+		UserAgent: req.GetUserAgent(),
+	})
+	if err != nil {
+		u.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return toSessionTokensProto(tokens), nil
+}
+
+func (u *UserHandler) AuthenticateAccessToken(ctx context.Context, req *pb.AuthenticateAccessTokenRequest) (*pb.AuthenticateResponse, error) {
+	user, session, err := u.sessionService.AuthenticateAccessToken(ctx, req.GetAccessToken())
+	if err != nil {
+		u.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return &pb.AuthenticateResponse{
+		User:      toUserProto(user),
+		SessionId: int32(session.ID),
+	}, nil
+}
+
+func (u *UserHandler) ListSessions(ctx context.Context, req *pb.ListSessionsRequest) (*pb.ListSessionsResponse, error) {
+	page, err := pagination.Parse(req.GetPageSize(), req.GetPageToken())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	sessions, total, err := u.sessionService.GetSessions(ctx, int(req.GetUserId()), page)
+	if err != nil {
+		u.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+
+	var sessionsProto []*pb.Session
+	for _, session := range sessions {
+		sessionsProto = append(sessionsProto, &pb.Session{
+			Id:         int32(session.ID),
+			UserId:     int32(session.UserID),
+			Device:     session.Device,
+			Ip:         session.IP,
+			UserAgent:  session.UserAgent,
+			CreatedAt:  timestamppb.New(session.CreatedAt),
+			LastSeenAt: timestamppb.New(session.LastSeenAt),
+			ExpiresAt:  timestamppb.New(session.ExpiresAt),
+		})
+	}
+	return &pb.ListSessionsResponse{
+		Sessions: sessionsProto,
+		Page:     toPageProto(page, total),
+	}, nil
+}
+
+func (u *UserHandler) RevokeSession(ctx context.Context, req *pb.RevokeSessionRequest) (*pb.MutationResponse, error) {
+	// Sesi yang dicabut pemanggil dari sesi itu sendiri adalah logout
+	reason := entity.SessionRevokedByUser
+	if callerSessionID(ctx) == int(req.GetSessionId()) {
+		reason = entity.SessionRevokedLogout
+	}
+	if err := u.sessionService.RevokeSession(ctx, int(req.GetUserId()), int(req.GetSessionId()), reason); err != nil {
+		u.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return &pb.MutationResponse{
+		Message: fmt.Sprintf("Sesi %d berhasil dicabut", req.GetSessionId()),
+	}, nil
+}
+
+func (u *UserHandler) RevokeAllSessions(ctx context.Context, req *pb.RevokeAllSessionsRequest) (*pb.MutationResponse, error) {
+	count, err := u.sessionService.RevokeAllSessions(ctx, int(req.GetUserId()), int(req.GetExceptSessionId()))
+	if err != nil {
+		u.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return &pb.MutationResponse{
+		Message: fmt.Sprintf("%d sesi berhasil dicabut", count),
+	}, nil
+}
+
+func toSessionTokensProto(tokens service.SessionTokens) *pb.SessionTokens {
+	return &pb.SessionTokens{
+		SessionId:             int32(tokens.SessionID),
+		AccessToken:           tokens.AccessToken,
+		AccessTokenExpiresAt:  timestamppb.New(tokens.AccessExpiresAt),
+		RefreshToken:          tokens.RefreshToken,
+		RefreshTokenExpiresAt: timestamppb.New(tokens.RefreshExpiresAt),
+	}
+}
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"context"
+
+	pb "github.com/susilo001/simple-wallet-system/user/proto/user/v1"
+)
+
+func (u *UserHandler) EnrollTOTP(ctx context.Context, req *pb.EnrollTOTPRequest) (*pb.EnrollTOTPResponse, error) {
+	secret, uri, err := u.twoFactorService.EnrollTOTP(ctx, int(req.GetUserId()))
+	if err != nil {
+		u.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return &pb.EnrollTOTPResponse{
+		Secret:     secret,
+		OtpauthUri: uri,
+	}, nil
+}
+
+func (u *UserHandler) ConfirmTOTP(ctx context.Context, req *pb.ConfirmTOTPRequest) (*pb.RecoveryCodesResponse, error) {
+	codes, err := u.twoFactorService.ConfirmTOTP(ctx, int(req.GetUserId()), req.GetCode())
+	if err != nil {
+		u.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return &pb.RecoveryCodesResponse{
+		RecoveryCodes: codes,
+	}, nil
+}
+
+func (u *UserHandler) DisableTOTP(ctx context.Context, req *pb.DisableTOTPRequest) (*pb.MutationResponse, error) {
+	if err := u.twoFactorService.DisableTOTP(ctx, int(req.GetUserId()), req.GetCode()); err != nil {
+		u.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return &pb.MutationResponse{
+		Message: "2FA berhasil dimatikan",
+	}, nil
+}
+
+func (u *UserHandler) RegenerateRecoveryCodes(ctx context.Context, req *pb.RegenerateRecoveryCodesRequest) (*pb.RecoveryCodesResponse, error) {
+	codes, err := u.twoFactorService.RegenerateRecoveryCodes(ctx, int(req.GetUserId()), req.GetCode())
+	if err != nil {
+		u.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return &pb.RecoveryCodesResponse{
+		RecoveryCodes: codes,
+	}, nil
+}
+
+func (u *UserHandler) VerifySecondFactor(ctx context.Context, req *pb.VerifySecondFactorRequest) (*pb.VerifySecondFactorResponse, error) {
+	method, err := u.twoFactorService.VerifySecondFactor(ctx, int(req.GetUserId()), req.GetCode())
+	if err != nil {
+		u.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return &pb.VerifySecondFactorResponse{
+		Method: method,
+	}, nil
+}
@@ -0,0 +1,54 @@
+// Package kyc berisi verifier yang memeriksa dokumen pengajuan KYC. Service
+// hanya bergantung pada interface Verifier sehingga penyedia verifikasi
+// bisa diganti tanpa mengubah alur statusnya.
+package kyc
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/susilo001/simple-wallet-system/user/entity"
+)
+
+// Result adalah keputusan verifier untuk sebuah pengajuan. Status
+// KYCStatusPending berarti verifier tidak bisa memutuskan dan pengajuan
+// harus diperiksa manual oleh staf.
+type Result struct {
+	Status string
+	Reason string
+}
+
+// Verifier memeriksa dokumen sebuah pengajuan KYC.
+type Verifier interface {
+	// Name dicatat sebagai pemeriksa pengajuan yang diputuskan verifier.
+	Name() string
+	Verify(ctx context.Context, submission entity.KYCSubmission) (Result, error)
+}
+
+// FakeVerifier adalah verifier lokal untuk development dan testing. Ia
+// menolak dokumen yang kedaluwarsa, dan memutuskan dari awalan nomor
+// dokumen: "REJECT" ditolak, "REVIEW" diserahkan ke pemeriksaan manual,
+// selain itu diterima.
+type FakeVerifier struct{}
+
+func NewFakeVerifier() *FakeVerifier {
+	return &FakeVerifier{}
+}
+
+func (v *FakeVerifier) Name() string {
+	return "fake-verifier"
+}
+
+func (v *FakeVerifier) Verify(ctx context.Context, submission entity.KYCSubmission) (Result, error) {
+	number := strings.ToUpper(submission.DocumentNumber)
+	switch {
+	case submission.DocumentExpiry.Before(time.Now()):
+		return Result{Status: entity.KYCStatusRejected, Reason: "dokumen sudah kedaluwarsa"}, nil
+	case strings.HasPrefix(number, "REJECT"):
+		return Result{Status: entity.KYCStatusRejected, Reason: "dokumen tidak cocok dengan data pengguna"}, nil
+	case strings.HasPrefix(number, "REVIEW"):
+		return Result{Status: entity.KYCStatusPending, Reason: "perlu pemeriksaan manual"}, nil
+	}
+	return Result{Status: entity.KYCStatusVerified}, nil
+}
@@ -0,0 +1,171 @@
+// Package logging menyiapkan logger terstruktur. Log yang ditulis dengan
+// context panggilan gRPC membawa request ID-nya, yang dibuat gateway dan
+// diteruskan lewat metadata x-request-id, sehingga satu request bisa
+// diikuti di log semua service.
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// RequestIDMetadataKey adalah metadata gRPC yang membawa request ID.
+const RequestIDMetadataKey = "x-request-id"
+
+// redacted menggantikan nilai atribut yang sensitif.
+const redacted = "[REDACTED]"
+
+// sensitiveKeys adalah key atribut yang nilainya tidak pernah dicatat.
+var sensitiveKeys = map[string]bool{
+	"password":      true,
+	"new_password":  true,
+	"token":         true,
+	"access_token":  true,
+	"refresh_token": true,
+	"secret":        true,
+	"code":          true,
+	"key":           true,
+	"api_key":       true,
+	"authorization": true,
+}
+
+// New mengembalikan logger yang menulis ke w mulai dari level ("debug",
+// "info", "warn", atau "error"), sebagai baris JSON jika json true dan
+// sebagai teks jika tidak. Atribut sensitif disensor dan alamat email
+// disamarkan.
+func New(w io.Writer, level string, json bool) *slog.Logger {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		lvl = slog.LevelInfo
+	}
+	opts := &slog.HandlerOptions{Level: lvl, ReplaceAttr: redact}
+	var handler slog.Handler = slog.NewTextHandler(w, opts)
+	if json {
+		handler = slog.NewJSONHandler(w, opts)
+	}
+	return slog.New(contextHandler{handler})
+}
+
+func redact(groups []string, a slog.Attr) slog.Attr {
+	key := strings.ToLower(a.Key)
+	switch {
+	case sensitiveKeys[key]:
+		return slog.String(a.Key, redacted)
+	case key == "email":
+		return slog.String(a.Key, MaskEmail(a.Value.String()))
+	}
+	return a
+}
+
+// MaskEmail hanya menyisakan huruf pertama dan domain alamat email, cukup
+// untuk membedakan user di log tanpa membuka alamatnya.
+func MaskEmail(email string) string {
+	local, domain, ok := strings.Cut(email, "@")
+	if !ok || local == "" {
+		return redacted
+	}
+	return local[:1] + "***@" + domain
+}
+
+type requestIDKey struct{}
+type methodKey struct{}
+
+// WithRequestID mengembalikan salinan ctx yang membawa request ID.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestID mengembalikan request ID yang dibawa ctx, jika ada.
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// NewRequestID mengembalikan request ID acak, untuk pekerjaan yang tidak
+// datang lewat gateway.
+func NewRequestID() string {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+// contextHandler menambahkan request ID, method gRPC, dan trace ID dari
+// context sebuah log ke log tersebut.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if requestID := RequestID(ctx); requestID != "" {
+		r.AddAttrs(slog.String("request_id", requestID))
+	}
+	if method, ok := ctx.Value(methodKey{}).(string); ok {
+		r.AddAttrs(slog.String("method", method))
+	}
+	if span := trace.SpanContextFromContext(ctx); span.HasTraceID() {
+		r.AddAttrs(slog.String("trace_id", span.TraceID().String()))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
+
+// UnaryServerInterceptor menaruh request ID dari metadata panggilan, atau
+// request ID baru jika tidak ada, beserta method-nya di context panggilan,
+// lalu mencatat setiap panggilan setelah selesai ditangani. Interceptor ini
+// dipasang paling awal agar interceptor lain mencatat log dengan request ID.
+func UnaryServerInterceptor(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		requestID := ""
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(RequestIDMetadataKey); len(values) > 0 {
+				requestID = values[0]
+			}
+		}
+		if requestID == "" {
+			requestID = NewRequestID()
+		}
+		ctx = WithRequestID(ctx, requestID)
+		ctx = context.WithValue(ctx, methodKey{}, info.FullMethod)
+
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		code := status.Code(err)
+		level := slog.LevelInfo
+		switch code {
+		case codes.OK:
+		case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable:
+			level = slog.LevelError
+		default:
+			level = slog.LevelWarn
+		}
+		logger.Log(ctx, level, "handled call", "grpc_code", code.String(), "duration", time.Since(start))
+		return resp, err
+	}
+}
+
+// OutgoingContext mengembalikan salinan ctx yang meneruskan request ID-nya
+// ke service yang dipanggil.
+func OutgoingContext(ctx context.Context) context.Context {
+	if requestID := RequestID(ctx); requestID != "" {
+		return metadata.AppendToOutgoingContext(ctx, RequestIDMetadataKey, requestID)
+	}
+	return ctx
+}
@@ -0,0 +1,18 @@
+// Package mail mengirim email ke pengguna. Service hanya bergantung pada
+// interface Mailer sehingga SMTP bisa diganti dengan sink lokal saat
+// development dan testing.
+package mail
+
+import "context"
+
+// Message adalah email teks biasa.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer mengirim email.
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
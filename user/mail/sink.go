@@ -0,0 +1,59 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FileMailer menulis setiap email sebagai file .eml di sebuah direktori,
+// untuk development tanpa server SMTP.
+type FileMailer struct {
+	dir  string
+	from string
+}
+
+func NewFileMailer(dir string, from string) *FileMailer {
+	return &FileMailer{dir: dir, from: from}
+}
+
+func (m *FileMailer) Send(ctx context.Context, msg Message) error {
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return fmt.Errorf("gagal membuat direktori email: %w", err)
+	}
+	recipient := strings.NewReplacer("/", "_", "\\", "_").Replace(msg.To)
+	name := fmt.Sprintf("%s-%s.eml", time.Now().UTC().Format("20060102T150405.000000000"), recipient)
+	if err := os.WriteFile(filepath.Join(m.dir, name), format(m.from, msg), 0o600); err != nil {
+		return fmt.Errorf("gagal menulis email ke %s: %w", msg.To, err)
+	}
+	return nil
+}
+
+// MemoryMailer menyimpan email yang dikirim di memori, untuk testing.
+type MemoryMailer struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+func NewMemoryMailer() *MemoryMailer {
+	return &MemoryMailer{}
+}
+
+func (m *MemoryMailer) Send(ctx context.Context, msg Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.messages = append(m.messages, msg)
+	return nil
+}
+
+// Sent mengembalikan salinan semua email yang sudah dikirim, dari yang
+// paling lama.
+func (m *MemoryMailer) Sent() []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Message(nil), m.messages...)
+}
@@ -0,0 +1,60 @@
+// Package metrics menyajikan metrik Prometheus di /metrics. Setiap service
+// melaporkan panggilan gRPC dengan nama metrik dan label yang sama agar
+// dashboard dan alert berlaku untuk semuanya.
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	grpcHandled = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_server_handled_total",
+		Help: "gRPC calls handled, by method and status code.",
+	}, []string{"grpc_service", "grpc_method", "grpc_code"})
+	grpcHandlingSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_server_handling_seconds",
+		Help:    "Time taken to handle gRPC calls, by method.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"grpc_service", "grpc_method"})
+)
+
+// UnaryServerInterceptor mencatat jumlah, error, dan durasi setiap
+// panggilan. Interceptor ini dipasang paling awal agar panggilan yang
+// ditolak interceptor berikutnya juga terhitung.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		service, method := splitMethod(info.FullMethod)
+		grpcHandled.WithLabelValues(service, method, status.Code(err).String()).Inc()
+		grpcHandlingSeconds.WithLabelValues(service, method).Observe(time.Since(start).Seconds())
+		return resp, err
+	}
+}
+
+// splitMethod memecah "/package.Service/Method" menjadi service dan method.
+func splitMethod(fullMethod string) (string, string) {
+	service, method, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	return service, method
+}
+
+// Serve menyajikan /metrics di addr sampai proses berhenti.
+func Serve(addr string, logger *slog.Logger) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	logger.Info("Serving metrics", "address", addr)
+	if err := http.ListenAndServe(addr, mux); err != nil {
+		logger.Error("Error serving metrics", "error", err)
+	}
+}
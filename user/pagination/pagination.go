@@ -0,0 +1,90 @@
+// Package pagination membaca page_size dan page_token dari request list dan
+// mengambil satu halaman dari database dengan LIMIT dan OFFSET. Semua
+// service mempaging list-nya dengan aturan dan format page token yang sama.
+package pagination
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// DefaultPageSize dipakai jika page_size tidak diisi.
+	DefaultPageSize = 50
+	// MaxPageSize adalah page_size terbesar; nilai di atasnya diturunkan
+	// ke sini.
+	MaxPageSize = 100
+)
+
+var (
+	// ErrInvalidPageSize dikembalikan untuk page_size negatif.
+	ErrInvalidPageSize = errors.New("page_size tidak boleh negatif")
+	// ErrInvalidPageToken dikembalikan untuk page_token yang tidak dibuat
+	// oleh NextPageToken.
+	ErrInvalidPageToken = errors.New("page_token tidak valid")
+)
+
+// Page adalah satu halaman list: paling banyak Limit item mulai dari item
+// ke-Offset.
+type Page struct {
+	Limit  int
+	Offset int
+}
+
+// Parse mengubah page_size dan page_token request menjadi Page. page_size 0
+// berarti DefaultPageSize. Page token berisi offset halaman; klien harus
+// menganggapnya opaque.
+func Parse(pageSize int32, pageToken string) (Page, error) {
+	if pageSize < 0 {
+		return Page{}, ErrInvalidPageSize
+	}
+	page := Page{Limit: DefaultPageSize}
+	if pageSize > 0 {
+		page.Limit = min(int(pageSize), MaxPageSize)
+	}
+	if pageToken != "" {
+		raw, err := base64.RawURLEncoding.DecodeString(pageToken)
+		if err == nil {
+			page.Offset, err = strconv.Atoi(string(raw))
+		}
+		if err != nil || page.Offset < 0 {
+			return Page{}, ErrInvalidPageToken
+		}
+	}
+	return page, nil
+}
+
+// NextPageToken mengembalikan page token halaman sesudah p dalam list
+// berisi total item, atau string kosong jika p adalah halaman terakhir.
+func (p Page) NextPageToken(total int) string {
+	end := p.Offset + p.Limit
+	if end >= total {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(end)))
+}
+
+// Find mengisi dest, pointer ke slice, dengan baris halaman p dari query
+// dan mengembalikan jumlah semua baris query. Query harus sudah diurutkan
+// agar halaman-halamannya tidak tumpang tindih.
+func Find(query *gorm.DB, p Page, dest any) (int, error) {
+	query = query.Model(dest).Session(&gorm.Session{})
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return 0, err
+	}
+	if err := query.Limit(p.Limit).Offset(p.Offset).Find(dest).Error; err != nil {
+		return 0, err
+	}
+	return int(total), nil
+}
+
+// Slice mengembalikan item halaman p dari list yang sudah ada di memori.
+func Slice[T any](items []T, p Page) []T {
+	start := min(p.Offset, len(items))
+	end := min(start+p.Limit, len(items))
+	return items[start:end]
+}
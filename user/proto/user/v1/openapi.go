@@ -0,0 +1,9 @@
+package user
+
+import _ "embed"
+
+// OpenAPI is the OpenAPI v2 document of the HTTP rules in user.proto,
+// generated by buf generate together with the code in this package.
+//
+//go:embed user.swagger.json
+var OpenAPI []byte
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/susilo001/simple-wallet-system/user/entity"
+	"github.com/susilo001/simple-wallet-system/user/service"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type accountRepository struct {
+	db     GormDBIface
+	logger *slog.Logger
+}
+
+func NewAccountRepository(db GormDBIface, logger *slog.Logger) service.IAccountRepository {
+	return &accountRepository{db: db, logger: logger}
+}
+
+func (r *accountRepository) Transaction(ctx context.Context, fn func(repo service.IAccountRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&accountRepository{db: tx, logger: r.logger})
+	})
+}
+
+func (r *accountRepository) CreateUserToken(ctx context.Context, token *entity.UserToken) error {
+	if err := r.db.WithContext(ctx).Create(token).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error creating user token", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (r *accountRepository) GetUserTokenForUpdate(ctx context.Context, tokenHash string) (entity.UserToken, error) {
+	var token entity.UserToken
+	if err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).First(&token, "token_hash = ?", tokenHash).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.UserToken{}, nil
+		}
+		r.logger.ErrorContext(ctx, "Error getting user token for update", "error", err)
+		return entity.UserToken{}, err
+	}
+	return token, nil
+}
+
+func (r *accountRepository) UseUserTokens(ctx context.Context, userID int, purpose string, usedAt time.Time) error {
+	if err := r.db.WithContext(ctx).Model(&entity.UserToken{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+		Update("used_at", usedAt).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error using user tokens", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (r *accountRepository) SetEmailVerified(ctx context.Context, userID int, verifiedAt time.Time) error {
+	if err := r.db.WithContext(ctx).Model(&entity.User{ID: userID}).
+		Where("email_verified_at IS NULL").
+		Update("email_verified_at", verifiedAt).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error setting email verified", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (r *accountRepository) UpdateUserPassword(ctx context.Context, userID int, passwordHash string) error {
+	if err := r.db.WithContext(ctx).Model(&entity.User{ID: userID}).Update("password", passwordHash).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error updating user password", "error", err)
+		return err
+	}
+	return nil
+}
+
+// VerifyExistingEmails menandai email semua user yang sudah ada sebagai
+// terverifikasi. Dipanggil sekali saat kolom email_verified_at baru dibuat,
+// karena user lama sudah aktif sebelum verifikasi email diwajibkan.
+func VerifyExistingEmails(db *gorm.DB) error {
+	return db.Model(&entity.User{}).
+		Where("email_verified_at IS NULL").
+		Update("email_verified_at", gorm.Expr("created_at")).Error
+}
+
+// HashPlaintextPasswords mengganti password user lama yang masih tersimpan
+// apa adanya dengan hash bcrypt. Password yang sudah berupa hash bcrypt
+// (diawali "$2") dilewati sehingga aman dipanggil setiap startup.
+func HashPlaintextPasswords(db *gorm.DB) error {
+	var users []entity.User
+	if err := db.Select("id", "password").Where("password NOT LIKE ?", "$2%").Find(&users).Error; err != nil {
+		return err
+	}
+	for _, user := range users {
+		hash, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return fmt.Errorf("user %d: %w", user.ID, err)
+		}
+		if err := db.Model(&entity.User{ID: user.ID}).Update("password", string(hash)).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/susilo001/simple-wallet-system/user/entity"
+	"github.com/susilo001/simple-wallet-system/user/pagination"
+	"github.com/susilo001/simple-wallet-system/user/service"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type apiKeyRepository struct {
+	db     GormDBIface
+	logger *slog.Logger
+}
+
+func NewAPIKeyRepository(db GormDBIface, logger *slog.Logger) service.IAPIKeyRepository {
+	return &apiKeyRepository{db: db, logger: logger}
+}
+
+func (r *apiKeyRepository) Transaction(ctx context.Context, fn func(repo service.IAPIKeyRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&apiKeyRepository{db: tx, logger: r.logger})
+	})
+}
+
+func (r *apiKeyRepository) CreateAPIKey(ctx context.Context, key *entity.APIKey) error {
+	if err := r.db.WithContext(ctx).Create(key).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error creating API key", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (r *apiKeyRepository) GetAPIKeyForUpdate(ctx context.Context, id int) (entity.APIKey, error) {
+	var key entity.APIKey
+	if err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).First(&key, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.APIKey{}, nil
+		}
+		r.logger.ErrorContext(ctx, "Error getting API key for update", "error", err)
+		return entity.APIKey{}, err
+	}
+	return key, nil
+}
+
+func (r *apiKeyRepository) GetAPIKeyByHash(ctx context.Context, keyHash string) (entity.APIKey, error) {
+	var key entity.APIKey
+	if err := r.db.WithContext(ctx).First(&key, "key_hash = ?", keyHash).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.APIKey{}, nil
+		}
+		r.logger.ErrorContext(ctx, "Error getting API key by hash", "error", err)
+		return entity.APIKey{}, err
+	}
+	return key, nil
+}
+
+func (r *apiKeyRepository) UpdateAPIKey(ctx context.Context, key *entity.APIKey) error {
+	if err := r.db.WithContext(ctx).Save(key).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error updating API key", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (r *apiKeyRepository) TouchAPIKey(ctx context.Context, id int, lastUsedAt time.Time) error {
+	if err := r.db.WithContext(ctx).Model(&entity.APIKey{ID: id}).UpdateColumn("last_used_at", lastUsedAt).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error touching API key", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (r *apiKeyRepository) GetAPIKeys(ctx context.Context, userID int, page pagination.Page) ([]entity.APIKey, int, error) {
+	var keys []entity.APIKey
+	total, err := pagination.Find(r.db.WithContext(ctx).Where("user_id = ?", userID).Order("id DESC"), page, &keys)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error getting API keys", "error", err)
+		return nil, 0, err
+	}
+	return keys, total, nil
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/susilo001/simple-wallet-system/user/entity"
+	"github.com/susilo001/simple-wallet-system/user/service"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// auditChainHeadID adalah primary key satu-satunya baris AuditChainHead.
+const auditChainHeadID = 1
+
+type auditRepository struct {
+	db     GormDBIface
+	logger *slog.Logger
+}
+
+func NewAuditRepository(db GormDBIface, logger *slog.Logger) service.IAuditRepository {
+	return &auditRepository{db: db, logger: logger}
+}
+
+func (r *auditRepository) Transaction(ctx context.Context, fn func(repo service.IAuditRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&auditRepository{db: tx, logger: r.logger})
+	})
+}
+
+func (r *auditRepository) GetAuditChainHeadForUpdate(ctx context.Context) (entity.AuditChainHead, error) {
+	head := entity.AuditChainHead{ID: auditChainHeadID}
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&head).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error creating audit chain head", "error", err)
+		return entity.AuditChainHead{}, err
+	}
+	if err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).First(&head, auditChainHeadID).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error getting audit chain head for update", "error", err)
+		return entity.AuditChainHead{}, err
+	}
+	return head, nil
+}
+
+func (r *auditRepository) GetAuditChainHead(ctx context.Context) (entity.AuditChainHead, error) {
+	var heads []entity.AuditChainHead
+	if err := r.db.WithContext(ctx).Where("id = ?", auditChainHeadID).Find(&heads).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error getting audit chain head", "error", err)
+		return entity.AuditChainHead{}, err
+	}
+	if len(heads) == 0 {
+		return entity.AuditChainHead{}, nil
+	}
+	return heads[0], nil
+}
+
+func (r *auditRepository) SaveAuditChainHead(ctx context.Context, head *entity.AuditChainHead) error {
+	if err := r.db.WithContext(ctx).Save(head).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error saving audit chain head", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (r *auditRepository) CreateAuditEntry(ctx context.Context, entry *entity.AuditEntry) error {
+	if err := r.db.WithContext(ctx).Create(entry).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error creating audit entry", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (r *auditRepository) GetAuditEntries(ctx context.Context, filter service.AuditFilter) ([]entity.AuditEntry, error) {
+	query := r.db.WithContext(ctx).Where("sequence > ?", filter.AfterSequence)
+	if filter.Actor != "" {
+		query = query.Where("actor = ?", filter.Actor)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.Target != "" {
+		query = query.Where("target = ?", filter.Target)
+	}
+	if filter.RequestID != "" {
+		query = query.Where("request_id = ?", filter.RequestID)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("created_at < ?", filter.To)
+	}
+
+	var entries []entity.AuditEntry
+	if err := query.Order("sequence").Limit(filter.Limit).Find(&entries).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error getting audit entries", "error", err)
+		return nil, err
+	}
+	return entries, nil
+}
+
+// ProtectAuditLog memasang trigger yang menolak UPDATE, DELETE dan TRUNCATE
+// pada log audit sehingga log hanya bisa ditambah. Tidak melakukan apa-apa
+// pada database selain PostgreSQL.
+func ProtectAuditLog(db *gorm.DB) error {
+	if db.Dialector.Name() != "postgres" {
+		return nil
+	}
+
+	table := entity.AuditEntry{}.TableName()
+	statements := []string{
+		`CREATE OR REPLACE FUNCTION reject_audit_change() RETURNS trigger AS $$
+		BEGIN
+			RAISE EXCEPTION 'audit log is append-only';
+		END;
+		$$ LANGUAGE plpgsql`,
+		fmt.Sprintf(`DROP TRIGGER IF EXISTS %[1]s_append_only ON %[1]s`, table),
+		fmt.Sprintf(`CREATE TRIGGER %[1]s_append_only BEFORE UPDATE OR DELETE ON %[1]s
+		FOR EACH ROW EXECUTE FUNCTION reject_audit_change()`, table),
+		fmt.Sprintf(`DROP TRIGGER IF EXISTS %[1]s_no_truncate ON %[1]s`, table),
+		fmt.Sprintf(`CREATE TRIGGER %[1]s_no_truncate BEFORE TRUNCATE ON %[1]s
+		FOR EACH STATEMENT EXECUTE FUNCTION reject_audit_change()`, table),
+	}
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/susilo001/simple-wallet-system/user/entity"
+	"github.com/susilo001/simple-wallet-system/user/pagination"
+	"github.com/susilo001/simple-wallet-system/user/service"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type kycRepository struct {
+	db     GormDBIface
+	logger *slog.Logger
+}
+
+func NewKYCRepository(db GormDBIface, logger *slog.Logger) service.IKYCRepository {
+	return &kycRepository{db: db, logger: logger}
+}
+
+func (r *kycRepository) Transaction(ctx context.Context, fn func(repo service.IKYCRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&kycRepository{db: tx, logger: r.logger})
+	})
+}
+
+func (r *kycRepository) CreateKYCSubmission(ctx context.Context, submission *entity.KYCSubmission) error {
+	if err := r.db.WithContext(ctx).Create(submission).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error creating KYC submission", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (r *kycRepository) GetKYCSubmissionForUpdate(ctx context.Context, id int) (entity.KYCSubmission, error) {
+	var submission entity.KYCSubmission
+	if err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).First(&submission, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.KYCSubmission{}, nil
+		}
+		r.logger.ErrorContext(ctx, "Error getting KYC submission for update", "error", err)
+		return entity.KYCSubmission{}, err
+	}
+	return submission, nil
+}
+
+func (r *kycRepository) HasPendingKYCSubmission(ctx context.Context, userID int) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&entity.KYCSubmission{}).
+		Where("user_id = ? AND status = ?", userID, entity.KYCStatusPending).
+		Count(&count).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error checking pending KYC submission", "error", err)
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *kycRepository) GetKYCSubmissions(ctx context.Context, userID int, status string, page pagination.Page) ([]entity.KYCSubmission, int, error) {
+	var submissions []entity.KYCSubmission
+	query := r.db.WithContext(ctx).Order("id")
+	if userID != 0 {
+		query = query.Where("user_id = ?", userID)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	total, err := pagination.Find(query, page, &submissions)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error getting KYC submissions", "error", err)
+		return nil, 0, err
+	}
+	return submissions, total, nil
+}
+
+func (r *kycRepository) UpdateKYCSubmission(ctx context.Context, submission *entity.KYCSubmission) error {
+	if err := r.db.WithContext(ctx).Save(submission).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error updating KYC submission", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (r *kycRepository) UpdateUserKYCLevel(ctx context.Context, userID int, level string) error {
+	if err := r.db.WithContext(ctx).Model(&entity.User{ID: userID}).Update("kyc_level", level).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error updating user KYC level", "error", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/susilo001/simple-wallet-system/user/entity"
+	"github.com/susilo001/simple-wallet-system/user/pagination"
+	"github.com/susilo001/simple-wallet-system/user/service"
+)
+
+// memorySessionRepository menyimpan sesi di memori, untuk development dan
+// testing. Semua sesi hilang saat service berhenti, dan hanya berguna jika
+// user service berjalan sebagai satu instance.
+type memorySessionRepository struct {
+	// txMu membuat transaksi berjalan satu per satu, pengganti row lock.
+	// Perubahan di transaksi yang gagal tidak di-rollback.
+	txMu sync.Mutex
+
+	mu            sync.Mutex
+	sessions      map[int]entity.Session
+	refreshTokens map[int]entity.RefreshToken
+	nextSessionID int
+	nextTokenID   int
+}
+
+// NewMemorySessionRepository menyimpan sesi di memori. Lihat juga
+// NewSessionRepository.
+func NewMemorySessionRepository() service.ISessionRepository {
+	return &memorySessionRepository{
+		sessions:      map[int]entity.Session{},
+		refreshTokens: map[int]entity.RefreshToken{},
+	}
+}
+
+func (r *memorySessionRepository) Transaction(ctx context.Context, fn func(repo service.ISessionRepository) error) error {
+	r.txMu.Lock()
+	defer r.txMu.Unlock()
+	return fn(r)
+}
+
+func (r *memorySessionRepository) CreateSession(ctx context.Context, session *entity.Session) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.nextSessionID++
+	now := time.Now().UTC()
+	session.ID = r.nextSessionID
+	session.CreatedAt = now
+	session.UpdatedAt = now
+	r.sessions[session.ID] = *session
+	return nil
+}
+
+func (r *memorySessionRepository) GetSessionForUpdate(ctx context.Context, id int) (entity.Session, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.sessions[id], nil
+}
+
+func (r *memorySessionRepository) GetSessionByAccessToken(ctx context.Context, tokenHash string) (entity.Session, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, session := range r.sessions {
+		if session.AccessTokenHash == tokenHash {
+			return session, nil
+		}
+	}
+	return entity.Session{}, nil
+}
+
+func (r *memorySessionRepository) UpdateSession(ctx context.Context, session *entity.Session) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	session.UpdatedAt = time.Now().UTC()
+	r.sessions[session.ID] = *session
+	return nil
+}
+
+func (r *memorySessionRepository) TouchSession(ctx context.Context, id int, lastSeenAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if session, ok := r.sessions[id]; ok {
+		session.LastSeenAt = lastSeenAt
+		r.sessions[id] = session
+	}
+	return nil
+}
+
+func (r *memorySessionRepository) GetSessions(ctx context.Context, userID int, activeAt time.Time, page pagination.Page) ([]entity.Session, int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var sessions []entity.Session
+	for _, session := range r.sessions {
+		if session.UserID == userID && session.Active(activeAt) {
+			sessions = append(sessions, session)
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		if !sessions[i].LastSeenAt.Equal(sessions[j].LastSeenAt) {
+			return sessions[i].LastSeenAt.After(sessions[j].LastSeenAt)
+		}
+		return sessions[i].ID > sessions[j].ID
+	})
+	return pagination.Slice(sessions, page), len(sessions), nil
+}
+
+func (r *memorySessionRepository) RevokeSessions(ctx context.Context, userID int, exceptSessionID int, reason string, revokedAt time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	count := 0
+	for id, session := range r.sessions {
+		if session.UserID != userID || id == exceptSessionID || !session.Active(revokedAt) {
+			continue
+		}
+		revokedAt := revokedAt
+		session.RevokedAt = &revokedAt
+		session.RevokedReason = reason
+		r.sessions[id] = session
+		count++
+	}
+	return count, nil
+}
+
+func (r *memorySessionRepository) CreateRefreshToken(ctx context.Context, token *entity.RefreshToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.nextTokenID++
+	token.ID = r.nextTokenID
+	token.CreatedAt = time.Now().UTC()
+	r.refreshTokens[token.ID] = *token
+	return nil
+}
+
+func (r *memorySessionRepository) GetRefreshTokenForUpdate(ctx context.Context, tokenHash string) (entity.RefreshToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, token := range r.refreshTokens {
+		if token.TokenHash == tokenHash {
+			return token, nil
+		}
+	}
+	return entity.RefreshToken{}, nil
+}
+
+func (r *memorySessionRepository) UseRefreshToken(ctx context.Context, id int, usedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if token, ok := r.refreshTokens[id]; ok {
+		token.UsedAt = &usedAt
+		r.refreshTokens[id] = token
+	}
+	return nil
+}
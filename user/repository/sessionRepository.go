@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/susilo001/simple-wallet-system/user/entity"
+	"github.com/susilo001/simple-wallet-system/user/pagination"
+	"github.com/susilo001/simple-wallet-system/user/service"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type sessionRepository struct {
+	db     GormDBIface
+	logger *slog.Logger
+}
+
+// NewSessionRepository menyimpan sesi di Postgres. Lihat juga
+// NewMemorySessionRepository.
+func NewSessionRepository(db GormDBIface, logger *slog.Logger) service.ISessionRepository {
+	return &sessionRepository{db: db, logger: logger}
+}
+
+func (r *sessionRepository) Transaction(ctx context.Context, fn func(repo service.ISessionRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&sessionRepository{db: tx, logger: r.logger})
+	})
+}
+
+func (r *sessionRepository) CreateSession(ctx context.Context, session *entity.Session) error {
+	if err := r.db.WithContext(ctx).Create(session).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error creating session", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (r *sessionRepository) GetSessionForUpdate(ctx context.Context, id int) (entity.Session, error) {
+	var session entity.Session
+	if err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).First(&session, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.Session{}, nil
+		}
+		r.logger.ErrorContext(ctx, "Error getting session for update", "error", err)
+		return entity.Session{}, err
+	}
+	return session, nil
+}
+
+func (r *sessionRepository) GetSessionByAccessToken(ctx context.Context, tokenHash string) (entity.Session, error) {
+	var session entity.Session
+	if err := r.db.WithContext(ctx).First(&session, "access_token_hash = ?", tokenHash).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.Session{}, nil
+		}
+		r.logger.ErrorContext(ctx, "Error getting session by access token", "error", err)
+		return entity.Session{}, err
+	}
+	return session, nil
+}
+
+func (r *sessionRepository) UpdateSession(ctx context.Context, session *entity.Session) error {
+	if err := r.db.WithContext(ctx).Save(session).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error updating session", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (r *sessionRepository) TouchSession(ctx context.Context, id int, lastSeenAt time.Time) error {
+	if err := r.db.WithContext(ctx).Model(&entity.Session{ID: id}).UpdateColumn("last_seen_at", lastSeenAt).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error touching session", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (r *sessionRepository) GetSessions(ctx context.Context, userID int, activeAt time.Time, page pagination.Page) ([]entity.Session, int, error) {
+	var sessions []entity.Session
+	query := r.db.WithContext(ctx).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, activeAt).
+		Order("last_seen_at DESC, id DESC")
+	total, err := pagination.Find(query, page, &sessions)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error getting sessions", "error", err)
+		return nil, 0, err
+	}
+	return sessions, total, nil
+}
+
+func (r *sessionRepository) RevokeSessions(ctx context.Context, userID int, exceptSessionID int, reason string, revokedAt time.Time) (int, error) {
+	result := r.db.WithContext(ctx).Model(&entity.Session{}).
+		Where("user_id = ? AND id <> ? AND revoked_at IS NULL AND expires_at > ?", userID, exceptSessionID, revokedAt).
+		Updates(map[string]interface{}{"revoked_at": revokedAt, "revoked_reason": reason})
+	if result.Error != nil {
+		r.logger.ErrorContext(ctx, "Error revoking sessions", "error", result.Error)
+		return 0, result.Error
+	}
+	return int(result.RowsAffected), nil
+}
+
+func (r *sessionRepository) CreateRefreshToken(ctx context.Context, token *entity.RefreshToken) error {
+	if err := r.db.WithContext(ctx).Create(token).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error creating refresh token", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (r *sessionRepository) GetRefreshTokenForUpdate(ctx context.Context, tokenHash string) (entity.RefreshToken, error) {
+	var token entity.RefreshToken
+	if err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).First(&token, "token_hash = ?", tokenHash).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.RefreshToken{}, nil
+		}
+		r.logger.ErrorContext(ctx, "Error getting refresh token for update", "error", err)
+		return entity.RefreshToken{}, err
+	}
+	return token, nil
+}
+
+func (r *sessionRepository) UseRefreshToken(ctx context.Context, id int, usedAt time.Time) error {
+	if err := r.db.WithContext(ctx).Model(&entity.RefreshToken{ID: id}).Update("used_at", usedAt).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error using refresh token", "error", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/susilo001/simple-wallet-system/user/entity"
+	"github.com/susilo001/simple-wallet-system/user/service"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type twoFactorRepository struct {
+	db     GormDBIface
+	logger *slog.Logger
+}
+
+func NewTwoFactorRepository(db GormDBIface, logger *slog.Logger) service.ITwoFactorRepository {
+	return &twoFactorRepository{db: db, logger: logger}
+}
+
+func (r *twoFactorRepository) Transaction(ctx context.Context, fn func(repo service.ITwoFactorRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&twoFactorRepository{db: tx, logger: r.logger})
+	})
+}
+
+func (r *twoFactorRepository) GetTOTPSecretForUpdate(ctx context.Context, userID int) (entity.TOTPSecret, error) {
+	var secret entity.TOTPSecret
+	if err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).First(&secret, "user_id = ?", userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.TOTPSecret{}, nil
+		}
+		r.logger.ErrorContext(ctx, "Error getting TOTP secret for update", "error", err)
+		return entity.TOTPSecret{}, err
+	}
+	return secret, nil
+}
+
+func (r *twoFactorRepository) SaveTOTPSecret(ctx context.Context, secret *entity.TOTPSecret) error {
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"secret", "confirmed_at", "last_step", "failed_attempts", "locked_until", "updated_at"}),
+	}).Create(secret).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error saving TOTP secret", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (r *twoFactorRepository) DeleteTOTPSecret(ctx context.Context, userID int) error {
+	if err := r.db.WithContext(ctx).Delete(&entity.TOTPSecret{}, "user_id = ?", userID).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error deleting TOTP secret", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (r *twoFactorRepository) SetTwoFactorEnabled(ctx context.Context, userID int, enabledAt *time.Time) error {
+	if err := r.db.WithContext(ctx).Model(&entity.User{ID: userID}).Update("two_factor_enabled_at", enabledAt).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error setting two factor enabled", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (r *twoFactorRepository) ReplaceRecoveryCodes(ctx context.Context, userID int, codeHashes []string) error {
+	if err := r.db.WithContext(ctx).Delete(&entity.RecoveryCode{}, "user_id = ?", userID).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error deleting recovery codes", "error", err)
+		return err
+	}
+	if len(codeHashes) == 0 {
+		return nil
+	}
+	codes := make([]entity.RecoveryCode, 0, len(codeHashes))
+	for _, hash := range codeHashes {
+		codes = append(codes, entity.RecoveryCode{UserID: userID, CodeHash: hash})
+	}
+	if err := r.db.WithContext(ctx).Create(&codes).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error creating recovery codes", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (r *twoFactorRepository) UseRecoveryCode(ctx context.Context, userID int, codeHash string, usedAt time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&entity.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", usedAt)
+	if result.Error != nil {
+		r.logger.ErrorContext(ctx, "Error using recovery code", "error", result.Error)
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -3,9 +3,10 @@ package repository
 import (
 	"context"
 	"errors"
-	"log"
+	"log/slog"
 
 	"github.com/susilo001/simple-wallet-system/user/entity"
+	"github.com/susilo001/simple-wallet-system/user/pagination"
 	"github.com/susilo001/simple-wallet-system/user/service"
 	"gorm.io/gorm"
 )
@@ -20,16 +21,17 @@ type GormDBIface interface {
 }
 
 type userRepository struct {
-	db GormDBIface
+	db     GormDBIface
+	logger *slog.Logger
 }
 
-func NewUserRepository(db GormDBIface) service.IUserRepository {
-	return &userRepository{db: db}
+func NewUserRepository(db GormDBIface, logger *slog.Logger) service.IUserRepository {
+	return &userRepository{db: db, logger: logger}
 }
 
 func (r *userRepository) CreateUser(ctx context.Context, user *entity.User) (entity.User, error) {
 	if err := r.db.WithContext(ctx).Create(user).Error; err != nil {
-		log.Printf("Error creating user: %v\n", err)
+		r.logger.ErrorContext(ctx, "Error creating user", "error", err)
 		return entity.User{}, err
 	}
 	return *user, nil
@@ -37,48 +39,60 @@ func (r *userRepository) CreateUser(ctx context.Context, user *entity.User) (ent
 
 func (r *userRepository) GetUserByID(ctx context.Context, id int) (entity.User, error) {
 	var user entity.User
-	if err := r.db.WithContext(ctx).Select("id", "name", "email", "password", "created_at", "updated_at").First(&user, id).Error; err != nil {
+	if err := r.db.WithContext(ctx).Select("id", "name", "email", "password", "role", "kyc_level", "email_verified_at", "two_factor_enabled_at", "created_at", "updated_at").First(&user, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return entity.User{}, nil
 		}
-		log.Printf("Error getting user by ID: %v\n", err)
+		r.logger.ErrorContext(ctx, "Error getting user by ID", "error", err)
 		return entity.User{}, err
 	}
 	return user, nil
 }
 
-func (r *userRepository) UpdateUser(ctx context.Context, id int, user entity.User) (entity.User, error) {
-	var existingUser entity.User
-	if err := r.db.WithContext(ctx).Select("id", "name", "email", "password", "created_at", "updated_at").First(&existingUser, id).Error; err != nil {
-		log.Printf("Error finding user to update: %v\n", err)
+func (r *userRepository) GetUserByEmail(ctx context.Context, email string) (entity.User, error) {
+	var user entity.User
+	if err := r.db.WithContext(ctx).Select("id", "name", "email", "password", "role", "kyc_level", "email_verified_at", "two_factor_enabled_at", "created_at", "updated_at").First(&user, "email = ?", email).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.User{}, nil
+		}
+		r.logger.ErrorContext(ctx, "Error getting user by email", "error", err)
 		return entity.User{}, err
 	}
+	return user, nil
+}
 
-	existingUser.Name = user.Name
-	existingUser.Email = user.Email
-	if err := r.db.WithContext(ctx).Save(&existingUser).Error; err != nil {
-		log.Printf("Error updating user: %v\n", err)
+func (r *userRepository) UpdateUser(ctx context.Context, id int, user entity.User) (entity.User, error) {
+	user.ID = id
+	if err := r.db.WithContext(ctx).Model(&user).Select("name", "email", "password", "email_verified_at", "updated_at").Updates(&user).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error updating user", "error", err)
 		return entity.User{}, err
 	}
-	return existingUser, nil
+	return user, nil
+}
+
+func (r *userRepository) UpdateUserRole(ctx context.Context, id int, role string) error {
+	if err := r.db.WithContext(ctx).Model(&entity.User{ID: id}).Update("role", role).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error updating user role", "error", err)
+		return err
+	}
+	return nil
 }
 
 func (r *userRepository) DeleteUser(ctx context.Context, id int) error {
 	if err := r.db.WithContext(ctx).Delete(&entity.User{}, id).Error; err != nil {
-		log.Printf("Error deleting user: %v\n", err)
+		r.logger.ErrorContext(ctx, "Error deleting user", "error", err)
 		return err
 	}
 	return nil
 }
 
-func (r *userRepository) GetAllUsers(ctx context.Context) ([]entity.User, error) {
+func (r *userRepository) GetUsers(ctx context.Context, page pagination.Page) ([]entity.User, int, error) {
 	var users []entity.User
-	if err := r.db.WithContext(ctx).Select("id", "name", "email", "created_at", "updated_at").Find(&users).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return users, nil
-		}
-		log.Printf("Error getting all users: %v\n", err)
-		return nil, err
+	query := r.db.WithContext(ctx).Select("id", "name", "email", "role", "kyc_level", "email_verified_at", "two_factor_enabled_at", "created_at", "updated_at").Order("id")
+	total, err := pagination.Find(query, page, &users)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error getting users", "error", err)
+		return nil, 0, err
 	}
-	return users, nil
+	return users, total, nil
 }
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/susilo001/simple-wallet-system/user/entity"
+	"github.com/susilo001/simple-wallet-system/user/mail"
+)
+
+const (
+	// EmailVerificationTTL adalah masa berlaku token verifikasi email.
+	EmailVerificationTTL = 24 * time.Hour
+	// PasswordResetTTL adalah masa berlaku token reset password.
+	PasswordResetTTL = time.Hour
+	// MinPasswordLength adalah panjang minimal password baru.
+	MinPasswordLength = 8
+)
+
+// IAccountService mengelola verifikasi email dan reset password. Semua
+// permintaan yang menerima email berhasil walaupun emailnya tidak terdaftar,
+// agar email yang terdaftar tidak bisa ditebak.
+type IAccountService interface {
+	// SendEmailVerification mengirim ulang token verifikasi ke email yang
+	// belum diverifikasi.
+	SendEmailVerification(ctx context.Context, email string) error
+	VerifyEmail(ctx context.Context, token string) error
+	RequestPasswordReset(ctx context.Context, email string) error
+	// ResetPassword mengganti password dengan token reset dan mengembalikan
+	// ID user pemilik token.
+	ResetPassword(ctx context.Context, token string, newPassword string) (int, error)
+}
+
+type IAccountRepository interface {
+	Transaction(ctx context.Context, fn func(repo IAccountRepository) error) error
+	CreateUserToken(ctx context.Context, token *entity.UserToken) error
+	GetUserTokenForUpdate(ctx context.Context, tokenHash string) (entity.UserToken, error)
+	// UseUserTokens menandai semua token user untuk purpose yang belum
+	// dipakai sebagai sudah dipakai.
+	UseUserTokens(ctx context.Context, userID int, purpose string, usedAt time.Time) error
+	SetEmailVerified(ctx context.Context, userID int, verifiedAt time.Time) error
+	UpdateUserPassword(ctx context.Context, userID int, passwordHash string) error
+}
+
+type accountService struct {
+	accountRepo     IAccountRepository
+	userRepo        IUserRepository
+	mailer          mail.Mailer
+	verificationURL string
+	resetURL        string
+	logger          *slog.Logger
+}
+
+// NewAccountService membuat service akun. verificationURL dan resetURL
+// adalah format link di email, dengan %s untuk tokennya.
+func NewAccountService(accountRepo IAccountRepository, userRepo IUserRepository, mailer mail.Mailer, verificationURL string, resetURL string, logger *slog.Logger) IAccountService {
+	return &accountService{
+		accountRepo:     accountRepo,
+		userRepo:        userRepo,
+		mailer:          mailer,
+		verificationURL: verificationURL,
+		resetURL:        resetURL,
+		logger:          logger,
+	}
+}
+
+func (s *accountService) SendEmailVerification(ctx context.Context, email string) error {
+	user, err := s.userRepo.GetUserByEmail(ctx, strings.TrimSpace(email))
+	if err != nil {
+		return fmt.Errorf("gagal mendapatkan pengguna berdasarkan email: %v", err)
+	}
+	if user.ID == 0 || user.EmailVerifiedAt != nil {
+		return nil
+	}
+
+	token, err := s.issueToken(ctx, user.ID, entity.TokenPurposeEmailVerification, EmailVerificationTTL)
+	if err != nil {
+		return fmt.Errorf("gagal membuat token verifikasi email: %v", err)
+	}
+	return s.mailer.Send(ctx, mail.Message{
+		To:      user.Email,
+		Subject: "Verifikasi email Anda",
+		Body: fmt.Sprintf("Halo %s,\n\nBuka link berikut untuk memverifikasi email Anda:\n\n%s\n\nLink ini berlaku %d jam.\n",
+			user.Name, fmt.Sprintf(s.verificationURL, token), int(EmailVerificationTTL.Hours())),
+	})
+}
+
+func (s *accountService) VerifyEmail(ctx context.Context, token string) error {
+	err := s.accountRepo.Transaction(ctx, func(repo IAccountRepository) error {
+		userToken, err := s.useToken(ctx, repo, token, entity.TokenPurposeEmailVerification)
+		if err != nil {
+			return err
+		}
+		return repo.SetEmailVerified(ctx, userToken.UserID, time.Now().UTC())
+	})
+	if err != nil {
+		return fmt.Errorf("gagal memverifikasi email: %w", err)
+	}
+	return nil
+}
+
+func (s *accountService) RequestPasswordReset(ctx context.Context, email string) error {
+	user, err := s.userRepo.GetUserByEmail(ctx, strings.TrimSpace(email))
+	if err != nil {
+		return fmt.Errorf("gagal mendapatkan pengguna berdasarkan email: %v", err)
+	}
+	if user.ID == 0 {
+		return nil
+	}
+
+	token, err := s.issueToken(ctx, user.ID, entity.TokenPurposePasswordReset, PasswordResetTTL)
+	if err != nil {
+		return fmt.Errorf("gagal membuat token reset password: %v", err)
+	}
+	return s.mailer.Send(ctx, mail.Message{
+		To:      user.Email,
+		Subject: "Reset password",
+		Body: fmt.Sprintf("Halo %s,\n\nKami menerima permintaan reset password akun Anda. Buka link berikut untuk membuat password baru:\n\n%s\n\nLink ini berlaku %d jam. Abaikan email ini jika Anda tidak memintanya.\n",
+			user.Name, fmt.Sprintf(s.resetURL, token), int(PasswordResetTTL.Hours())),
+	})
+}
+
+func (s *accountService) ResetPassword(ctx context.Context, token string, newPassword string) (int, error) {
+	if len(newPassword) < MinPasswordLength {
+		return 0, fmt.Errorf("%w: minimal %d karakter", ErrWeakPassword, MinPasswordLength)
+	}
+
+	hash, err := hashPassword(newPassword)
+	if err != nil {
+		return 0, err
+	}
+
+	var userID int
+	err = s.accountRepo.Transaction(ctx, func(repo IAccountRepository) error {
+		userToken, err := s.useToken(ctx, repo, token, entity.TokenPurposePasswordReset)
+		if err != nil {
+			return err
+		}
+		userID = userToken.UserID
+		if err := repo.UpdateUserPassword(ctx, userToken.UserID, hash); err != nil {
+			return err
+		}
+		// Token reset sampai ke email user, jadi emailnya terbukti miliknya
+		return repo.SetEmailVerified(ctx, userToken.UserID, time.Now().UTC())
+	})
+	if err != nil {
+		return 0, fmt.Errorf("gagal mereset password: %w", err)
+	}
+	return userID, nil
+}
+
+// issueToken membuat token baru dan mengembalikan nilai aslinya, yang hanya
+// dikirim lewat email.
+func (s *accountService) issueToken(ctx context.Context, userID int, purpose string, ttl time.Duration) (string, error) {
+	token, err := newToken()
+	if err != nil {
+		return "", err
+	}
+	userToken := entity.UserToken{
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: hashToken(token),
+		ExpiresAt: time.Now().UTC().Add(ttl),
+	}
+	if err := s.accountRepo.CreateUserToken(ctx, &userToken); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// useToken mengunci token lalu menandainya, beserta semua token lain milik
+// user untuk purpose yang sama, sudah dipakai. Token yang tidak ada, untuk
+// purpose lain, sudah dipakai atau kedaluwarsa ditolak dengan error yang
+// sama.
+func (s *accountService) useToken(ctx context.Context, repo IAccountRepository, token string, purpose string) (entity.UserToken, error) {
+	userToken, err := repo.GetUserTokenForUpdate(ctx, hashToken(token))
+	if err != nil {
+		return entity.UserToken{}, err
+	}
+	now := time.Now().UTC()
+	if userToken.ID == 0 || userToken.Purpose != purpose || userToken.UsedAt != nil || !now.Before(userToken.ExpiresAt) {
+		if userToken.ID != 0 {
+			s.logger.WarnContext(ctx, "Rejected user token", "purpose", purpose, "token_id", userToken.ID, "user_id", userToken.UserID)
+		}
+		return entity.UserToken{}, ErrInvalidToken
+	}
+	if err := repo.UseUserTokens(ctx, userToken.UserID, purpose, now); err != nil {
+		return entity.UserToken{}, err
+	}
+	return userToken, nil
+}
+
+// newToken membuat token acak 256 bit yang aman dipakai di URL.
+func newToken() (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
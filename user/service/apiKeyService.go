@@ -0,0 +1,245 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/susilo001/simple-wallet-system/user/entity"
+	"github.com/susilo001/simple-wallet-system/user/pagination"
+)
+
+const (
+	// APIKeyPrefix mengawali setiap API key agar mudah dikenali, misalnya
+	// oleh secret scanner.
+	APIKeyPrefix = "wsk_"
+	// APIKeyRotationGracePeriod adalah lama key lama masih berlaku setelah
+	// dirotasi, agar integrasi sempat berganti ke key baru.
+	APIKeyRotationGracePeriod = 24 * time.Hour
+	// APIKeyTouchInterval membatasi seberapa sering LastUsedAt diperbarui.
+	APIKeyTouchInterval = time.Minute
+)
+
+// NewAPIKey adalah data API key yang akan dibuat.
+type NewAPIKey struct {
+	Name          string
+	Scopes        []string
+	TransferLimit float64
+	// ExpiresAt kosong berarti key berlaku sampai dicabut.
+	ExpiresAt *time.Time
+}
+
+// IAPIKeyService mengelola API key untuk integrasi server ke server. Nilai
+// key hanya dikembalikan saat dibuat atau dirotasi.
+type IAPIKeyService interface {
+	CreateAPIKey(ctx context.Context, userID int, newKey NewAPIKey) (entity.APIKey, string, error)
+	GetAPIKeys(ctx context.Context, userID int, page pagination.Page) ([]entity.APIKey, int, error)
+	RevokeAPIKey(ctx context.Context, userID int, id int) (entity.APIKey, error)
+	// RotateAPIKey membuat key baru dengan pengaturan yang sama. Key lama
+	// masih berlaku selama APIKeyRotationGracePeriod.
+	RotateAPIKey(ctx context.Context, userID int, id int) (entity.APIKey, string, error)
+	// AuthenticateAPIKey mengembalikan pemilik key beserta key-nya.
+	AuthenticateAPIKey(ctx context.Context, key string) (entity.User, entity.APIKey, error)
+}
+
+type IAPIKeyRepository interface {
+	Transaction(ctx context.Context, fn func(repo IAPIKeyRepository) error) error
+	CreateAPIKey(ctx context.Context, key *entity.APIKey) error
+	// GetAPIKeyForUpdate dan GetAPIKeyByHash mengembalikan key kosong jika
+	// tidak ada.
+	GetAPIKeyForUpdate(ctx context.Context, id int) (entity.APIKey, error)
+	GetAPIKeyByHash(ctx context.Context, keyHash string) (entity.APIKey, error)
+	UpdateAPIKey(ctx context.Context, key *entity.APIKey) error
+	TouchAPIKey(ctx context.Context, id int, lastUsedAt time.Time) error
+	GetAPIKeys(ctx context.Context, userID int, page pagination.Page) ([]entity.APIKey, int, error)
+}
+
+type apiKeyService struct {
+	apiKeyRepo  IAPIKeyRepository
+	userService IUserService
+	logger      *slog.Logger
+}
+
+func NewAPIKeyService(apiKeyRepo IAPIKeyRepository, userService IUserService, logger *slog.Logger) IAPIKeyService {
+	return &apiKeyService{
+		apiKeyRepo:  apiKeyRepo,
+		userService: userService,
+		logger:      logger,
+	}
+}
+
+func (s *apiKeyService) CreateAPIKey(ctx context.Context, userID int, newKey NewAPIKey) (entity.APIKey, string, error) {
+	scopes, err := validateAPIKey(newKey)
+	if err != nil {
+		return entity.APIKey{}, "", err
+	}
+	user, err := s.userService.GetUserByID(ctx, userID)
+	if err != nil {
+		return entity.APIKey{}, "", err
+	}
+	if user.ID == 0 {
+		return entity.APIKey{}, "", ErrUserNotFound
+	}
+
+	apiKey := entity.APIKey{
+		UserID:        userID,
+		Name:          strings.TrimSpace(newKey.Name),
+		Scopes:        strings.Join(scopes, ","),
+		TransferLimit: newKey.TransferLimit,
+		ExpiresAt:     newKey.ExpiresAt,
+	}
+	key, err := createAPIKey(ctx, s.apiKeyRepo, &apiKey)
+	if err != nil {
+		return entity.APIKey{}, "", fmt.Errorf("gagal membuat API key: %v", err)
+	}
+	return apiKey, key, nil
+}
+
+func (s *apiKeyService) GetAPIKeys(ctx context.Context, userID int, page pagination.Page) ([]entity.APIKey, int, error) {
+	keys, total, err := s.apiKeyRepo.GetAPIKeys(ctx, userID, page)
+	if err != nil {
+		return nil, 0, fmt.Errorf("gagal mendapatkan API key: %v", err)
+	}
+	return keys, total, nil
+}
+
+func (s *apiKeyService) RevokeAPIKey(ctx context.Context, userID int, id int) (entity.APIKey, error) {
+	var apiKey entity.APIKey
+	err := s.apiKeyRepo.Transaction(ctx, func(repo IAPIKeyRepository) error {
+		var err error
+		apiKey, err = repo.GetAPIKeyForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if apiKey.ID == 0 || apiKey.UserID != userID {
+			return ErrAPIKeyNotFound
+		}
+		if apiKey.RevokedAt != nil {
+			return nil
+		}
+		now := time.Now().UTC()
+		apiKey.RevokedAt = &now
+		return repo.UpdateAPIKey(ctx, &apiKey)
+	})
+	if err != nil {
+		return entity.APIKey{}, fmt.Errorf("gagal mencabut API key: %w", err)
+	}
+	return apiKey, nil
+}
+
+func (s *apiKeyService) RotateAPIKey(ctx context.Context, userID int, id int) (entity.APIKey, string, error) {
+	var rotated entity.APIKey
+	var key string
+	err := s.apiKeyRepo.Transaction(ctx, func(repo IAPIKeyRepository) error {
+		old, err := repo.GetAPIKeyForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if old.ID == 0 || old.UserID != userID {
+			return ErrAPIKeyNotFound
+		}
+		now := time.Now().UTC()
+		if !old.Active(now) {
+			return fmt.Errorf("%w: key %d sudah dicabut atau kedaluwarsa", ErrInvalidAPIKey, old.ID)
+		}
+
+		rotated = entity.APIKey{
+			UserID:        old.UserID,
+			Name:          old.Name,
+			Scopes:        old.Scopes,
+			TransferLimit: old.TransferLimit,
+			ExpiresAt:     old.ExpiresAt,
+			RotatedFromID: &old.ID,
+		}
+		key, err = createAPIKey(ctx, repo, &rotated)
+		if err != nil {
+			return err
+		}
+
+		graceEnd := now.Add(APIKeyRotationGracePeriod)
+		if old.ExpiresAt == nil || graceEnd.Before(*old.ExpiresAt) {
+			old.ExpiresAt = &graceEnd
+		}
+		return repo.UpdateAPIKey(ctx, &old)
+	})
+	if err != nil {
+		return entity.APIKey{}, "", fmt.Errorf("gagal merotasi API key: %w", err)
+	}
+	return rotated, key, nil
+}
+
+func (s *apiKeyService) AuthenticateAPIKey(ctx context.Context, key string) (entity.User, entity.APIKey, error) {
+	apiKey, err := s.apiKeyRepo.GetAPIKeyByHash(ctx, hashToken(key))
+	if err != nil {
+		return entity.User{}, entity.APIKey{}, fmt.Errorf("gagal mendapatkan API key: %v", err)
+	}
+	now := time.Now().UTC()
+	if !apiKey.Active(now) {
+		return entity.User{}, entity.APIKey{}, ErrAPIKeyRejected
+	}
+	user, err := s.userService.GetUserByID(ctx, apiKey.UserID)
+	if err != nil {
+		return entity.User{}, entity.APIKey{}, err
+	}
+	if user.ID == 0 {
+		return entity.User{}, entity.APIKey{}, ErrAPIKeyRejected
+	}
+
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= APIKeyTouchInterval {
+		if err := s.apiKeyRepo.TouchAPIKey(ctx, apiKey.ID, now); err != nil {
+			s.logger.ErrorContext(ctx, "Error touching API key", "api_key_id", apiKey.ID, "error", err)
+		} else {
+			apiKey.LastUsedAt = &now
+		}
+	}
+	return user, apiKey, nil
+}
+
+// validateAPIKey memeriksa data key baru dan mengembalikan scope-nya tanpa
+// duplikat.
+func validateAPIKey(newKey NewAPIKey) ([]string, error) {
+	if strings.TrimSpace(newKey.Name) == "" {
+		return nil, fmt.Errorf("%w: nama wajib diisi", ErrInvalidAPIKey)
+	}
+	if len(newKey.Scopes) == 0 {
+		return nil, fmt.Errorf("%w: minimal satu scope", ErrInvalidAPIKey)
+	}
+	var scopes []string
+	seen := map[string]bool{}
+	for _, scope := range newKey.Scopes {
+		if !entity.IsValidScope(scope) {
+			return nil, fmt.Errorf("%w: scope %q tidak dikenal", ErrInvalidAPIKey, scope)
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+	if newKey.TransferLimit < 0 {
+		return nil, fmt.Errorf("%w: batas transfer tidak boleh negatif", ErrInvalidAPIKey)
+	}
+	if seen[entity.ScopeTransfersCreate] != (newKey.TransferLimit > 0) {
+		return nil, fmt.Errorf("%w: batas transfer wajib diisi jika dan hanya jika scope %s diberikan", ErrInvalidAPIKey, entity.ScopeTransfersCreate)
+	}
+	if newKey.ExpiresAt != nil && !newKey.ExpiresAt.After(time.Now()) {
+		return nil, fmt.Errorf("%w: waktu kedaluwarsa sudah lewat", ErrInvalidAPIKey)
+	}
+	return scopes, nil
+}
+
+// createAPIKey membuat nilai key baru untuk apiKey lalu menyimpannya.
+func createAPIKey(ctx context.Context, repo IAPIKeyRepository, apiKey *entity.APIKey) (string, error) {
+	token, err := newToken()
+	if err != nil {
+		return "", err
+	}
+	key := APIKeyPrefix + token
+	apiKey.Prefix = key[:len(APIKeyPrefix)+6]
+	apiKey.KeyHash = hashToken(key)
+	if err := repo.CreateAPIKey(ctx, apiKey); err != nil {
+		return "", err
+	}
+	return key, nil
+}
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/susilo001/simple-wallet-system/user/entity"
+)
+
+const (
+	DefaultAuditPageSize = 100
+	MaxAuditPageSize     = 1000
+)
+
+// auditVerifyBatchSize adalah jumlah entri yang dibaca verifier sekaligus.
+const auditVerifyBatchSize = 500
+
+// AuditFilter memilih entri audit. Field yang kosong tidak menyaring.
+type AuditFilter struct {
+	Actor     string
+	Action    string
+	Target    string
+	RequestID string
+	From      time.Time
+	To        time.Time
+	// AfterSequence untuk paging: hanya entri dengan sequence lebih besar
+	// yang dikembalikan.
+	AfterSequence int64
+	Limit         int
+}
+
+// AuditVerification adalah hasil pemeriksaan rantai hash audit.
+type AuditVerification struct {
+	Entries int64
+	Valid   bool
+	// BrokenAt adalah sequence entri pertama yang gagal diperiksa.
+	BrokenAt int64
+	Problem  string
+}
+
+type IAuditService interface {
+	Record(ctx context.Context, entry entity.AuditEntry) (entity.AuditEntry, error)
+	ListAuditEntries(ctx context.Context, filter AuditFilter) ([]entity.AuditEntry, error)
+	VerifyAuditLog(ctx context.Context) (AuditVerification, error)
+}
+
+type IAuditRepository interface {
+	Transaction(ctx context.Context, fn func(repo IAuditRepository) error) error
+	// GetAuditChainHeadForUpdate mengunci kepala rantai, dan membuatnya
+	// terlebih dahulu jika log masih kosong.
+	GetAuditChainHeadForUpdate(ctx context.Context) (entity.AuditChainHead, error)
+	GetAuditChainHead(ctx context.Context) (entity.AuditChainHead, error)
+	SaveAuditChainHead(ctx context.Context, head *entity.AuditChainHead) error
+	CreateAuditEntry(ctx context.Context, entry *entity.AuditEntry) error
+	GetAuditEntries(ctx context.Context, filter AuditFilter) ([]entity.AuditEntry, error)
+}
+
+type auditService struct {
+	auditRepo IAuditRepository
+}
+
+func NewAuditService(auditRepo IAuditRepository) IAuditService {
+	return &auditService{auditRepo: auditRepo}
+}
+
+// Record menambahkan entri ke log audit dan menyambungkannya ke kepala
+// rantai saat ini.
+func (s *auditService) Record(ctx context.Context, entry entity.AuditEntry) (entity.AuditEntry, error) {
+	err := s.auditRepo.Transaction(ctx, func(repo IAuditRepository) error {
+		head, err := repo.GetAuditChainHeadForUpdate(ctx)
+		if err != nil {
+			return err
+		}
+
+		entry.ID = 0
+		entry.Sequence = head.Sequence + 1
+		entry.PrevHash = head.Hash
+		// Database menyimpan mikrodetik; hash nilai yang akan dibaca kembali.
+		entry.CreatedAt = time.Now().UTC().Truncate(time.Microsecond)
+		entry.Hash = auditHash(entry)
+		if err := repo.CreateAuditEntry(ctx, &entry); err != nil {
+			return err
+		}
+
+		head.Sequence = entry.Sequence
+		head.Hash = entry.Hash
+		return repo.SaveAuditChainHead(ctx, &head)
+	})
+	if err != nil {
+		return entity.AuditEntry{}, fmt.Errorf("gagal mencatat entri audit: %w", err)
+	}
+	return entry, nil
+}
+
+func (s *auditService) ListAuditEntries(ctx context.Context, filter AuditFilter) ([]entity.AuditEntry, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultAuditPageSize
+	}
+	if filter.Limit > MaxAuditPageSize {
+		filter.Limit = MaxAuditPageSize
+	}
+
+	entries, err := s.auditRepo.GetAuditEntries(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mendapatkan entri audit: %w", err)
+	}
+	return entries, nil
+}
+
+// VerifyAuditLog membaca seluruh log berurutan dan memeriksa bahwa sequence
+// tidak berlubang, setiap entri tersambung ke hash entri sebelumnya, setiap
+// hash cocok dengan isi entrinya, dan entri terakhir adalah kepala rantai.
+func (s *auditService) VerifyAuditLog(ctx context.Context) (AuditVerification, error) {
+	head, err := s.auditRepo.GetAuditChainHead(ctx)
+	if err != nil {
+		return AuditVerification{}, fmt.Errorf("gagal memverifikasi log audit: %w", err)
+	}
+
+	var result AuditVerification
+	var last entity.AuditEntry
+	for {
+		entries, err := s.auditRepo.GetAuditEntries(ctx, AuditFilter{AfterSequence: last.Sequence, Limit: auditVerifyBatchSize})
+		if err != nil {
+			return AuditVerification{}, fmt.Errorf("gagal memverifikasi log audit: %w", err)
+		}
+
+		for _, entry := range entries {
+			result.Entries++
+			switch {
+			case entry.Sequence != last.Sequence+1:
+				return broken(result, last.Sequence+1, fmt.Sprintf("entri %d hilang", last.Sequence+1)), nil
+			case entry.PrevHash != last.Hash:
+				return broken(result, entry.Sequence, "hash sebelumnya tidak cocok dengan entri sebelumnya"), nil
+			case entry.Hash != auditHash(entry):
+				return broken(result, entry.Sequence, "hash tidak cocok dengan isi entri"), nil
+			}
+			last = entry
+		}
+		if len(entries) < auditVerifyBatchSize {
+			break
+		}
+	}
+
+	if last.Sequence != head.Sequence || last.Hash != head.Hash {
+		return broken(result, last.Sequence+1, fmt.Sprintf("log berakhir di entri %d tetapi kepala rantai di entri %d", last.Sequence, head.Sequence)), nil
+	}
+	result.Valid = true
+	return result, nil
+}
+
+func broken(result AuditVerification, sequence int64, problem string) AuditVerification {
+	result.BrokenAt = sequence
+	result.Problem = problem
+	return result
+}
+
+// auditHash meng-hash semua field entri kecuali ID dan Hash. Setiap field
+// diawali panjangnya agar memindahkan teks antar field mengubah hash.
+func auditHash(entry entity.AuditEntry) string {
+	h := sha256.New()
+	for _, field := range []string{
+		entry.PrevHash,
+		strconv.FormatInt(entry.Sequence, 10),
+		entry.CreatedAt.UTC().Format(time.RFC3339Nano),
+		entry.Actor,
+		entry.Action,
+		entry.Target,
+		entry.Request,
+		entry.Before,
+		entry.After,
+		entry.Outcome,
+		entry.Error,
+		entry.RequestID,
+	} {
+		fmt.Fprintf(h, "%d:%s\n", len(field), field)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/susilo001/simple-wallet-system/user/entity"
+)
+
+// memoryAuditRepository menyimpan log audit di memori. Transaksi tidak
+// di-rollback.
+type memoryAuditRepository struct {
+	head    entity.AuditChainHead
+	entries []entity.AuditEntry
+}
+
+func (r *memoryAuditRepository) Transaction(ctx context.Context, fn func(repo IAuditRepository) error) error {
+	return fn(r)
+}
+
+func (r *memoryAuditRepository) GetAuditChainHeadForUpdate(ctx context.Context) (entity.AuditChainHead, error) {
+	return r.head, nil
+}
+
+func (r *memoryAuditRepository) GetAuditChainHead(ctx context.Context) (entity.AuditChainHead, error) {
+	return r.head, nil
+}
+
+func (r *memoryAuditRepository) SaveAuditChainHead(ctx context.Context, head *entity.AuditChainHead) error {
+	r.head = *head
+	return nil
+}
+
+func (r *memoryAuditRepository) CreateAuditEntry(ctx context.Context, entry *entity.AuditEntry) error {
+	r.entries = append(r.entries, *entry)
+	return nil
+}
+
+func (r *memoryAuditRepository) GetAuditEntries(ctx context.Context, filter AuditFilter) ([]entity.AuditEntry, error) {
+	var entries []entity.AuditEntry
+	for _, entry := range r.entries {
+		if entry.Sequence > filter.AfterSequence && len(entries) < filter.Limit {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+func TestAuditHash(t *testing.T) {
+	entry := entity.AuditEntry{Sequence: 1, Actor: "admin", Action: "UpdateUser", Target: "user:1"}
+	if auditHash(entry) != auditHash(entry) {
+		t.Fatal("auditHash() tidak deterministik")
+	}
+
+	moved := entry
+	moved.Actor, moved.Action = "adminUpdate", "User"
+	if auditHash(moved) == auditHash(entry) {
+		t.Error("auditHash() tidak berubah saat teks dipindah antar field")
+	}
+
+	relinked := entry
+	relinked.PrevHash = "0000"
+	if auditHash(relinked) == auditHash(entry) {
+		t.Error("auditHash() tidak berubah saat hash sebelumnya berubah")
+	}
+
+	stored := entry
+	stored.ID, stored.Hash = 42, auditHash(entry)
+	if auditHash(stored) != auditHash(entry) {
+		t.Error("auditHash() berubah karena ID atau Hash")
+	}
+}
+
+func TestVerifyAuditLog(t *testing.T) {
+	tests := []struct {
+		name         string
+		entries      int
+		tamper       func(repo *memoryAuditRepository)
+		wantBrokenAt int64
+	}{
+		{name: "log kosong", entries: 0},
+		{name: "log utuh", entries: 3},
+		{name: "log utuh lebih dari satu batch", entries: auditVerifyBatchSize + 1},
+		{
+			name:         "entri diubah",
+			entries:      3,
+			tamper:       func(repo *memoryAuditRepository) { repo.entries[1].Outcome = "OK" },
+			wantBrokenAt: 2,
+		},
+		{
+			name:         "entri dihapus",
+			entries:      3,
+			tamper:       func(repo *memoryAuditRepository) { repo.entries = append(repo.entries[:1], repo.entries[2:]...) },
+			wantBrokenAt: 2,
+		},
+		{
+			name:    "entri diubah dan di-hash ulang",
+			entries: 3,
+			tamper: func(repo *memoryAuditRepository) {
+				repo.entries[1].Outcome = "OK"
+				repo.entries[1].Hash = auditHash(repo.entries[1])
+			},
+			wantBrokenAt: 3,
+		},
+		{
+			name:         "log terpotong",
+			entries:      3,
+			tamper:       func(repo *memoryAuditRepository) { repo.entries = repo.entries[:2] },
+			wantBrokenAt: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := &memoryAuditRepository{}
+			s := NewAuditService(repo)
+			for i := 0; i < tt.entries; i++ {
+				if _, err := s.Record(ctx, entity.AuditEntry{Actor: "admin", Action: "UpdateUser", Target: "user:1", Outcome: "Unavailable"}); err != nil {
+					t.Fatalf("Record() error = %v", err)
+				}
+			}
+			if tt.tamper != nil {
+				tt.tamper(repo)
+			}
+
+			got, err := s.VerifyAuditLog(ctx)
+			if err != nil {
+				t.Fatalf("VerifyAuditLog() error = %v", err)
+			}
+			if got.Valid != (tt.wantBrokenAt == 0) || got.BrokenAt != tt.wantBrokenAt {
+				t.Errorf("VerifyAuditLog() = %+v, ingin putus di %d", got, tt.wantBrokenAt)
+			}
+			if got.Valid && got.Entries != int64(tt.entries) {
+				t.Errorf("VerifyAuditLog() memeriksa %d entri, ingin %d", got.Entries, tt.entries)
+			}
+		})
+	}
+}
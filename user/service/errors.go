@@ -0,0 +1,50 @@
+package service
+
+import "errors"
+
+var (
+	// ErrUserNotFound dikembalikan jika user dengan ID yang diminta tidak ada.
+	ErrUserNotFound = errors.New("user tidak ditemukan")
+	// ErrInvalidCredentials dikembalikan jika email atau password salah.
+	// Pesannya sengaja sama untuk keduanya agar email yang terdaftar tidak
+	// bisa ditebak.
+	ErrInvalidCredentials = errors.New("email atau password salah")
+	// ErrInvalidRole dikembalikan untuk role yang tidak dikenal.
+	ErrInvalidRole = errors.New("role tidak valid")
+	// ErrInvalidKYCSubmission dikembalikan untuk pengajuan KYC yang datanya
+	// tidak lengkap atau levelnya tidak bisa diajukan.
+	ErrInvalidKYCSubmission = errors.New("pengajuan KYC tidak valid")
+	// ErrKYCSubmissionPending dikembalikan jika user masih punya pengajuan
+	// yang belum diputuskan.
+	ErrKYCSubmissionPending  = errors.New("masih ada pengajuan KYC yang sedang diproses")
+	ErrKYCSubmissionNotFound = errors.New("pengajuan KYC tidak ditemukan")
+	// ErrKYCSubmissionClosed dikembalikan saat memutuskan pengajuan yang
+	// sudah diputuskan.
+	ErrKYCSubmissionClosed = errors.New("pengajuan KYC sudah diputuskan")
+	// ErrEmailNotVerified dikembalikan saat login dengan email yang belum
+	// diverifikasi.
+	ErrEmailNotVerified = errors.New("email belum diverifikasi")
+	// ErrInvalidToken dikembalikan untuk token email yang tidak ada, sudah
+	// dipakai atau kedaluwarsa.
+	ErrInvalidToken = errors.New("token tidak valid atau sudah kedaluwarsa")
+	ErrWeakPassword = errors.New("password terlalu pendek")
+	// ErrTwoFactorEnabled dikembalikan saat mendaftarkan TOTP untuk user
+	// yang 2FA-nya sudah aktif.
+	ErrTwoFactorEnabled    = errors.New("2FA sudah aktif")
+	ErrTwoFactorNotEnabled = errors.New("2FA belum aktif")
+	// ErrInvalidSecondFactor dikembalikan untuk kode TOTP atau kode cadangan
+	// yang salah atau sudah dipakai.
+	ErrInvalidSecondFactor = errors.New("kode 2FA salah")
+	// ErrSecondFactorLocked dikembalikan setelah terlalu banyak kode salah.
+	ErrSecondFactorLocked = errors.New("terlalu banyak kode 2FA yang salah, coba lagi nanti")
+	// ErrInvalidSession dikembalikan untuk access token atau refresh token
+	// yang tidak ada, kedaluwarsa, atau sesinya sudah dicabut.
+	ErrInvalidSession  = errors.New("sesi tidak valid atau sudah berakhir")
+	ErrSessionNotFound = errors.New("sesi tidak ditemukan")
+	// ErrInvalidAPIKey dikembalikan untuk data API key yang tidak valid.
+	ErrInvalidAPIKey  = errors.New("API key tidak valid")
+	ErrAPIKeyNotFound = errors.New("API key tidak ditemukan")
+	// ErrAPIKeyRejected dikembalikan saat autentikasi dengan API key yang
+	// tidak ada, kedaluwarsa atau sudah dicabut.
+	ErrAPIKeyRejected = errors.New("API key ditolak")
+)
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/susilo001/simple-wallet-system/user/entity"
+	"github.com/susilo001/simple-wallet-system/user/kyc"
+	"github.com/susilo001/simple-wallet-system/user/pagination"
+)
+
+// kycLevelDocuments adalah jenis dokumen yang diterima untuk setiap level.
+var kycLevelDocuments = map[string][]string{
+	entity.KYCLevelBasic: {entity.DocumentNationalID, entity.DocumentPassport, entity.DocumentDriverLicense},
+	entity.KYCLevelFull:  {entity.DocumentNationalID, entity.DocumentPassport},
+}
+
+type IKYCService interface {
+	// SubmitKYC mencatat pengajuan lalu langsung memeriksanya dengan
+	// verifier. Pengajuan yang tidak bisa diputuskan verifier tetap pending
+	// sampai diperiksa staf lewat ReviewKYCSubmission.
+	SubmitKYC(ctx context.Context, submission entity.KYCSubmission) (entity.KYCSubmission, error)
+	// GetKYCSubmissions mengembalikan satu halaman pengajuan milik userID
+	// (semua user jika 0) dengan status tersebut (semua status jika kosong)
+	// dan jumlah semua pengajuan itu.
+	GetKYCSubmissions(ctx context.Context, userID int, status string, page pagination.Page) ([]entity.KYCSubmission, int, error)
+	ReviewKYCSubmission(ctx context.Context, id int, approve bool, reviewer string, reason string) (entity.KYCSubmission, error)
+	GetKYCLevel(ctx context.Context, userID int) (string, error)
+}
+
+type IKYCRepository interface {
+	Transaction(ctx context.Context, fn func(repo IKYCRepository) error) error
+	CreateKYCSubmission(ctx context.Context, submission *entity.KYCSubmission) error
+	GetKYCSubmissionForUpdate(ctx context.Context, id int) (entity.KYCSubmission, error)
+	HasPendingKYCSubmission(ctx context.Context, userID int) (bool, error)
+	GetKYCSubmissions(ctx context.Context, userID int, status string, page pagination.Page) ([]entity.KYCSubmission, int, error)
+	UpdateKYCSubmission(ctx context.Context, submission *entity.KYCSubmission) error
+	UpdateUserKYCLevel(ctx context.Context, userID int, level string) error
+}
+
+type kycService struct {
+	kycRepo  IKYCRepository
+	userRepo IUserRepository
+	verifier kyc.Verifier
+	logger   *slog.Logger
+}
+
+// NewKYCService membuat service KYC yang memeriksa pengajuan dengan
+// verifier.
+func NewKYCService(kycRepo IKYCRepository, userRepo IUserRepository, verifier kyc.Verifier, logger *slog.Logger) IKYCService {
+	return &kycService{kycRepo: kycRepo, userRepo: userRepo, verifier: verifier, logger: logger}
+}
+
+func (s *kycService) SubmitKYC(ctx context.Context, submission entity.KYCSubmission) (entity.KYCSubmission, error) {
+	if err := validateKYCSubmission(&submission); err != nil {
+		return entity.KYCSubmission{}, err
+	}
+	user, err := s.userRepo.GetUserByID(ctx, submission.UserID)
+	if err != nil {
+		return entity.KYCSubmission{}, fmt.Errorf("gagal mendapatkan pengguna berdasarkan ID: %v", err)
+	}
+	if user.ID == 0 {
+		return entity.KYCSubmission{}, ErrUserNotFound
+	}
+	// Level hanya bisa naik satu per satu
+	if entity.KYCLevelRank(submission.Level) != entity.KYCLevelRank(kycLevel(user))+1 {
+		return entity.KYCSubmission{}, fmt.Errorf("%w: level %s tidak bisa diajukan dari level %s", ErrInvalidKYCSubmission, submission.Level, kycLevel(user))
+	}
+	pending, err := s.kycRepo.HasPendingKYCSubmission(ctx, user.ID)
+	if err != nil {
+		return entity.KYCSubmission{}, fmt.Errorf("gagal memeriksa pengajuan KYC: %v", err)
+	}
+	if pending {
+		return entity.KYCSubmission{}, ErrKYCSubmissionPending
+	}
+
+	submission.Status = entity.KYCStatusPending
+	if err := s.kycRepo.CreateKYCSubmission(ctx, &submission); err != nil {
+		return entity.KYCSubmission{}, fmt.Errorf("gagal membuat pengajuan KYC: %v", err)
+	}
+
+	// Jika verifier gagal, pengajuan tetap pending untuk diperiksa manual
+	result, err := s.verifier.Verify(ctx, submission)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error verifying KYC submission", "submission_id", submission.ID, "error", err)
+		return submission, nil
+	}
+	if result.Status == entity.KYCStatusPending {
+		submission.Reason = result.Reason
+		if err := s.kycRepo.UpdateKYCSubmission(ctx, &submission); err != nil {
+			return entity.KYCSubmission{}, fmt.Errorf("gagal memperbarui pengajuan KYC: %v", err)
+		}
+		return submission, nil
+	}
+	return s.decide(ctx, submission.ID, result.Status == entity.KYCStatusVerified, s.verifier.Name(), result.Reason)
+}
+
+func (s *kycService) GetKYCSubmissions(ctx context.Context, userID int, status string, page pagination.Page) ([]entity.KYCSubmission, int, error) {
+	submissions, total, err := s.kycRepo.GetKYCSubmissions(ctx, userID, status, page)
+	if err != nil {
+		return nil, 0, fmt.Errorf("gagal mendapatkan pengajuan KYC: %v", err)
+	}
+	return submissions, total, nil
+}
+
+// ReviewKYCSubmission memutuskan pengajuan yang masih pending secara
+// manual. Penolakan harus disertai alasan.
+func (s *kycService) ReviewKYCSubmission(ctx context.Context, id int, approve bool, reviewer string, reason string) (entity.KYCSubmission, error) {
+	if reviewer == "" {
+		return entity.KYCSubmission{}, fmt.Errorf("%w: reviewer wajib diisi", ErrInvalidKYCSubmission)
+	}
+	if !approve && strings.TrimSpace(reason) == "" {
+		return entity.KYCSubmission{}, fmt.Errorf("%w: alasan penolakan wajib diisi", ErrInvalidKYCSubmission)
+	}
+	return s.decide(ctx, id, approve, reviewer, strings.TrimSpace(reason))
+}
+
+func (s *kycService) GetKYCLevel(ctx context.Context, userID int) (string, error) {
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("gagal mendapatkan pengguna berdasarkan ID: %v", err)
+	}
+	if user.ID == 0 {
+		return "", ErrUserNotFound
+	}
+	return kycLevel(user), nil
+}
+
+// decide menyelesaikan pengajuan yang masih pending. Jika diterima, level
+// KYC user dinaikkan dalam transaksi database yang sama.
+func (s *kycService) decide(ctx context.Context, id int, approve bool, reviewer string, reason string) (entity.KYCSubmission, error) {
+	var submission entity.KYCSubmission
+	err := s.kycRepo.Transaction(ctx, func(repo IKYCRepository) error {
+		var err error
+		submission, err = repo.GetKYCSubmissionForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if submission.ID == 0 {
+			return ErrKYCSubmissionNotFound
+		}
+		if submission.Status != entity.KYCStatusPending {
+			return fmt.Errorf("%w: status pengajuan %d sudah %s", ErrKYCSubmissionClosed, id, submission.Status)
+		}
+
+		now := time.Now().UTC()
+		submission.Status = entity.KYCStatusRejected
+		if approve {
+			submission.Status = entity.KYCStatusVerified
+		}
+		submission.Reason = reason
+		submission.ReviewedBy = reviewer
+		submission.ReviewedAt = &now
+		if err := repo.UpdateKYCSubmission(ctx, &submission); err != nil {
+			return err
+		}
+		if approve {
+			return repo.UpdateUserKYCLevel(ctx, submission.UserID, submission.Level)
+		}
+		return nil
+	})
+	if err != nil {
+		return entity.KYCSubmission{}, fmt.Errorf("gagal memutuskan pengajuan KYC: %w", err)
+	}
+	return submission, nil
+}
+
+func validateKYCSubmission(submission *entity.KYCSubmission) error {
+	submission.DocumentNumber = strings.TrimSpace(submission.DocumentNumber)
+	submission.DocumentCountry = strings.ToUpper(strings.TrimSpace(submission.DocumentCountry))
+
+	documents, ok := kycLevelDocuments[submission.Level]
+	if !ok {
+		return fmt.Errorf("%w: level %q tidak bisa diajukan", ErrInvalidKYCSubmission, submission.Level)
+	}
+	accepted := false
+	for _, document := range documents {
+		accepted = accepted || document == submission.DocumentType
+	}
+	switch {
+	case !accepted:
+		return fmt.Errorf("%w: dokumen %q tidak diterima untuk level %s", ErrInvalidKYCSubmission, submission.DocumentType, submission.Level)
+	case submission.DocumentNumber == "":
+		return fmt.Errorf("%w: nomor dokumen wajib diisi", ErrInvalidKYCSubmission)
+	case len(submission.DocumentCountry) != 2:
+		return fmt.Errorf("%w: negara dokumen harus kode ISO 3166 dua huruf", ErrInvalidKYCSubmission)
+	case submission.DocumentExpiry.IsZero():
+		return fmt.Errorf("%w: tanggal kedaluwarsa dokumen wajib diisi", ErrInvalidKYCSubmission)
+	}
+	return nil
+}
+
+// kycLevel menganggap user tanpa level KYC belum diverifikasi.
+func kycLevel(user entity.User) string {
+	if user.KYCLevel == "" {
+		return entity.KYCLevelNone
+	}
+	return user.KYCLevel
+}
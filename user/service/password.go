@@ -0,0 +1,30 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// dummyPasswordHash dibandingkan saat email tidak terdaftar agar Authenticate
+// tetap menghabiskan waktu satu kali bcrypt.
+var dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("dummy-password"), bcrypt.DefaultCost)
+
+// hashPassword mengubah password menjadi hash bcrypt yang disimpan di
+// database. Password tidak pernah disimpan dalam bentuk asli.
+func hashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if errors.Is(err, bcrypt.ErrPasswordTooLong) {
+		return "", fmt.Errorf("%w: maksimal 72 byte", ErrWeakPassword)
+	}
+	if err != nil {
+		return "", fmt.Errorf("gagal meng-hash password: %v", err)
+	}
+	return string(hash), nil
+}
+
+// checkPassword melaporkan apakah password cocok dengan hash yang disimpan.
+func checkPassword(hash string, password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
@@ -0,0 +1,284 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/susilo001/simple-wallet-system/user/entity"
+	"github.com/susilo001/simple-wallet-system/user/pagination"
+)
+
+const (
+	// AccessTokenTTL adalah masa berlaku access token. Access token yang
+	// bocor hanya berguna sebentar; setelah itu perlu refresh token.
+	AccessTokenTTL = 15 * time.Minute
+	// RefreshTokenTTL adalah masa berlaku refresh token. Sesi yang tidak
+	// dipakai selama ini berakhir.
+	RefreshTokenTTL = 30 * 24 * time.Hour
+	// SessionTouchInterval membatasi seberapa sering LastSeenAt diperbarui,
+	// agar tidak setiap request menulis ke database.
+	SessionTouchInterval = time.Minute
+)
+
+// SessionInfo adalah perangkat tempat user login.
+type SessionInfo struct {
+	Device    string
+	IP        string
+	UserAgent string
+}
+
+// SessionTokens adalah token sebuah sesi. Nilai aslinya hanya dikembalikan
+// sekali ini; yang disimpan hanya hash-nya.
+type SessionTokens struct {
+	SessionID        int
+	AccessToken      string
+	AccessExpiresAt  time.Time
+	RefreshToken     string
+	RefreshExpiresAt time.Time
+}
+
+// ISessionService mengelola sesi login dan tokennya.
+type ISessionService interface {
+	// Login memeriksa email dan password seperti Authenticate lalu membuat
+	// sesi baru.
+	Login(ctx context.Context, email string, password string, info SessionInfo) (SessionTokens, error)
+	// Refresh menukar refresh token dengan access token dan refresh token
+	// baru. Refresh token yang sudah pernah ditukar mencabut seluruh sesi.
+	Refresh(ctx context.Context, refreshToken string, info SessionInfo) (SessionTokens, error)
+	// AuthenticateAccessToken mengembalikan user dan sesi pemilik access
+	// token.
+	AuthenticateAccessToken(ctx context.Context, accessToken string) (entity.User, entity.Session, error)
+	// GetSessions mengembalikan satu halaman sesi user yang masih aktif dan
+	// jumlah semua sesi aktifnya.
+	GetSessions(ctx context.Context, userID int, page pagination.Page) ([]entity.Session, int, error)
+	RevokeSession(ctx context.Context, userID int, sessionID int, reason string) error
+	// RevokeAllSessions mencabut semua sesi aktif user kecuali
+	// exceptSessionID (0 untuk semuanya) dan mengembalikan jumlahnya.
+	RevokeAllSessions(ctx context.Context, userID int, exceptSessionID int) (int, error)
+}
+
+type ISessionRepository interface {
+	Transaction(ctx context.Context, fn func(repo ISessionRepository) error) error
+	CreateSession(ctx context.Context, session *entity.Session) error
+	// GetSessionForUpdate dan GetSessionByAccessToken mengembalikan sesi
+	// kosong jika tidak ada.
+	GetSessionForUpdate(ctx context.Context, id int) (entity.Session, error)
+	GetSessionByAccessToken(ctx context.Context, tokenHash string) (entity.Session, error)
+	UpdateSession(ctx context.Context, session *entity.Session) error
+	TouchSession(ctx context.Context, id int, lastSeenAt time.Time) error
+	// GetSessions mengembalikan sesi user yang belum dicabut dan masih
+	// berlaku pada activeAt, yang terakhir dipakai lebih dulu.
+	GetSessions(ctx context.Context, userID int, activeAt time.Time, page pagination.Page) ([]entity.Session, int, error)
+	RevokeSessions(ctx context.Context, userID int, exceptSessionID int, reason string, revokedAt time.Time) (int, error)
+	CreateRefreshToken(ctx context.Context, token *entity.RefreshToken) error
+	GetRefreshTokenForUpdate(ctx context.Context, tokenHash string) (entity.RefreshToken, error)
+	UseRefreshToken(ctx context.Context, id int, usedAt time.Time) error
+}
+
+type sessionService struct {
+	sessionRepo ISessionRepository
+	userService IUserService
+	logger      *slog.Logger
+}
+
+// NewSessionService membuat service sesi. Login memakai
+// userService.Authenticate sehingga aturan login yang sama berlaku.
+func NewSessionService(sessionRepo ISessionRepository, userService IUserService, logger *slog.Logger) ISessionService {
+	return &sessionService{
+		sessionRepo: sessionRepo,
+		userService: userService,
+		logger:      logger,
+	}
+}
+
+func (s *sessionService) Login(ctx context.Context, email string, password string, info SessionInfo) (SessionTokens, error) {
+	user, err := s.userService.Authenticate(ctx, email, password)
+	if err != nil {
+		return SessionTokens{}, err
+	}
+
+	now := time.Now().UTC()
+	session := entity.Session{
+		UserID:     user.ID,
+		Device:     info.Device,
+		IP:         info.IP,
+		UserAgent:  info.UserAgent,
+		LastSeenAt: now,
+	}
+	var tokens SessionTokens
+	err = s.sessionRepo.Transaction(ctx, func(repo ISessionRepository) error {
+		var err error
+		tokens, err = issueSessionTokens(ctx, repo, &session, now)
+		return err
+	})
+	if err != nil {
+		return SessionTokens{}, fmt.Errorf("gagal membuat sesi: %w", err)
+	}
+	return tokens, nil
+}
+
+func (s *sessionService) Refresh(ctx context.Context, refreshToken string, info SessionInfo) (SessionTokens, error) {
+	var tokens SessionTokens
+	reused := false
+	err := s.sessionRepo.Transaction(ctx, func(repo ISessionRepository) error {
+		token, err := repo.GetRefreshTokenForUpdate(ctx, hashToken(refreshToken))
+		if err != nil {
+			return err
+		}
+		if token.ID == 0 {
+			return ErrInvalidSession
+		}
+		session, err := repo.GetSessionForUpdate(ctx, token.SessionID)
+		if err != nil {
+			return err
+		}
+		now := time.Now().UTC()
+		if !session.Active(now) {
+			return ErrInvalidSession
+		}
+		if token.UsedAt != nil {
+			// Pemakai token yang sudah dirotasi tidak bisa dibedakan dari
+			// pemilik sesi, jadi seluruh keluarga token dicabut. Pencabutan
+			// harus tetap di-commit, sehingga error-nya dikembalikan di luar
+			// transaksi.
+			s.logger.WarnContext(ctx, "Refresh token reused, revoking session", "token_id", token.ID, "session_id", session.ID, "user_id", session.UserID)
+			session.RevokedAt = &now
+			session.RevokedReason = entity.SessionRevokedTokenReused
+			reused = true
+			return repo.UpdateSession(ctx, &session)
+		}
+		if !now.Before(token.ExpiresAt) {
+			return ErrInvalidSession
+		}
+		if err := repo.UseRefreshToken(ctx, token.ID, now); err != nil {
+			return err
+		}
+
+		if info.IP != "" {
+			session.IP = info.IP
+		}
+		if info.UserAgent != "" {
+			session.UserAgent = info.UserAgent
+		}
+		session.LastSeenAt = now
+		tokens, err = issueSessionTokens(ctx, repo, &session, now)
+		return err
+	})
+	if err == nil && reused {
+		err = ErrInvalidSession
+	}
+	if err != nil {
+		return SessionTokens{}, fmt.Errorf("gagal memperbarui sesi: %w", err)
+	}
+	return tokens, nil
+}
+
+func (s *sessionService) AuthenticateAccessToken(ctx context.Context, accessToken string) (entity.User, entity.Session, error) {
+	session, err := s.sessionRepo.GetSessionByAccessToken(ctx, hashToken(accessToken))
+	if err != nil {
+		return entity.User{}, entity.Session{}, fmt.Errorf("gagal mendapatkan sesi: %v", err)
+	}
+	now := time.Now().UTC()
+	if !session.Active(now) || !now.Before(session.AccessExpiresAt) {
+		return entity.User{}, entity.Session{}, ErrInvalidSession
+	}
+	user, err := s.userService.GetUserByID(ctx, session.UserID)
+	if err != nil {
+		return entity.User{}, entity.Session{}, err
+	}
+	if user.ID == 0 {
+		return entity.User{}, entity.Session{}, ErrInvalidSession
+	}
+
+	if now.Sub(session.LastSeenAt) >= SessionTouchInterval {
+		if err := s.sessionRepo.TouchSession(ctx, session.ID, now); err != nil {
+			// Sesinya tetap sah walaupun waktu terakhir dipakai gagal dicatat
+			s.logger.ErrorContext(ctx, "Error touching session", "session_id", session.ID, "error", err)
+		} else {
+			session.LastSeenAt = now
+		}
+	}
+	return user, session, nil
+}
+
+func (s *sessionService) GetSessions(ctx context.Context, userID int, page pagination.Page) ([]entity.Session, int, error) {
+	sessions, total, err := s.sessionRepo.GetSessions(ctx, userID, time.Now().UTC(), page)
+	if err != nil {
+		return nil, 0, fmt.Errorf("gagal mendapatkan sesi: %v", err)
+	}
+	return sessions, total, nil
+}
+
+func (s *sessionService) RevokeSession(ctx context.Context, userID int, sessionID int, reason string) error {
+	err := s.sessionRepo.Transaction(ctx, func(repo ISessionRepository) error {
+		session, err := repo.GetSessionForUpdate(ctx, sessionID)
+		if err != nil {
+			return err
+		}
+		if session.ID == 0 || session.UserID != userID {
+			return ErrSessionNotFound
+		}
+		// Mencabut sesi yang sudah dicabut tidak mengubah apa pun
+		if session.RevokedAt != nil {
+			return nil
+		}
+		now := time.Now().UTC()
+		session.RevokedAt = &now
+		session.RevokedReason = reason
+		return repo.UpdateSession(ctx, &session)
+	})
+	if err != nil {
+		return fmt.Errorf("gagal mencabut sesi: %w", err)
+	}
+	return nil
+}
+
+func (s *sessionService) RevokeAllSessions(ctx context.Context, userID int, exceptSessionID int) (int, error) {
+	count, err := s.sessionRepo.RevokeSessions(ctx, userID, exceptSessionID, entity.SessionRevokedAll, time.Now().UTC())
+	if err != nil {
+		return 0, fmt.Errorf("gagal mencabut semua sesi: %v", err)
+	}
+	return count, nil
+}
+
+// issueSessionTokens membuat access token dan refresh token baru untuk
+// session, lalu menyimpan sesinya (membuatnya jika belum ada).
+func issueSessionTokens(ctx context.Context, repo ISessionRepository, session *entity.Session, now time.Time) (SessionTokens, error) {
+	accessToken, err := newToken()
+	if err != nil {
+		return SessionTokens{}, err
+	}
+	refreshToken, err := newToken()
+	if err != nil {
+		return SessionTokens{}, err
+	}
+
+	session.AccessTokenHash = hashToken(accessToken)
+	session.AccessExpiresAt = now.Add(AccessTokenTTL)
+	session.ExpiresAt = now.Add(RefreshTokenTTL)
+	if session.ID == 0 {
+		err = repo.CreateSession(ctx, session)
+	} else {
+		err = repo.UpdateSession(ctx, session)
+	}
+	if err != nil {
+		return SessionTokens{}, err
+	}
+	if err := repo.CreateRefreshToken(ctx, &entity.RefreshToken{
+		SessionID: session.ID,
+		TokenHash: hashToken(refreshToken),
+		ExpiresAt: session.ExpiresAt,
+	}); err != nil {
+		return SessionTokens{}, err
+	}
+
+	return SessionTokens{
+		SessionID:        session.ID,
+		AccessToken:      accessToken,
+		AccessExpiresAt:  session.AccessExpiresAt,
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: session.ExpiresAt,
+	}, nil
+}
@@ -0,0 +1,288 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/susilo001/simple-wallet-system/user/entity"
+	"github.com/susilo001/simple-wallet-system/user/totp"
+)
+
+const (
+	// RecoveryCodeCount adalah jumlah kode cadangan yang dibuat sekaligus.
+	RecoveryCodeCount = 10
+	// MaxSecondFactorAttempts adalah jumlah kode salah berturut-turut
+	// sebelum faktor kedua dikunci selama SecondFactorLockout, karena kode
+	// 6 digit mudah ditebak jika percobaannya tidak dibatasi.
+	MaxSecondFactorAttempts = 5
+	SecondFactorLockout     = 15 * time.Minute
+)
+
+// ITwoFactorService mengelola autentikasi dua faktor dengan aplikasi
+// authenticator (TOTP) dan kode cadangan.
+type ITwoFactorService interface {
+	// EnrollTOTP membuat secret baru untuk user. 2FA baru aktif setelah
+	// ConfirmTOTP menerima kode pertama dari secret ini.
+	EnrollTOTP(ctx context.Context, userID int) (secret string, uri string, err error)
+	// ConfirmTOTP mengaktifkan 2FA dan mengembalikan kode cadangan, yang
+	// hanya ditampilkan sekali ini.
+	ConfirmTOTP(ctx context.Context, userID int, code string) ([]string, error)
+	// DisableTOTP mematikan 2FA dengan kode TOTP atau kode cadangan.
+	DisableTOTP(ctx context.Context, userID int, code string) error
+	// RegenerateRecoveryCodes mengganti semua kode cadangan user.
+	RegenerateRecoveryCodes(ctx context.Context, userID int, code string) ([]string, error)
+	// VerifySecondFactor memeriksa kode TOTP atau kode cadangan dan
+	// mengembalikan cara yang dipakai. Setiap kode hanya diterima sekali.
+	VerifySecondFactor(ctx context.Context, userID int, code string) (string, error)
+}
+
+type ITwoFactorRepository interface {
+	Transaction(ctx context.Context, fn func(repo ITwoFactorRepository) error) error
+	// GetTOTPSecretForUpdate mengembalikan secret kosong jika user belum
+	// pernah mendaftar.
+	GetTOTPSecretForUpdate(ctx context.Context, userID int) (entity.TOTPSecret, error)
+	SaveTOTPSecret(ctx context.Context, secret *entity.TOTPSecret) error
+	DeleteTOTPSecret(ctx context.Context, userID int) error
+	SetTwoFactorEnabled(ctx context.Context, userID int, enabledAt *time.Time) error
+	// ReplaceRecoveryCodes menghapus semua kode cadangan user lalu
+	// menyimpan codeHashes.
+	ReplaceRecoveryCodes(ctx context.Context, userID int, codeHashes []string) error
+	// UseRecoveryCode menandai kode cadangan yang belum dipakai sebagai
+	// sudah dipakai, dan mengembalikan false jika tidak ada.
+	UseRecoveryCode(ctx context.Context, userID int, codeHash string, usedAt time.Time) (bool, error)
+}
+
+type twoFactorService struct {
+	twoFactorRepo ITwoFactorRepository
+	userRepo      IUserRepository
+	issuer        string
+	logger        *slog.Logger
+}
+
+// NewTwoFactorService membuat service 2FA. issuer adalah nama yang tampil
+// di aplikasi authenticator.
+func NewTwoFactorService(twoFactorRepo ITwoFactorRepository, userRepo IUserRepository, issuer string, logger *slog.Logger) ITwoFactorService {
+	return &twoFactorService{
+		twoFactorRepo: twoFactorRepo,
+		userRepo:      userRepo,
+		issuer:        issuer,
+		logger:        logger,
+	}
+}
+
+func (s *twoFactorService) EnrollTOTP(ctx context.Context, userID int) (string, string, error) {
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return "", "", fmt.Errorf("gagal mendapatkan pengguna berdasarkan ID: %v", err)
+	}
+	if user.ID == 0 {
+		return "", "", ErrUserNotFound
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return "", "", fmt.Errorf("gagal membuat secret TOTP: %v", err)
+	}
+	err = s.twoFactorRepo.Transaction(ctx, func(repo ITwoFactorRepository) error {
+		existing, err := repo.GetTOTPSecretForUpdate(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if existing.ConfirmedAt != nil {
+			return ErrTwoFactorEnabled
+		}
+		// Pendaftaran yang belum dikonfirmasi diganti, termasuk kuncinya
+		return repo.SaveTOTPSecret(ctx, &entity.TOTPSecret{UserID: userID, Secret: secret})
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("gagal mendaftarkan TOTP: %w", err)
+	}
+	return secret, totp.URI(s.issuer, user.Email, secret), nil
+}
+
+func (s *twoFactorService) ConfirmTOTP(ctx context.Context, userID int, code string) ([]string, error) {
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat kode cadangan: %v", err)
+	}
+
+	failed := false
+	err = s.twoFactorRepo.Transaction(ctx, func(repo ITwoFactorRepository) error {
+		secret, err := repo.GetTOTPSecretForUpdate(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if secret.UserID == 0 {
+			return ErrTwoFactorNotEnabled
+		}
+		if secret.ConfirmedAt != nil {
+			return ErrTwoFactorEnabled
+		}
+		// Kode cadangan belum ada, jadi hanya kode TOTP yang diterima
+		method, err := s.checkSecondFactor(ctx, repo, &secret, code, false)
+		if err != nil {
+			return err
+		}
+		if method == "" {
+			failed = true
+			return nil
+		}
+
+		now := time.Now().UTC()
+		secret.ConfirmedAt = &now
+		if err := repo.SaveTOTPSecret(ctx, &secret); err != nil {
+			return err
+		}
+		if err := repo.SetTwoFactorEnabled(ctx, userID, &now); err != nil {
+			return err
+		}
+		return repo.ReplaceRecoveryCodes(ctx, userID, hashes)
+	})
+	if err == nil && failed {
+		err = ErrInvalidSecondFactor
+	}
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengaktifkan 2FA: %w", err)
+	}
+	return codes, nil
+}
+
+func (s *twoFactorService) DisableTOTP(ctx context.Context, userID int, code string) error {
+	_, err := s.withSecondFactor(ctx, userID, code, func(repo ITwoFactorRepository) error {
+		if err := repo.DeleteTOTPSecret(ctx, userID); err != nil {
+			return err
+		}
+		if err := repo.ReplaceRecoveryCodes(ctx, userID, nil); err != nil {
+			return err
+		}
+		return repo.SetTwoFactorEnabled(ctx, userID, nil)
+	})
+	if err != nil {
+		return fmt.Errorf("gagal mematikan 2FA: %w", err)
+	}
+	return nil
+}
+
+func (s *twoFactorService) RegenerateRecoveryCodes(ctx context.Context, userID int, code string) ([]string, error) {
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat kode cadangan: %v", err)
+	}
+	_, err = s.withSecondFactor(ctx, userID, code, func(repo ITwoFactorRepository) error {
+		return repo.ReplaceRecoveryCodes(ctx, userID, hashes)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat ulang kode cadangan: %w", err)
+	}
+	return codes, nil
+}
+
+func (s *twoFactorService) VerifySecondFactor(ctx context.Context, userID int, code string) (string, error) {
+	method, err := s.withSecondFactor(ctx, userID, code, func(repo ITwoFactorRepository) error {
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("gagal memverifikasi faktor kedua: %w", err)
+	}
+	return method, nil
+}
+
+// withSecondFactor menjalankan fn di transaksi yang sama setelah kode TOTP
+// atau kode cadangan user diterima. Kode yang salah tetap dicatat walaupun
+// fn tidak dijalankan, sehingga transaksinya tidak di-rollback.
+func (s *twoFactorService) withSecondFactor(ctx context.Context, userID int, code string, fn func(repo ITwoFactorRepository) error) (string, error) {
+	var method string
+	err := s.twoFactorRepo.Transaction(ctx, func(repo ITwoFactorRepository) error {
+		secret, err := repo.GetTOTPSecretForUpdate(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if secret.ConfirmedAt == nil {
+			return ErrTwoFactorNotEnabled
+		}
+		method, err = s.checkSecondFactor(ctx, repo, &secret, code, true)
+		if err != nil || method == "" {
+			return err
+		}
+		return fn(repo)
+	})
+	if err != nil {
+		return "", err
+	}
+	if method == "" {
+		return "", ErrInvalidSecondFactor
+	}
+	return method, nil
+}
+
+// checkSecondFactor mengembalikan cara yang cocok dengan code, atau string
+// kosong jika code salah. Kode salah menambah hitungan percobaan dan
+// mengunci faktor kedua setelah MaxSecondFactorAttempts kali.
+func (s *twoFactorService) checkSecondFactor(ctx context.Context, repo ITwoFactorRepository, secret *entity.TOTPSecret, code string, allowRecovery bool) (string, error) {
+	now := time.Now().UTC()
+	if secret.LockedUntil != nil && now.Before(*secret.LockedUntil) {
+		return "", ErrSecondFactorLocked
+	}
+
+	method := ""
+	code = strings.TrimSpace(code)
+	if step, ok := totp.Validate(secret.Secret, code, now); ok && step > secret.LastStep {
+		secret.LastStep = step
+		method = entity.SecondFactorTOTP
+	} else if allowRecovery && len(code) > totp.Digits {
+		used, err := repo.UseRecoveryCode(ctx, secret.UserID, hashToken(normalizeRecoveryCode(code)), now)
+		if err != nil {
+			return "", err
+		}
+		if used {
+			method = entity.SecondFactorRecoveryCode
+		}
+	}
+
+	if method == "" {
+		secret.FailedAttempts++
+		if secret.FailedAttempts >= MaxSecondFactorAttempts {
+			s.logger.WarnContext(ctx, "Locked second factor", "user_id", secret.UserID, "failed_attempts", secret.FailedAttempts)
+			lockedUntil := now.Add(SecondFactorLockout)
+			secret.LockedUntil = &lockedUntil
+			secret.FailedAttempts = 0
+		}
+	} else {
+		secret.FailedAttempts = 0
+		secret.LockedUntil = nil
+	}
+	if err := repo.SaveTOTPSecret(ctx, secret); err != nil {
+		return "", err
+	}
+	return method, nil
+}
+
+// newRecoveryCodes membuat RecoveryCodeCount kode berformat xxxx-xxxx
+// beserta hash-nya.
+func newRecoveryCodes() ([]string, []string, error) {
+	encoding := base32.StdEncoding.WithPadding(base32.NoPadding)
+	codes := make([]string, 0, RecoveryCodeCount)
+	hashes := make([]string, 0, RecoveryCodeCount)
+	for i := 0; i < RecoveryCodeCount; i++ {
+		raw := make([]byte, 5)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, nil, err
+		}
+		code := strings.ToLower(encoding.EncodeToString(raw))
+		codes = append(codes, code[:4]+"-"+code[4:])
+		hashes = append(hashes, hashToken(code))
+	}
+	return codes, hashes, nil
+}
+
+// normalizeRecoveryCode menerima kode cadangan dengan huruf besar, tanpa
+// tanda hubung atau dengan spasi.
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
@@ -3,26 +3,39 @@ package service
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/susilo001/simple-wallet-system/user/entity"
+	"github.com/susilo001/simple-wallet-system/user/pagination"
 )
 
 // IUserService mendefinisikan interface untuk layanan pengguna
 type IUserService interface {
 	CreateUser(ctx context.Context, user *entity.User) (entity.User, error)
 	GetUserByID(ctx context.Context, id int) (entity.User, error)
+	// UpdateUser mengganti nama, email dan password user. Field yang kosong
+	// tidak diubah.
 	UpdateUser(ctx context.Context, id int, user entity.User) (entity.User, error)
 	DeleteUser(ctx context.Context, id int) error
-	GetAllUsers(ctx context.Context) ([]entity.User, error)
+	// GetUsers mengembalikan satu halaman pengguna dan jumlah semua pengguna.
+	GetUsers(ctx context.Context, page pagination.Page) ([]entity.User, int, error)
+	// Authenticate mengembalikan user dengan email dan password tersebut.
+	Authenticate(ctx context.Context, email string, password string) (entity.User, error)
+	// AssignRole mengganti role user.
+	AssignRole(ctx context.Context, id int, role string) (entity.User, error)
 }
 
 // IUserRepository mendefinisikan interface untuk repository pengguna
 type IUserRepository interface {
 	CreateUser(ctx context.Context, user *entity.User) (entity.User, error)
 	GetUserByID(ctx context.Context, id int) (entity.User, error)
+	// UpdateUser menyimpan nama, email, password dan waktu verifikasi email
+	// user.
 	UpdateUser(ctx context.Context, id int, user entity.User) (entity.User, error)
 	DeleteUser(ctx context.Context, id int) error
-	GetAllUsers(ctx context.Context) ([]entity.User, error)
+	GetUsers(ctx context.Context, page pagination.Page) ([]entity.User, int, error)
+	GetUserByEmail(ctx context.Context, email string) (entity.User, error)
+	UpdateUserRole(ctx context.Context, id int, role string) error
 }
 
 // userService adalah implementasi dari IUserService yang menggunakan IUserRepository
@@ -37,6 +50,13 @@ func NewUserService(userRepo IUserRepository) IUserService {
 
 // CreateUser membuat pengguna baru
 func (s *userService) CreateUser(ctx context.Context, user *entity.User) (entity.User, error) {
+	// Pengguna baru selalu customer, role lain diberikan lewat AssignRole
+	user.Role = entity.RoleCustomer
+	hash, err := hashPassword(user.Password)
+	if err != nil {
+		return entity.User{}, err
+	}
+	user.Password = hash
 	// Memanggil CreateUser dari repository untuk membuat pengguna baru
 	createdUser, err := s.userRepo.CreateUser(ctx, user)
 	if err != nil {
@@ -55,10 +75,37 @@ func (s *userService) GetUserByID(ctx context.Context, id int) (entity.User, err
 	return user, nil
 }
 
-// UpdateUser memperbarui data pengguna
+// UpdateUser memperbarui data pengguna. Email yang berubah harus
+// diverifikasi ulang sebelum user bisa login lagi.
 func (s *userService) UpdateUser(ctx context.Context, id int, user entity.User) (entity.User, error) {
+	existing, err := s.userRepo.GetUserByID(ctx, id)
+	if err != nil {
+		return entity.User{}, fmt.Errorf("gagal mendapatkan pengguna berdasarkan ID: %v", err)
+	}
+	if existing.ID == 0 {
+		return entity.User{}, ErrUserNotFound
+	}
+
+	if name := strings.TrimSpace(user.Name); name != "" {
+		existing.Name = name
+	}
+	if email := strings.TrimSpace(user.Email); email != "" && email != existing.Email {
+		existing.Email = email
+		existing.EmailVerifiedAt = nil
+	}
+	if user.Password != "" {
+		if len(user.Password) < MinPasswordLength {
+			return entity.User{}, fmt.Errorf("%w: minimal %d karakter", ErrWeakPassword, MinPasswordLength)
+		}
+		hash, err := hashPassword(user.Password)
+		if err != nil {
+			return entity.User{}, err
+		}
+		existing.Password = hash
+	}
+
 	// Memanggil UpdateUser dari repository untuk memperbarui data pengguna
-	updatedUser, err := s.userRepo.UpdateUser(ctx, id, user)
+	updatedUser, err := s.userRepo.UpdateUser(ctx, id, existing)
 	if err != nil {
 		return entity.User{}, fmt.Errorf("gagal memperbarui pengguna: %v", err)
 	}
@@ -75,12 +122,52 @@ func (s *userService) DeleteUser(ctx context.Context, id int) error {
 	return nil
 }
 
-// GetAllUsers mendapatkan semua pengguna
-func (s *userService) GetAllUsers(ctx context.Context) ([]entity.User, error) {
-	// Memanggil GetAllUsers dari repository untuk mendapatkan semua pengguna
-	users, err := s.userRepo.GetAllUsers(ctx)
+// GetUsers mendapatkan satu halaman pengguna
+func (s *userService) GetUsers(ctx context.Context, page pagination.Page) ([]entity.User, int, error) {
+	// Memanggil GetUsers dari repository untuk mendapatkan halaman pengguna
+	users, total, err := s.userRepo.GetUsers(ctx, page)
+	if err != nil {
+		return nil, 0, fmt.Errorf("gagal mendapatkan daftar pengguna: %v", err)
+	}
+	return users, total, nil
+}
+
+// Authenticate memeriksa email dan password pengguna
+func (s *userService) Authenticate(ctx context.Context, email string, password string) (entity.User, error) {
+	user, err := s.userRepo.GetUserByEmail(ctx, email)
 	if err != nil {
-		return nil, fmt.Errorf("gagal mendapatkan semua pengguna: %v", err)
+		return entity.User{}, fmt.Errorf("gagal mendapatkan pengguna berdasarkan email: %v", err)
 	}
-	return users, nil
-}
\ No newline at end of file
+	// Password tetap dibandingkan dengan hash dummy walaupun user tidak ada
+	// agar waktu responsnya tidak membocorkan email mana yang terdaftar
+	hash := user.Password
+	if user.ID == 0 {
+		hash = string(dummyPasswordHash)
+	}
+	if !checkPassword(hash, password) || user.ID == 0 {
+		return entity.User{}, ErrInvalidCredentials
+	}
+	if user.EmailVerifiedAt == nil {
+		return entity.User{}, ErrEmailNotVerified
+	}
+	return user, nil
+}
+
+// AssignRole mengganti role pengguna
+func (s *userService) AssignRole(ctx context.Context, id int, role string) (entity.User, error) {
+	if !entity.IsValidRole(role) {
+		return entity.User{}, ErrInvalidRole
+	}
+	user, err := s.userRepo.GetUserByID(ctx, id)
+	if err != nil {
+		return entity.User{}, fmt.Errorf("gagal mendapatkan pengguna berdasarkan ID: %v", err)
+	}
+	if user.ID == 0 {
+		return entity.User{}, ErrUserNotFound
+	}
+	if err := s.userRepo.UpdateUserRole(ctx, id, role); err != nil {
+		return entity.User{}, fmt.Errorf("gagal mengganti role pengguna: %v", err)
+	}
+	user.Role = role
+	return user, nil
+}
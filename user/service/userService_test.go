@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/susilo001/simple-wallet-system/user/entity"
+)
+
+// fakeUserRepository menyimpan user di memori. Method lain panic.
+type fakeUserRepository struct {
+	IUserRepository
+	users map[int]entity.User
+}
+
+func (r *fakeUserRepository) GetUserByID(ctx context.Context, id int) (entity.User, error) {
+	return r.users[id], nil
+}
+
+func (r *fakeUserRepository) UpdateUser(ctx context.Context, id int, user entity.User) (entity.User, error) {
+	user.ID = id
+	r.users[id] = user
+	return user, nil
+}
+
+func TestUpdateUser(t *testing.T) {
+	verifiedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	existing := entity.User{ID: 1, Name: "Budi", Email: "budi@example.com", Password: "hash-lama", Role: entity.RoleCustomer, EmailVerifiedAt: &verifiedAt}
+
+	t.Run("field kosong tidak diubah", func(t *testing.T) {
+		repo := &fakeUserRepository{users: map[int]entity.User{1: existing}}
+		got, err := NewUserService(repo).UpdateUser(context.Background(), 1, entity.User{Name: "Budi Santoso"})
+		if err != nil {
+			t.Fatalf("UpdateUser() error = %v", err)
+		}
+		if got.Name != "Budi Santoso" || got.Email != existing.Email || got.Password != existing.Password || got.EmailVerifiedAt == nil {
+			t.Errorf("UpdateUser() = %+v, ingin hanya namanya berubah", got)
+		}
+	})
+
+	t.Run("email baru harus diverifikasi ulang", func(t *testing.T) {
+		repo := &fakeUserRepository{users: map[int]entity.User{1: existing}}
+		got, err := NewUserService(repo).UpdateUser(context.Background(), 1, entity.User{Email: "budi@contoh.id"})
+		if err != nil {
+			t.Fatalf("UpdateUser() error = %v", err)
+		}
+		if got.Email != "budi@contoh.id" || got.EmailVerifiedAt != nil {
+			t.Errorf("UpdateUser() = %+v, ingin email baru yang belum diverifikasi", got)
+		}
+	})
+
+	t.Run("password disimpan sebagai hash", func(t *testing.T) {
+		repo := &fakeUserRepository{users: map[int]entity.User{1: existing}}
+		got, err := NewUserService(repo).UpdateUser(context.Background(), 1, entity.User{Password: "rahasia-baru"})
+		if err != nil {
+			t.Fatalf("UpdateUser() error = %v", err)
+		}
+		if got.Password == "rahasia-baru" || !checkPassword(got.Password, "rahasia-baru") {
+			t.Errorf("UpdateUser() menyimpan password %q, ingin hash dari password baru", got.Password)
+		}
+	})
+
+	t.Run("password terlalu pendek", func(t *testing.T) {
+		repo := &fakeUserRepository{users: map[int]entity.User{1: existing}}
+		if _, err := NewUserService(repo).UpdateUser(context.Background(), 1, entity.User{Password: "pendek"}); !errors.Is(err, ErrWeakPassword) {
+			t.Errorf("UpdateUser() error = %v, ingin ErrWeakPassword", err)
+		}
+	})
+
+	t.Run("user tidak ada", func(t *testing.T) {
+		repo := &fakeUserRepository{users: map[int]entity.User{}}
+		if _, err := NewUserService(repo).UpdateUser(context.Background(), 0, entity.User{Name: "Budi"}); !errors.Is(err, ErrUserNotFound) {
+			t.Errorf("UpdateUser() error = %v, ingin ErrUserNotFound", err)
+		}
+	})
+}
@@ -0,0 +1,93 @@
+// Package totp membuat dan memeriksa kode sekali pakai berbasis waktu
+// (RFC 6238) yang dipakai aplikasi authenticator: HMAC-SHA1, 6 digit,
+// berganti setiap 30 detik.
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// Period adalah lama setiap kode berlaku.
+	Period = 30 * time.Second
+	Digits = 6
+	// Skew adalah jumlah periode sebelum dan sesudah saat ini yang kodenya
+	// masih diterima, untuk jam perangkat yang sedikit meleset.
+	Skew = 1
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret membuat secret acak 160 bit dalam base32, format yang
+// dimasukkan ke aplikasi authenticator.
+func GenerateSecret() (string, error) {
+	raw := make([]byte, 20)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return encoding.EncodeToString(raw), nil
+}
+
+// Step mengembalikan nomor periode untuk waktu t.
+func Step(t time.Time) int64 {
+	return t.Unix() / int64(Period/time.Second)
+}
+
+// Code mengembalikan kode untuk secret pada periode step.
+func Code(secret string, step int64) (string, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", fmt.Errorf("secret TOTP tidak valid: %v", err)
+	}
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", Digits, value%1000000), nil
+}
+
+// Validate mengembalikan periode kode jika code cocok dengan secret pada
+// waktu t, dengan toleransi Skew periode. Periode dikembalikan agar
+// pemanggil bisa menolak kode yang sama dipakai dua kali.
+func Validate(secret string, code string, t time.Time) (int64, bool) {
+	if len(code) != Digits {
+		return 0, false
+	}
+	now := Step(t)
+	for step := now - Skew; step <= now+Skew; step++ {
+		expected, err := Code(secret, step)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// URI mengembalikan link otpauth:// yang bisa dijadikan QR code untuk
+// aplikasi authenticator.
+func URI(issuer string, account string, secret string) string {
+	label := url.PathEscape(issuer + ":" + account)
+	query := url.Values{
+		"secret": {secret},
+		"issuer": {issuer},
+		"digits": {fmt.Sprint(Digits)},
+		"period": {fmt.Sprint(int(Period / time.Second))},
+	}
+	return "otpauth://totp/" + label + "?" + query.Encode()
+}
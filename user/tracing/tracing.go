@@ -0,0 +1,88 @@
+// Package tracing memasang tracer OpenTelemetry global. Span dari gRPC dan
+// GORM dikirim ke exporter yang dipilih di config, dan trace context dari
+// gateway diteruskan lewat metadata gRPC.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// Exporter yang didukung Setup.
+const (
+	ExporterNone   = "none"
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+	ExporterFile   = "file"
+)
+
+// Setup memasang tracer provider global untuk service dengan exporter:
+// "otlp" mengirim ke collector di endpoint (host:port, tanpa TLS), "stdout"
+// mencetak span, "file" menulis span sebagai JSON ke file, dan
+// "none" tidak mengekspor span tetapi tetap meneruskan trace context.
+// Fungsi yang dikembalikan mengirim span yang tersisa saat shutdown.
+func Setup(ctx context.Context, service, exporter, endpoint, file string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var spanExporter sdktrace.SpanExporter
+	var closeFile func() error
+	switch exporter {
+	case ExporterNone, "":
+		return func(context.Context) error { return nil }, nil
+	case ExporterOTLP:
+		otlpExporter, err := otlptracegrpc.New(ctx, otlptracegrpc.WithEndpoint(endpoint), otlptracegrpc.WithInsecure())
+		if err != nil {
+			return nil, fmt.Errorf("gagal membuat exporter OTLP: %w", err)
+		}
+		spanExporter = otlpExporter
+	case ExporterStdout:
+		stdoutExporter, err := stdouttrace.New(stdouttrace.WithPrettyPrint())
+		if err != nil {
+			return nil, fmt.Errorf("gagal membuat exporter stdout: %w", err)
+		}
+		spanExporter = stdoutExporter
+	case ExporterFile:
+		f, err := os.OpenFile(file, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("gagal membuka file trace: %w", err)
+		}
+		fileExporter, err := stdouttrace.New(stdouttrace.WithWriter(f))
+		if err != nil {
+			f.Close()
+			return nil, fmt.Errorf("gagal membuat exporter file: %w", err)
+		}
+		spanExporter = fileExporter
+		closeFile = f.Close
+	default:
+		return nil, fmt.Errorf("exporter trace tidak dikenal: %q", exporter)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(semconv.ServiceName(service)))
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat resource trace: %w", err)
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(spanExporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if closeFile != nil {
+			if closeErr := closeFile(); err == nil {
+				err = closeErr
+			}
+		}
+		return err
+	}, nil
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/susilo001/simple-wallet-system/wallet/service"
+)
+
+// runAuditVerify implements the audit-verify subcommand. It checks the hash
+// chain of the audit log and returns exit status 1 if it is broken.
+func runAuditVerify(auditService service.IAuditService) int {
+	result, err := auditService.VerifyAuditLog(context.Background())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	if !result.Valid {
+		fmt.Printf("audit log broken at entry %d: %s (%d entries checked)\n", result.BrokenAt, result.Problem, result.Entries)
+		return 1
+	}
+	fmt.Printf("audit log intact: %d entries\n", result.Entries)
+	return 0
+}
@@ -1,6 +1,106 @@
 package config
 
+import (
+	"time"
+
+	"github.com/susilo001/simple-wallet-system/wallet/entity"
+)
+
 const (
 	AuthBasicUsername = "user"
 	AuthBasicPassword = "pass"
-)
\ No newline at end of file
+)
+
+// HouseWalletUserID owns the internal wallet that collects fees. The wallet
+// is created on startup if it does not exist yet.
+const HouseWalletUserID = 0
+
+// SchedulerInterval is how often the scheduler worker looks for due
+// scheduled transfers.
+const SchedulerInterval = 30 * time.Second
+
+// PaymentRequestExpiryInterval is how often pending payment requests past
+// their expiry are marked as expired.
+const PaymentRequestExpiryInterval = time.Minute
+
+// BalanceSnapshotInterval is how often the snapshot worker checks that every
+// wallet has a balance snapshot as of the most recent UTC midnight.
+const BalanceSnapshotInterval = time.Hour
+
+// ReconciliationInterval is how often every wallet balance is reconciled
+// against the transaction history. ReconciliationProposeAdjustments makes
+// the job open a pending adjustment for each discrepancy it finds.
+const (
+	ReconciliationInterval           = 24 * time.Hour
+	ReconciliationProposeAdjustments = false
+)
+
+// Transfers scoring at least RiskReviewScore are held for manual review,
+// and those scoring at least RiskBlockScore are rejected outright. The
+// points each risk rule adds, and when it matches, are set below.
+const (
+	RiskReviewScore = 50
+	RiskBlockScore  = 90
+
+	RiskNewRecipientPoints = 20
+
+	RiskUnusualAmountPoints       = 40
+	RiskUnusualAmountMultiplier   = 5
+	RiskUnusualAmountLookback     = 90 * 24 * time.Hour
+	RiskUnusualAmountMinTransfers = 3
+
+	RiskRapidTransfersPoints   = 30
+	RiskRapidTransfersWindow   = 10 * time.Minute
+	RiskRapidTransfersMaxCount = 5
+
+	RiskNewAccountPoints = 25
+	RiskNewAccountMinAge = 7 * 24 * time.Hour
+)
+
+// UserServiceAddress is where the user service, which reports the KYC level
+// of wallet owners, listens. It accepts the same basic credentials as this
+// service.
+const UserServiceAddress = "localhost:50051"
+
+// KYCLevelLimits caps the limits of every customer wallet by its owner's
+// KYC level. A wallet or tier limit can only be lower than the cap; zero
+// leaves a rule uncapped.
+var KYCLevelLimits = map[string]entity.WalletLimit{
+	"none": {
+		MaxSingleTransfer: 1_000_000,
+		DailyOutgoing:     2_000_000,
+		MonthlyOutgoing:   10_000_000,
+		MaxBalance:        2_000_000,
+	},
+	"basic": {
+		MaxSingleTransfer: 5_000_000,
+		DailyOutgoing:     10_000_000,
+		MonthlyOutgoing:   40_000_000,
+		MaxBalance:        10_000_000,
+	},
+	"full": {
+		MaxSingleTransfer: 20_000_000,
+		DailyOutgoing:     50_000_000,
+		MonthlyOutgoing:   100_000_000,
+		MaxBalance:        20_000_000,
+	},
+}
+
+// TraceExporter chooses where OpenTelemetry spans go: "none", "otlp" to the
+// collector at TraceOTLPEndpoint, "stdout", or "file" writing to TraceFile
+// for local development.
+const (
+	TraceExporter     = "none"
+	TraceOTLPEndpoint = "localhost:4317"
+	TraceFile         = "traces.json"
+)
+
+// MetricsAddress is where Prometheus metrics are served on /metrics.
+const MetricsAddress = ":9092"
+
+// LogLevel is the lowest level logged: "debug", "info", "warn" or "error".
+// LogJSON writes logs as JSON lines instead of text.
+const (
+	LogLevel = "info"
+	LogJSON  = true
+)
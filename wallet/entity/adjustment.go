@@ -0,0 +1,44 @@
+package entity
+
+import "time"
+
+const (
+	// AdjustmentSourceReconciliation adjustments book a discrepancy found by
+	// reconciliation into the transaction history. The stored balance
+	// already holds the amount, so only the ADJUSTMENT transaction is added.
+	AdjustmentSourceReconciliation = "RECONCILIATION"
+	// AdjustmentSourceManual adjustments are credits and debits proposed by
+	// an operator. They change the stored balance and add the ADJUSTMENT
+	// transaction.
+	AdjustmentSourceManual = "MANUAL"
+)
+
+const (
+	AdjustmentStatusPending  = "PENDING"
+	AdjustmentStatusApproved = "APPROVED"
+	AdjustmentStatusRejected = "REJECTED"
+)
+
+// BalanceAdjustment is a proposed correction to a wallet that only takes
+// effect once approved by someone other than its proposer. A positive
+// Amount credits the wallet, a negative one debits it. The proposer and
+// reviewer are compared by user ID; ProposedByUserID is zero for proposals
+// of the reconciliation job.
+type BalanceAdjustment struct {
+	ID                  int        `gorm:"primaryKey;autoIncrement" json:"id"`
+	WalletID            int        `gorm:"not null;index" json:"wallet_id"`
+	Amount              float64    `gorm:"type:decimal(12,2)" json:"amount"`
+	Source              string     `gorm:"type:varchar;not null" json:"source"`
+	Status              string     `gorm:"type:varchar;not null;index" json:"status"`
+	Reason              string     `gorm:"type:varchar" json:"reason"`
+	ProposedBy          string     `gorm:"type:varchar" json:"proposed_by"`
+	ProposedByUserID    int        `gorm:"index" json:"proposed_by_user_id"`
+	ReviewedBy          string     `gorm:"type:varchar" json:"reviewed_by"`
+	ReviewedByUserID    int        `json:"reviewed_by_user_id"`
+	ReviewedAt          *time.Time `json:"reviewed_at"`
+	ReconciliationRunID int        `gorm:"index" json:"reconciliation_run_id"`
+	// TransactionID is the ADJUSTMENT transaction posted on approval.
+	TransactionID int       `json:"transaction_id"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
@@ -0,0 +1,50 @@
+package entity
+
+import "time"
+
+// AuditEntry records one call of a mutating RPC. Entries form a hash chain:
+// Hash covers every other field including PrevHash, the Hash of the entry
+// before it, so editing, inserting or deleting an entry breaks the chain
+// from that entry on.
+type AuditEntry struct {
+	ID       int    `gorm:"primaryKey;autoIncrement" json:"id"`
+	Sequence int64  `gorm:"not null;uniqueIndex" json:"sequence"`
+	Actor    string `gorm:"type:varchar;index" json:"actor"`
+	// Action is the full gRPC method name.
+	Action string `gorm:"type:varchar;index" json:"action"`
+	// Target names the object the call acts on, e.g. "wallet:12".
+	Target string `gorm:"type:varchar;index" json:"target"`
+	// Request, Before and After are JSON documents. Before and After hold
+	// the state of the target around the call when it can be loaded, and
+	// After holds the response otherwise.
+	Request string `gorm:"type:text" json:"request"`
+	Before  string `gorm:"type:text" json:"before"`
+	After   string `gorm:"type:text" json:"after"`
+	// Outcome is the gRPC status code of the call, "OK" on success.
+	Outcome   string    `gorm:"type:varchar" json:"outcome"`
+	Error     string    `gorm:"type:text" json:"error"`
+	RequestID string    `gorm:"type:varchar;index" json:"request_id"`
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+	PrevHash  string    `gorm:"type:varchar" json:"prev_hash"`
+	Hash      string    `gorm:"type:varchar;uniqueIndex" json:"hash"`
+}
+
+// TableName keeps the wallet audit log apart from the user service's one,
+// as both services may share a database.
+func (AuditEntry) TableName() string {
+	return "wallet_audit_entries"
+}
+
+// AuditChainHead is the single row holding the sequence and hash of the
+// latest audit entry. Appending locks it, which serialises the chain and
+// lets the verifier detect entries removed from the end.
+type AuditChainHead struct {
+	ID        int       `gorm:"primaryKey" json:"id"`
+	Sequence  int64     `json:"sequence"`
+	Hash      string    `gorm:"type:varchar" json:"hash"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (AuditChainHead) TableName() string {
+	return "wallet_audit_chain_heads"
+}
@@ -0,0 +1,28 @@
+package entity
+
+import "time"
+
+const (
+	BatchModeAtomic     = "ATOMIC"
+	BatchModeBestEffort = "BEST_EFFORT"
+)
+
+const (
+	BatchStatusProcessing = "PROCESSING"
+	BatchStatusCompleted  = "COMPLETED"
+	BatchStatusPartial    = "PARTIAL"
+	BatchStatusFailed     = "FAILED"
+)
+
+// TransferBatch groups the transfers of one BatchTransfer call, either from
+// one wallet to many or from many wallets to one.
+type TransferBatch struct {
+	ID             int       `gorm:"primaryKey;autoIncrement" json:"id"`
+	Mode           string    `gorm:"type:varchar;not null" json:"mode"`
+	Status         string    `gorm:"type:varchar;not null" json:"status"`
+	ItemCount      int       `json:"item_count"`
+	SucceededCount int       `json:"succeeded_count"`
+	TotalAmount    float64   `gorm:"type:decimal(12,2)" json:"total_amount"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
@@ -0,0 +1,29 @@
+package entity
+
+import "time"
+
+const (
+	FeeOperationTransfer   = "TRANSFER"
+	FeeOperationWithdrawal = "WITHDRAWAL"
+)
+
+// FeeRule prices an operation. A rule matches when its operation equals the
+// requested one, its tier is empty or equals the paying wallet's tier, and
+// the amount falls in [MinAmount, MaxAmount). A zero MaxAmount, MinFee or
+// MaxFee means unbounded. When several rules match, the one with the highest
+// priority wins.
+type FeeRule struct {
+	ID         int       `gorm:"primaryKey;autoIncrement" json:"id"`
+	Operation  string    `gorm:"type:varchar;not null;index" json:"operation"`
+	Tier       string    `gorm:"type:varchar" json:"tier"`
+	MinAmount  float64   `gorm:"type:decimal(10,2)" json:"min_amount"`
+	MaxAmount  float64   `gorm:"type:decimal(10,2)" json:"max_amount"`
+	FlatFee    float64   `gorm:"type:decimal(10,2)" json:"flat_fee"`
+	Percentage float64   `gorm:"type:decimal(5,2)" json:"percentage"`
+	MinFee     float64   `gorm:"type:decimal(10,2)" json:"min_fee"`
+	MaxFee     float64   `gorm:"type:decimal(10,2)" json:"max_fee"`
+	Priority   int       `json:"priority"`
+	Active     bool      `gorm:"not null" json:"active"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
@@ -0,0 +1,31 @@
+package entity
+
+import "time"
+
+const (
+	HeldTransferStatusPending  = "PENDING"
+	HeldTransferStatusReleased = "RELEASED"
+	HeldTransferStatusRejected = "REJECTED"
+)
+
+// HeldTransfer is a transfer the risk engine held for manual review. No
+// money moves until a reviewer releases it.
+type HeldTransfer struct {
+	ID          int     `gorm:"primaryKey;autoIncrement" json:"id"`
+	SenderID    int     `gorm:"not null;index" json:"sender_id"`
+	RecipientID int     `gorm:"not null" json:"recipient_id"`
+	Amount      float64 `gorm:"type:decimal(12,2)" json:"amount"`
+	Status      string  `gorm:"type:varchar;not null;index" json:"status"`
+	RiskScore   int     `json:"risk_score"`
+	// RiskHits is the JSON list of the rules that matched.
+	RiskHits string `gorm:"type:text" json:"risk_hits"`
+	// IdempotencyKey is the key the transfer was submitted with, used when
+	// it is released.
+	IdempotencyKey *string    `gorm:"type:varchar;index" json:"idempotency_key,omitempty"`
+	ReviewedBy     string     `gorm:"type:varchar" json:"reviewed_by"`
+	ReviewedAt     *time.Time `json:"reviewed_at"`
+	// TransactionID is the TRANSFER transaction posted on release.
+	TransactionID int       `json:"transaction_id"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
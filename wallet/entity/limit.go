@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+// WalletLimit holds the spending and velocity rules of a single wallet
+// (WalletID set) or of every wallet in a tier (Tier set). A zero value in
+// any field means the rule is not enforced.
+type WalletLimit struct {
+	ID                  int       `gorm:"primaryKey;autoIncrement" json:"id"`
+	WalletID            int       `gorm:"index" json:"wallet_id"`
+	Tier                string    `gorm:"type:varchar;index" json:"tier"`
+	MaxSingleTransfer   float64   `gorm:"type:decimal(10,2)" json:"max_single_transfer"`
+	DailyOutgoing       float64   `gorm:"type:decimal(10,2)" json:"daily_outgoing"`
+	MonthlyOutgoing     float64   `gorm:"type:decimal(10,2)" json:"monthly_outgoing"`
+	MaxTransfersPerHour int       `json:"max_transfers_per_hour"`
+	MaxBalance          float64   `gorm:"type:decimal(10,2)" json:"max_balance"`
+	CreatedAt           time.Time `json:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
@@ -0,0 +1,38 @@
+package entity
+
+import "time"
+
+const (
+	PaymentRequestKindRequest = "REQUEST"
+	PaymentRequestKindLink    = "LINK"
+)
+
+const (
+	PaymentRequestStatusPending    = "PENDING"
+	PaymentRequestStatusProcessing = "PROCESSING"
+	PaymentRequestStatusHeld       = "HELD"
+	PaymentRequestStatusPaid       = "PAID"
+	PaymentRequestStatusDeclined   = "DECLINED"
+	PaymentRequestStatusCancelled  = "CANCELLED"
+	PaymentRequestStatusExpired    = "EXPIRED"
+)
+
+// PaymentRequest asks for money to be paid into RequesterID. A REQUEST is
+// addressed to PayerID; a LINK has no payer and can be paid once by any
+// wallet that knows its Code. A request whose payment the risk engine held
+// stays HELD until a reviewer releases or rejects HeldTransferID.
+type PaymentRequest struct {
+	ID             int        `gorm:"primaryKey;autoIncrement" json:"id"`
+	Kind           string     `gorm:"type:varchar;not null" json:"kind"`
+	Code           *string    `gorm:"type:varchar;uniqueIndex" json:"code,omitempty"`
+	RequesterID    int        `gorm:"not null;index" json:"requester_id"`
+	PayerID        int        `gorm:"index" json:"payer_id"`
+	Amount         float64    `gorm:"type:decimal(10,2)" json:"amount"`
+	Note           string     `gorm:"type:varchar" json:"note"`
+	Status         string     `gorm:"type:varchar;not null;index" json:"status"`
+	ExpiresAt      time.Time  `gorm:"index" json:"expires_at"`
+	PaidAt         *time.Time `json:"paid_at"`
+	HeldTransferID *int       `gorm:"index" json:"held_transfer_id,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
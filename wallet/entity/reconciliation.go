@@ -0,0 +1,43 @@
+package entity
+
+import "time"
+
+// ReconciliationRun summarises one comparison of every stored wallet
+// balance with the balance derived from the transaction history.
+type ReconciliationRun struct {
+	ID               int `gorm:"primaryKey;autoIncrement" json:"id"`
+	WalletsChecked   int `json:"wallets_checked"`
+	DiscrepancyCount int `json:"discrepancy_count"`
+	// TotalLiveBalance is the sum of every stored balance, house wallet
+	// included.
+	TotalLiveBalance float64 `gorm:"type:decimal(14,2)" json:"total_live_balance"`
+	// TotalComputedBalance is the sum of every balance derived from the
+	// history, wallets that no longer exist included.
+	TotalComputedBalance float64 `gorm:"type:decimal(14,2)" json:"total_computed_balance"`
+	// ExternalNet is the money that entered the system minus the money that
+	// left it, i.e. top-ups and credit adjustments minus withdrawals and
+	// debit adjustments.
+	ExternalNet float64 `gorm:"type:decimal(14,2)" json:"external_net"`
+	// Conserved reports whether TotalLiveBalance equals ExternalNet.
+	Conserved           bool      `json:"conserved"`
+	AdjustmentsProposed int       `json:"adjustments_proposed"`
+	StartedAt           time.Time `json:"started_at"`
+	FinishedAt          time.Time `json:"finished_at"`
+	CreatedAt           time.Time `json:"created_at"`
+}
+
+// ReconciliationDiscrepancy is a wallet whose stored balance differs from
+// its history in a reconciliation run.
+type ReconciliationDiscrepancy struct {
+	ID              int     `gorm:"primaryKey;autoIncrement" json:"id"`
+	RunID           int     `gorm:"not null;index" json:"run_id"`
+	WalletID        int     `gorm:"not null" json:"wallet_id"`
+	LiveBalance     float64 `gorm:"type:decimal(12,2)" json:"live_balance"`
+	ComputedBalance float64 `gorm:"type:decimal(12,2)" json:"computed_balance"`
+	// Difference is LiveBalance minus ComputedBalance.
+	Difference float64 `gorm:"type:decimal(12,2)" json:"difference"`
+	// WalletMissing marks history that refers to a deleted wallet.
+	WalletMissing bool `json:"wallet_missing"`
+	// AdjustmentID is the adjustment proposed for this discrepancy, if any.
+	AdjustmentID int `json:"adjustment_id"`
+}
@@ -0,0 +1,43 @@
+package entity
+
+import "time"
+
+const (
+	ScheduleKindOnce     = "ONCE"
+	ScheduleKindInterval = "INTERVAL"
+	ScheduleKindCron     = "CRON"
+)
+
+const (
+	ScheduleStatusActive    = "ACTIVE"
+	ScheduleStatusPaused    = "PAUSED"
+	ScheduleStatusCancelled = "CANCELLED"
+	ScheduleStatusCompleted = "COMPLETED"
+	ScheduleStatusFailed    = "FAILED"
+)
+
+// ScheduledTransfer is a standing order executed by the scheduler worker.
+// OccurrenceAt is the planned time of the occurrence currently due; it stays
+// fixed while that occurrence is retried, whereas NextRunAt moves with the
+// retry backoff. While the transfer of an occurrence is held for review,
+// HeldTransferID is set and the schedule is not run until the review.
+type ScheduledTransfer struct {
+	ID              int        `gorm:"primaryKey;autoIncrement" json:"id"`
+	SenderID        int        `gorm:"not null;index" json:"sender_id"`
+	RecipientID     int        `gorm:"not null" json:"recipient_id"`
+	Amount          float64    `gorm:"type:decimal(10,2)" json:"amount"`
+	Kind            string     `gorm:"type:varchar;not null" json:"kind"`
+	IntervalSeconds int        `json:"interval_seconds"`
+	CronExpr        string     `gorm:"type:varchar" json:"cron_expr"`
+	EndAt           *time.Time `json:"end_at"`
+	Status          string     `gorm:"type:varchar;not null;index" json:"status"`
+	OccurrenceAt    time.Time  `json:"occurrence_at"`
+	NextRunAt       time.Time  `gorm:"index" json:"next_run_at"`
+	Attempts        int        `json:"attempts"`
+	ExecutedCount   int        `json:"executed_count"`
+	LastRunAt       *time.Time `json:"last_run_at"`
+	LastError       string     `gorm:"type:varchar" json:"last_error"`
+	HeldTransferID  *int       `gorm:"index" json:"held_transfer_id,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+// BalanceSnapshot is the balance of a wallet derived from every transaction
+// created before TakenAt. Historical balance queries start from the latest
+// snapshot instead of summing the whole history.
+type BalanceSnapshot struct {
+	ID        int       `gorm:"primaryKey;autoIncrement" json:"id"`
+	WalletID  int       `gorm:"not null;uniqueIndex:idx_balance_snapshot_wallet_taken_at" json:"wallet_id"`
+	TakenAt   time.Time `gorm:"not null;uniqueIndex:idx_balance_snapshot_wallet_taken_at" json:"taken_at"`
+	Balance   float64   `gorm:"type:decimal(12,2)" json:"balance"`
+	CreatedAt time.Time `json:"created_at"`
+}
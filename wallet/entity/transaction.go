@@ -4,11 +4,28 @@ import (
 	"time"
 )
 
+const (
+	TransactionTypeTopUp      = "TOPUP"
+	TransactionTypeTransfer   = "TRANSFER"
+	TransactionTypeWithdrawal = "WITHDRAWAL"
+	TransactionTypeFee        = "FEE"
+	TransactionTypeAdjustment = "ADJUSTMENT"
+)
+
 type Transaction struct {
-	ID          int       `gorm:"primaryKey;autoIncrement" json:"id"`
-	SenderID    int       `json:"sender_id"`
-	RecipientID int       `json:"recipient_id"`
-	Amount      float64   `json:"amount"`
-	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	ID          int     `gorm:"primaryKey;autoIncrement" json:"id"`
+	Type        string  `gorm:"type:varchar;index" json:"type"`
+	SenderID    int     `json:"sender_id"`
+	RecipientID int     `json:"recipient_id"`
+	Amount      float64 `json:"amount"`
+	// RelatedID points a FEE transaction at the transfer or withdrawal it
+	// was charged for.
+	RelatedID int `gorm:"index" json:"related_id"`
+	// BatchID links the transfers created by one batch transfer.
+	BatchID int `gorm:"index" json:"batch_id"`
+	// IdempotencyKey makes retried operations apply at most once. It is
+	// NULL for operations submitted without a key.
+	IdempotencyKey *string   `gorm:"type:varchar;uniqueIndex" json:"idempotency_key,omitempty"`
+	CreatedAt      time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time `gorm:"autoUpdateTime" json:"updated_at"`
 }
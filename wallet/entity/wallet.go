@@ -2,10 +2,30 @@ package entity
 
 import "time"
 
+const (
+	DefaultWalletTier  = "standard"
+	InternalWalletTier = "internal"
+)
+
+// Wallet statuses. A wallet starts ACTIVE; FROZEN_DEBIT stops money
+// leaving it, FROZEN_ALL stops money leaving or entering it, and CLOSED is
+// final. StatusReason explains the last status change.
+const (
+	WalletStatusActive      = "ACTIVE"
+	WalletStatusFrozenDebit = "FROZEN_DEBIT"
+	WalletStatusFrozenAll   = "FROZEN_ALL"
+	WalletStatusClosed      = "CLOSED"
+)
+
 type Wallet struct {
-	ID        int       `gorm:"primaryKey" json:"id"`
-	UserID    int       `gorm:"not null" json:"user_id"`
-	Balance   float64   `gorm:"type:decimal(10,2)" json:"balance"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID              int        `gorm:"primaryKey" json:"id"`
+	UserID          int        `gorm:"not null" json:"user_id"`
+	Balance         float64    `gorm:"type:decimal(10,2)" json:"balance"`
+	Tier            string     `gorm:"type:varchar;not null;default:standard" json:"tier"`
+	Internal        bool       `gorm:"not null;default:false" json:"internal"`
+	Status          string     `gorm:"type:varchar;not null;default:ACTIVE;index" json:"status"`
+	StatusReason    string     `gorm:"type:varchar" json:"status_reason,omitempty"`
+	StatusChangedAt *time.Time `json:"status_changed_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
 }
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/susilo001/simple-wallet-system/wallet/entity"
+	"github.com/susilo001/simple-wallet-system/wallet/pagination"
+	pb "github.com/susilo001/simple-wallet-system/wallet/proto/wallet/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (h *WalletHandler) ProposeBalanceAdjustment(ctx context.Context, req *pb.ProposeBalanceAdjustmentRequest) (*pb.BalanceAdjustment, error) {
+	proposerID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	adjustment, err := h.walletService.ProposeBalanceAdjustment(ctx, int(req.GetWalletId()), req.GetAmount(), req.GetReason(), proposerID)
+	if err != nil {
+		h.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return toBalanceAdjustmentProto(adjustment), nil
+}
+
+func (h *WalletHandler) ListBalanceAdjustments(ctx context.Context, req *pb.ListBalanceAdjustmentsRequest) (*pb.ListBalanceAdjustmentsResponse, error) {
+	page, err := pagination.Parse(req.GetPageSize(), req.GetPageToken())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	adjustments, total, err := h.walletService.GetBalanceAdjustments(ctx, req.GetStatus(), page)
+	if err != nil {
+		h.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+
+	var pbAdjustments []*pb.BalanceAdjustment
+	for _, adjustment := range adjustments {
+		pbAdjustments = append(pbAdjustments, toBalanceAdjustmentProto(adjustment))
+	}
+	return &pb.ListBalanceAdjustmentsResponse{
+		Adjustments: pbAdjustments,
+		Page:        toPageProto(page, total),
+	}, nil
+}
+
+func (h *WalletHandler) ReviewBalanceAdjustment(ctx context.Context, req *pb.ReviewBalanceAdjustmentRequest) (*pb.BalanceAdjustment, error) {
+	reviewerID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	adjustment, err := h.walletService.ReviewBalanceAdjustment(ctx, int(req.GetAdjustmentId()), req.GetApprove(), reviewerID)
+	if err != nil {
+		h.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return toBalanceAdjustmentProto(adjustment), nil
+}
+
+func (h *WalletHandler) TransferWalletOwnership(ctx context.Context, req *pb.TransferWalletOwnershipRequest) (*pb.Wallet, error) {
+	wallet, err := h.walletService.TransferWalletOwnership(ctx, int(req.GetWalletId()), int(req.GetNewUserId()))
+	if err != nil {
+		h.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return toWalletProto(wallet), nil
+}
+
+func toBalanceAdjustmentProto(adjustment entity.BalanceAdjustment) *pb.BalanceAdjustment {
+	res := &pb.BalanceAdjustment{
+		Id:                  int32(adjustment.ID),
+		WalletId:            int32(adjustment.WalletID),
+		Amount:              adjustment.Amount,
+		Source:              adjustment.Source,
+		Status:              adjustment.Status,
+		Reason:              adjustment.Reason,
+		ProposedBy:          adjustment.ProposedBy,
+		ReviewedBy:          adjustment.ReviewedBy,
+		ReconciliationRunId: int32(adjustment.ReconciliationRunID),
+		TransactionId:       int32(adjustment.TransactionID),
+		CreatedAt:           timestamppb.New(adjustment.CreatedAt),
+	}
+	if adjustment.ReviewedAt != nil {
+		res.ReviewedAt = timestamppb.New(*adjustment.ReviewedAt)
+	}
+	return res
+}
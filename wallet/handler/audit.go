@@ -0,0 +1,267 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"path"
+
+	"github.com/susilo001/simple-wallet-system/wallet/entity"
+	pb "github.com/susilo001/simple-wallet-system/wallet/proto/wallet/v1"
+	"github.com/susilo001/simple-wallet-system/wallet/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Metadata keys the gateway uses to pass on who made a call.
+const (
+	actorMetadataKey     = "x-actor"
+	requestIDMetadataKey = "x-request-id"
+)
+
+// auditTarget names the object a mutating call acts on. The state of
+// Wallets is recorded before and after the call.
+type auditTarget struct {
+	Name    string
+	Wallets []int
+}
+
+// auditTargets maps every mutating RPC to the target of a request. RPCs not
+// listed here are not audited.
+var auditTargets = map[string]func(req any) auditTarget{
+	"CreateWallet": func(req any) auditTarget {
+		return auditTarget{Name: fmt.Sprintf("user:%d", req.(*pb.CreateWalletRequest).GetUserId())}
+	},
+	"UpdateWallet": func(req any) auditTarget {
+		return walletTarget(int(req.(*pb.UpdateWalletRequest).GetUserId()))
+	},
+	"TopUpWallet": func(req any) auditTarget {
+		return walletTarget(int(req.(*pb.TopupRequest).GetWalletId()))
+	},
+	"WithdrawWallet": func(req any) auditTarget {
+		return walletTarget(int(req.(*pb.WithdrawRequest).GetWalletId()))
+	},
+	"Transfer": func(req any) auditTarget {
+		r := req.(*pb.TransferRequest)
+		return auditTarget{Name: fmt.Sprintf("wallet:%d", r.GetSenderId()), Wallets: []int{int(r.GetSenderId()), int(r.GetRecipientId())}}
+	},
+	"SetWalletTier": func(req any) auditTarget {
+		return walletTarget(int(req.(*pb.SetWalletTierRequest).GetWalletId()))
+	},
+	"SetWalletLimits": func(req any) auditTarget {
+		r := req.(*pb.SetWalletLimitsRequest)
+		if r.GetTier() != "" {
+			return auditTarget{Name: "tier:" + r.GetTier()}
+		}
+		return auditTarget{Name: fmt.Sprintf("wallet:%d", r.GetWalletId())}
+	},
+	"SaveFeeRule": func(req any) auditTarget {
+		return auditTarget{Name: fmt.Sprintf("fee_rule:%d", req.(*pb.SaveFeeRuleRequest).GetRule().GetId())}
+	},
+	"ScheduleTransfer": func(req any) auditTarget {
+		return auditTarget{Name: fmt.Sprintf("wallet:%d", req.(*pb.ScheduleTransferRequest).GetSenderId())}
+	},
+	"PauseScheduledTransfer":  scheduledTransferTarget,
+	"ResumeScheduledTransfer": scheduledTransferTarget,
+	"CancelScheduledTransfer": scheduledTransferTarget,
+	"RequestPayment": func(req any) auditTarget {
+		return auditTarget{Name: fmt.Sprintf("wallet:%d", req.(*pb.RequestPaymentRequest).GetRequesterWalletId())}
+	},
+	"CreatePaymentLink": func(req any) auditTarget {
+		return auditTarget{Name: fmt.Sprintf("wallet:%d", req.(*pb.CreatePaymentLinkRequest).GetRequesterWalletId())}
+	},
+	"AcceptPaymentRequest": func(req any) auditTarget {
+		r := req.(*pb.AcceptPaymentRequestRequest)
+		name := fmt.Sprintf("payment_request:%d", r.GetId())
+		if r.GetCode() != "" {
+			name = "payment_link:" + r.GetCode()
+		}
+		return auditTarget{Name: name, Wallets: []int{int(r.GetPayerWalletId())}}
+	},
+	"DeclinePaymentRequest": func(req any) auditTarget {
+		return auditTarget{Name: fmt.Sprintf("payment_request:%d", req.(*pb.DeclinePaymentRequestRequest).GetId())}
+	},
+	"CancelPaymentRequest": func(req any) auditTarget {
+		return auditTarget{Name: fmt.Sprintf("payment_request:%d", req.(*pb.CancelPaymentRequestRequest).GetId())}
+	},
+	"BatchTransfer": func(req any) auditTarget {
+		seen := map[int]bool{}
+		var wallets []int
+		for _, item := range req.(*pb.BatchTransferRequest).GetItems() {
+			for _, id := range []int{int(item.GetSenderId()), int(item.GetRecipientId())} {
+				if !seen[id] {
+					seen[id] = true
+					wallets = append(wallets, id)
+				}
+			}
+		}
+		return auditTarget{Name: "batch", Wallets: wallets}
+	},
+	"Reconcile": func(req any) auditTarget {
+		return auditTarget{Name: "reconciliation"}
+	},
+	"ProposeBalanceAdjustment": func(req any) auditTarget {
+		return auditTarget{Name: fmt.Sprintf("wallet:%d", req.(*pb.ProposeBalanceAdjustmentRequest).GetWalletId())}
+	},
+	"TransferWalletOwnership": func(req any) auditTarget {
+		return walletTarget(int(req.(*pb.TransferWalletOwnershipRequest).GetWalletId()))
+	},
+	"FreezeWallet": func(req any) auditTarget {
+		return walletTarget(int(req.(*pb.FreezeWalletRequest).GetWalletId()))
+	},
+	"UnfreezeWallet": func(req any) auditTarget {
+		return walletTarget(int(req.(*pb.UnfreezeWalletRequest).GetWalletId()))
+	},
+	"CloseWallet": func(req any) auditTarget {
+		return walletTarget(int(req.(*pb.CloseWalletRequest).GetWalletId()))
+	},
+	"ReviewBalanceAdjustment": func(req any) auditTarget {
+		return auditTarget{Name: fmt.Sprintf("adjustment:%d", req.(*pb.ReviewBalanceAdjustmentRequest).GetAdjustmentId())}
+	},
+	"ReviewHeldTransfer": func(req any) auditTarget {
+		return auditTarget{Name: fmt.Sprintf("held_transfer:%d", req.(*pb.ReviewHeldTransferRequest).GetHeldTransferId())}
+	},
+}
+
+func walletTarget(id int) auditTarget {
+	return auditTarget{Name: fmt.Sprintf("wallet:%d", id), Wallets: []int{id}}
+}
+
+func scheduledTransferTarget(req any) auditTarget {
+	return auditTarget{Name: fmt.Sprintf("scheduled_transfer:%d", req.(*pb.ScheduledTransferRequest).GetId())}
+}
+
+// NewAuditInterceptor records every call of a mutating RPC in the audit
+// log, successful or not. A failure to record is logged but does not fail
+// the call, which has already taken effect.
+func NewAuditInterceptor(auditService service.IAuditService, walletService service.IWalletService, logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		targetOf, ok := auditTargets[path.Base(info.FullMethod)]
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		target := targetOf(req)
+		entry := entity.AuditEntry{
+			Action:  info.FullMethod,
+			Target:  target.Name,
+			Request: auditJSON(req),
+		}
+		entry.Actor, entry.RequestID = callerFromContext(ctx)
+		if len(target.Wallets) > 0 {
+			entry.Before = walletsState(ctx, walletService, logger, target.Wallets)
+		}
+
+		resp, err := handler(ctx, req)
+
+		entry.Outcome = status.Code(err).String()
+		if err != nil {
+			entry.Error = status.Convert(err).Message()
+		}
+		if len(target.Wallets) > 0 {
+			entry.After = walletsState(ctx, walletService, logger, target.Wallets)
+		} else if err == nil {
+			entry.After = auditJSON(resp)
+		}
+		if _, auditErr := auditService.Record(context.WithoutCancel(ctx), entry); auditErr != nil {
+			logger.ErrorContext(ctx, "Error recording audit entry", "error", auditErr)
+		}
+		return resp, err
+	}
+}
+
+func (h *WalletHandler) ListAuditEntries(ctx context.Context, req *pb.ListAuditEntriesRequest) (*pb.ListAuditEntriesResponse, error) {
+	filter := service.AuditFilter{
+		Actor:         req.GetActor(),
+		Action:        req.GetAction(),
+		Target:        req.GetTarget(),
+		RequestID:     req.GetRequestId(),
+		AfterSequence: req.GetAfterSequence(),
+		Limit:         int(req.GetLimit()),
+	}
+	if req.From != nil {
+		filter.From = req.GetFrom().AsTime()
+	}
+	if req.To != nil {
+		filter.To = req.GetTo().AsTime()
+	}
+
+	entries, err := h.auditService.ListAuditEntries(ctx, filter)
+	if err != nil {
+		h.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+
+	var pbEntries []*pb.AuditEntry
+	for _, entry := range entries {
+		pbEntries = append(pbEntries, &pb.AuditEntry{
+			Sequence:  entry.Sequence,
+			Actor:     entry.Actor,
+			Action:    entry.Action,
+			Target:    entry.Target,
+			Request:   entry.Request,
+			Before:    entry.Before,
+			After:     entry.After,
+			Outcome:   entry.Outcome,
+			Error:     entry.Error,
+			RequestId: entry.RequestID,
+			CreatedAt: timestamppb.New(entry.CreatedAt),
+			PrevHash:  entry.PrevHash,
+			Hash:      entry.Hash,
+		})
+	}
+	return &pb.ListAuditEntriesResponse{
+		Entries: pbEntries,
+	}, nil
+}
+
+func callerFromContext(ctx context.Context) (actor string, requestID string) {
+	actor = "anonymous"
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return actor, ""
+	}
+	if values := md.Get(actorMetadataKey); len(values) > 0 && values[0] != "" {
+		actor = values[0]
+	}
+	if values := md.Get(requestIDMetadataKey); len(values) > 0 {
+		requestID = values[0]
+	}
+	return actor, requestID
+}
+
+func walletsState(ctx context.Context, walletService service.IWalletService, logger *slog.Logger, ids []int) string {
+	var wallets []entity.Wallet
+	for _, id := range ids {
+		wallet, err := walletService.GetWalletByID(ctx, id)
+		if err != nil {
+			logger.ErrorContext(ctx, "Error loading wallet for audit", "wallet_id", id, "error", err)
+			continue
+		}
+		if wallet.ID != 0 {
+			wallets = append(wallets, wallet)
+		}
+	}
+	data, err := json.Marshal(wallets)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+func auditJSON(v any) string {
+	message, ok := v.(proto.Message)
+	if !ok {
+		return ""
+	}
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(message)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
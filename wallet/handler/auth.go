@@ -0,0 +1,305 @@
+package handler
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/base64"
+	"path"
+	"strconv"
+	"strings"
+
+	pb "github.com/susilo001/simple-wallet-system/wallet/proto/wallet/v1"
+	"github.com/susilo001/simple-wallet-system/wallet/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Metadata keys the gateway uses to pass on the authenticated caller. They
+// are only trusted on calls that carry the service credentials in
+// authorization.
+const (
+	authorizationMetadataKey = "authorization"
+	userIDMetadataKey        = "x-user-id"
+	roleMetadataKey          = "x-role"
+)
+
+// Roles are assigned to users by the user service.
+const (
+	roleAnonymous = "anonymous"
+	roleCustomer  = "customer"
+	roleSupport   = "support"
+	roleFinance   = "finance"
+	roleAdmin     = "admin"
+)
+
+// caller is the user on whose behalf an RPC is made.
+type caller struct {
+	UserID int
+	Role   string
+}
+
+// customerRule reports whether a customer may make a call. Customers may
+// only act on their own wallets.
+type customerRule func(ctx context.Context, a *accessChecker, c caller, req any) (bool, error)
+
+// permission says who may call an RPC: any caller with one of Roles, and
+// customers if Customer allows the request.
+type permission struct {
+	Roles    []string
+	Customer customerRule
+}
+
+var (
+	staff   = []string{roleSupport, roleFinance, roleAdmin}
+	support = []string{roleSupport, roleAdmin}
+	finance = []string{roleFinance, roleAdmin}
+	admin   = []string{roleAdmin}
+)
+
+// permissions maps every RPC to who may call it. RPCs not listed here may
+// not be called by anyone.
+var permissions = map[string]permission{
+	"GetWallet":         {Roles: staff, Customer: ownsWallet(func(req any) int32 { return req.(*pb.GetWalletRequest).GetWalletId() })},
+	"GetBalance":        {Roles: staff, Customer: ownsWallet(func(req any) int32 { return req.(*pb.GetBalanceRequest).GetWalletId() })},
+	"GetTransactions":   {Roles: staff, Customer: ownsWallet(func(req any) int32 { return req.(*pb.GetTransactionsRequest).GetWalletId() })},
+	"GetWalletLimits":   {Roles: staff, Customer: ownsWallet(func(req any) int32 { return req.(*pb.GetWalletLimitsRequest).GetWalletId() })},
+	"GetStatement":      {Roles: staff, Customer: ownsWallet(func(req any) int32 { return req.(*pb.GetStatementRequest).GetWalletId() })},
+	"GetBalanceAsOf":    {Roles: staff, Customer: ownsWallet(func(req any) int32 { return req.(*pb.GetBalanceAsOfRequest).GetWalletId() })},
+	"GetBalanceHistory": {Roles: staff, Customer: ownsWallet(func(req any) int32 { return req.(*pb.GetBalanceHistoryRequest).GetWalletId() })},
+	"GetFeeRules":       {Roles: staff, Customer: anyCustomer},
+	"QuoteTransfer":     {Roles: staff, Customer: ownsWallet(func(req any) int32 { return req.(*pb.QuoteTransferRequest).GetSenderId() })},
+	"QuoteWithdrawal":   {Roles: staff, Customer: ownsWallet(func(req any) int32 { return req.(*pb.QuoteWithdrawalRequest).GetWalletId() })},
+	"VerifyBalance":     {Roles: staff},
+
+	"CreateWallet": {Roles: admin, Customer: func(ctx context.Context, a *accessChecker, c caller, req any) (bool, error) {
+		return int(req.(*pb.CreateWalletRequest).GetUserId()) == c.UserID, nil
+	}},
+	"ListWallets": {Roles: staff, Customer: func(ctx context.Context, a *accessChecker, c caller, req any) (bool, error) {
+		return int(req.(*pb.ListWalletsRequest).GetUserId()) == c.UserID, nil
+	}},
+	"UpdateWallet":   {Roles: admin},
+	"TopUpWallet":    {Roles: admin, Customer: ownsWallet(func(req any) int32 { return req.(*pb.TopupRequest).GetWalletId() })},
+	"WithdrawWallet": {Roles: admin, Customer: ownsWallet(func(req any) int32 { return req.(*pb.WithdrawRequest).GetWalletId() })},
+	"Transfer":       {Roles: admin, Customer: ownsWallet(func(req any) int32 { return req.(*pb.TransferRequest).GetSenderId() })},
+	"BatchTransfer": {Roles: admin, Customer: func(ctx context.Context, a *accessChecker, c caller, req any) (bool, error) {
+		for _, item := range req.(*pb.BatchTransferRequest).GetItems() {
+			if ok, err := a.owns(ctx, c, int(item.GetSenderId())); !ok || err != nil {
+				return false, err
+			}
+		}
+		return true, nil
+	}},
+	"GetTransferBatch": {Roles: staff, Customer: func(ctx context.Context, a *accessChecker, c caller, req any) (bool, error) {
+		_, transactions, err := a.walletService.GetTransferBatch(ctx, int(req.(*pb.GetTransferBatchRequest).GetBatchId()))
+		if err != nil {
+			return false, err
+		}
+		for _, transaction := range transactions {
+			if ok, err := a.owns(ctx, c, transaction.SenderID); ok || err != nil {
+				return ok, err
+			}
+		}
+		return false, nil
+	}},
+
+	"ScheduleTransfer":        {Roles: admin, Customer: ownsWallet(func(req any) int32 { return req.(*pb.ScheduleTransferRequest).GetSenderId() })},
+	"ListScheduledTransfers":  {Roles: staff, Customer: ownsWallet(func(req any) int32 { return req.(*pb.ListScheduledTransfersRequest).GetWalletId() })},
+	"PauseScheduledTransfer":  {Roles: support, Customer: ownsScheduledTransfer},
+	"ResumeScheduledTransfer": {Roles: support, Customer: ownsScheduledTransfer},
+	"CancelScheduledTransfer": {Roles: support, Customer: ownsScheduledTransfer},
+
+	"RequestPayment":    {Roles: admin, Customer: ownsWallet(func(req any) int32 { return req.(*pb.RequestPaymentRequest).GetRequesterWalletId() })},
+	"CreatePaymentLink": {Roles: admin, Customer: ownsWallet(func(req any) int32 { return req.(*pb.CreatePaymentLinkRequest).GetRequesterWalletId() })},
+	"GetPaymentRequest": {Roles: staff, Customer: func(ctx context.Context, a *accessChecker, c caller, req any) (bool, error) {
+		r := req.(*pb.GetPaymentRequestRequest)
+		// Payment links are meant to be shared; knowing the code is enough.
+		if r.GetCode() != "" {
+			return true, nil
+		}
+		request, err := a.paymentRequestService.GetPaymentRequest(ctx, int(r.GetId()))
+		if err != nil {
+			return false, err
+		}
+		if ok, err := a.owns(ctx, c, request.RequesterID); ok || err != nil {
+			return ok, err
+		}
+		return a.owns(ctx, c, request.PayerID)
+	}},
+	"ListPaymentRequests":   {Roles: staff, Customer: ownsWallet(func(req any) int32 { return req.(*pb.ListPaymentRequestsRequest).GetWalletId() })},
+	"AcceptPaymentRequest":  {Roles: admin, Customer: ownsWallet(func(req any) int32 { return req.(*pb.AcceptPaymentRequestRequest).GetPayerWalletId() })},
+	"DeclinePaymentRequest": {Roles: admin, Customer: ownsWallet(func(req any) int32 { return req.(*pb.DeclinePaymentRequestRequest).GetPayerWalletId() })},
+	"CancelPaymentRequest":  {Roles: support, Customer: ownsWallet(func(req any) int32 { return req.(*pb.CancelPaymentRequestRequest).GetRequesterWalletId() })},
+
+	"SetWalletTier":           {Roles: finance},
+	"SetWalletLimits":         {Roles: finance},
+	"SaveFeeRule":             {Roles: finance},
+	"Reconcile":               {Roles: finance},
+	"GetReconciliationReport": {Roles: finance},
+	"ListBalanceAdjustments":  {Roles: finance},
+	"ListAuditEntries":        {Roles: finance},
+
+	// Customers may freeze their own wallet, e.g. after losing a device,
+	// but only staff can lift a freeze.
+	"FreezeWallet":   {Roles: support, Customer: ownsWallet(func(req any) int32 { return req.(*pb.FreezeWalletRequest).GetWalletId() })},
+	"UnfreezeWallet": {Roles: support},
+	"CloseWallet":    {Roles: admin},
+
+	// Transfers held by the risk checks are reviewed by support.
+	"ListHeldTransfers":  {Roles: staff},
+	"ReviewHeldTransfer": {Roles: support},
+	"GetRiskMetrics":     {Roles: staff},
+
+	// Balance corrections and ownership changes are reserved for admins.
+	"ProposeBalanceAdjustment": {Roles: admin},
+	"ReviewBalanceAdjustment":  {Roles: admin},
+	"TransferWalletOwnership":  {Roles: admin},
+}
+
+func anyCustomer(ctx context.Context, a *accessChecker, c caller, req any) (bool, error) {
+	return true, nil
+}
+
+// ownsWallet allows customers to call an RPC for the wallet returned by
+// walletID.
+func ownsWallet(walletID func(req any) int32) customerRule {
+	return func(ctx context.Context, a *accessChecker, c caller, req any) (bool, error) {
+		return a.owns(ctx, c, int(walletID(req)))
+	}
+}
+
+func ownsScheduledTransfer(ctx context.Context, a *accessChecker, c caller, req any) (bool, error) {
+	schedule, err := a.scheduleService.GetScheduledTransfer(ctx, int(req.(*pb.ScheduledTransferRequest).GetId()))
+	if err != nil {
+		return false, err
+	}
+	return a.owns(ctx, c, schedule.SenderID)
+}
+
+// accessChecker looks up who owns the objects a request refers to.
+type accessChecker struct {
+	walletService         service.IWalletService
+	scheduleService       service.IScheduledTransferService
+	paymentRequestService service.IPaymentRequestService
+}
+
+func (a *accessChecker) owns(ctx context.Context, c caller, walletID int) (bool, error) {
+	wallet, err := a.walletService.GetWalletByID(ctx, walletID)
+	if err != nil {
+		return false, err
+	}
+	return wallet.ID != 0 && !wallet.Internal && wallet.UserID == c.UserID, nil
+}
+
+func (p permission) allows(ctx context.Context, a *accessChecker, c caller, req any) (bool, error) {
+	for _, role := range p.Roles {
+		if role == c.Role {
+			return true, nil
+		}
+	}
+	if c.Role != roleCustomer || p.Customer == nil {
+		return false, nil
+	}
+	return p.Customer(ctx, a, c, req)
+}
+
+// NewAuthInterceptor only accepts calls that carry the service credentials
+// username and password, and checks that the caller passed on by the
+// gateway may make the call.
+func NewAuthInterceptor(username string, password string, walletService service.IWalletService, scheduleService service.IScheduledTransferService, paymentRequestService service.IPaymentRequestService) grpc.UnaryServerInterceptor {
+	checker := &accessChecker{
+		walletService:         walletService,
+		scheduleService:       scheduleService,
+		paymentRequestService: paymentRequestService,
+	}
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if !hasServiceCredentials(md, username, password) {
+			return nil, status.Error(codes.Unauthenticated, "invalid service credentials")
+		}
+		c, err := callerFromMetadata(md)
+		if err != nil {
+			return nil, err
+		}
+
+		method := path.Base(info.FullMethod)
+		allowed := false
+		if perm, ok := permissions[method]; ok {
+			allowed, err = perm.allows(ctx, checker, c, req)
+			if err != nil {
+				return nil, toStatusError(err)
+			}
+		}
+		if !allowed {
+			if c.Role == roleAnonymous {
+				return nil, status.Error(codes.Unauthenticated, "authentication required")
+			}
+			return nil, status.Errorf(codes.PermissionDenied, "role %s may not call %s", c.Role, method)
+		}
+		return handler(ctx, req)
+	}
+}
+
+func hasServiceCredentials(md metadata.MD, username string, password string) bool {
+	values := md.Get(authorizationMetadataKey)
+	if len(values) == 0 {
+		return false
+	}
+	encoded, ok := strings.CutPrefix(values[0], "Basic ")
+	if !ok {
+		return false
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return false
+	}
+	gotUsername, gotPassword, ok := strings.Cut(string(decoded), ":")
+	if !ok {
+		return false
+	}
+	usernameMatch := subtle.ConstantTimeCompare([]byte(gotUsername), []byte(username))
+	passwordMatch := subtle.ConstantTimeCompare([]byte(gotPassword), []byte(password))
+	return usernameMatch&passwordMatch == 1
+}
+
+// callerFromMetadata reads the caller from md. Calls without x-role are
+// anonymous.
+func callerFromMetadata(md metadata.MD) (caller, error) {
+	c := caller{Role: roleAnonymous}
+	if values := md.Get(roleMetadataKey); len(values) > 0 && values[0] != "" {
+		switch values[0] {
+		case roleCustomer, roleSupport, roleFinance, roleAdmin:
+			c.Role = values[0]
+		default:
+			return caller{}, status.Errorf(codes.Unauthenticated, "unknown role %q", values[0])
+		}
+	}
+	if c.Role == roleAnonymous {
+		return c, nil
+	}
+	values := md.Get(userIDMetadataKey)
+	if len(values) == 0 {
+		return caller{}, status.Error(codes.Unauthenticated, "user ID required for role "+c.Role)
+	}
+	userID, err := strconv.Atoi(values[0])
+	if err != nil || userID <= 0 {
+		return caller{}, status.Errorf(codes.Unauthenticated, "invalid user ID %q", values[0])
+	}
+	c.UserID = userID
+	return c, nil
+}
+
+// authenticatedUserID returns the user the gateway passed on with the call,
+// which the auth interceptor has already let through.
+func authenticatedUserID(ctx context.Context) (int, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	c, err := callerFromMetadata(md)
+	if err != nil {
+		return 0, err
+	}
+	if c.UserID == 0 {
+		return 0, status.Error(codes.Unauthenticated, "authentication required")
+	}
+	return c.UserID, nil
+}
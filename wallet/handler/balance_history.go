@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"context"
+
+	pb "github.com/susilo001/simple-wallet-system/wallet/proto/wallet/v1"
+	"github.com/susilo001/simple-wallet-system/wallet/service"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (h *WalletHandler) GetBalanceAsOf(ctx context.Context, req *pb.GetBalanceAsOfRequest) (*pb.GetBalanceAsOfResponse, error) {
+	at := req.GetAt().AsTime()
+	balance, err := h.walletService.GetBalanceAsOf(ctx, int(req.GetWalletId()), at)
+	if err != nil {
+		h.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return &pb.GetBalanceAsOfResponse{
+		WalletId: req.GetWalletId(),
+		At:       timestamppb.New(at),
+		Balance:  balance,
+	}, nil
+}
+
+func (h *WalletHandler) GetBalanceHistory(ctx context.Context, req *pb.GetBalanceHistoryRequest) (*pb.GetBalanceHistoryResponse, error) {
+	history, err := h.walletService.GetBalanceHistory(ctx, int(req.GetWalletId()), req.GetFrom().AsTime(), req.GetTo().AsTime())
+	if err != nil {
+		h.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+
+	var pbPoints []*pb.BalancePoint
+	for _, point := range history.Points {
+		pbPoints = append(pbPoints, &pb.BalancePoint{
+			Date:    timestamppb.New(point.Date),
+			Balance: point.Balance,
+		})
+	}
+	return &pb.GetBalanceHistoryResponse{
+		WalletId: int32(history.WalletID),
+		Points:   pbPoints,
+		Check:    toBalanceCheckProto(history.Check),
+	}, nil
+}
+
+func (h *WalletHandler) VerifyBalance(ctx context.Context, req *pb.GetBalanceRequest) (*pb.BalanceCheck, error) {
+	check, err := h.walletService.VerifyBalance(ctx, int(req.GetWalletId()))
+	if err != nil {
+		h.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return toBalanceCheckProto(check), nil
+}
+
+func toBalanceCheckProto(check service.BalanceCheck) *pb.BalanceCheck {
+	return &pb.BalanceCheck{
+		WalletId:        int32(check.WalletID),
+		LiveBalance:     check.LiveBalance,
+		ComputedBalance: check.ComputedBalance,
+		Difference:      check.Difference,
+		Consistent:      check.Consistent,
+		CheckedAt:       timestamppb.New(check.CheckedAt),
+	}
+}
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/susilo001/simple-wallet-system/wallet/entity"
+	pb "github.com/susilo001/simple-wallet-system/wallet/proto/wallet/v1"
+	"github.com/susilo001/simple-wallet-system/wallet/service"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (h *WalletHandler) BatchTransfer(ctx context.Context, req *pb.BatchTransferRequest) (*pb.BatchTransferResponse, error) {
+	var items []service.BatchTransferItem
+	for _, item := range req.GetItems() {
+		items = append(items, service.BatchTransferItem{
+			SenderID:    int(item.GetSenderId()),
+			RecipientID: int(item.GetRecipientId()),
+			Amount:      item.GetAmount(),
+		})
+	}
+	if req.GetIdempotencyKey() != "" {
+		ctx = service.WithIdempotencyKey(ctx, req.GetIdempotencyKey())
+	}
+
+	result, err := h.walletService.BatchTransfer(ctx, items, req.GetAtomic())
+	if err != nil {
+		h.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+
+	var pbItems []*pb.BatchItemResult
+	for _, item := range result.Items {
+		pbItem := &pb.BatchItemResult{
+			Index:         int32(item.Index),
+			Status:        item.Status,
+			TransactionId: int32(item.TransactionID),
+		}
+		if item.Error != nil {
+			pbItem.Error = item.Error.Error()
+		}
+		pbItems = append(pbItems, pbItem)
+	}
+	return &pb.BatchTransferResponse{
+		Batch: toTransferBatchProto(result.Batch),
+		Items: pbItems,
+	}, nil
+}
+
+func (h *WalletHandler) GetTransferBatch(ctx context.Context, req *pb.GetTransferBatchRequest) (*pb.GetTransferBatchResponse, error) {
+	batch, transactions, err := h.walletService.GetTransferBatch(ctx, int(req.GetBatchId()))
+	if err != nil {
+		h.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	var pbTransactions []*pb.Transaction
+	for _, transaction := range transactions {
+		pbTransactions = append(pbTransactions, toTransactionProto(transaction))
+	}
+	return &pb.GetTransferBatchResponse{
+		Batch:        toTransferBatchProto(batch),
+		Transactions: pbTransactions,
+	}, nil
+}
+
+func toTransferBatchProto(batch entity.TransferBatch) *pb.TransferBatch {
+	return &pb.TransferBatch{
+		Id:             int32(batch.ID),
+		Mode:           batch.Mode,
+		Status:         batch.Status,
+		ItemCount:      int32(batch.ItemCount),
+		SucceededCount: int32(batch.SucceededCount),
+		TotalAmount:    batch.TotalAmount,
+		CreatedAt:      timestamppb.New(batch.CreatedAt),
+	}
+}
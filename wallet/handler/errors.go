@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/susilo001/simple-wallet-system/wallet/pagination"
+	"github.com/susilo001/simple-wallet-system/wallet/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// toStatusError maps service errors to gRPC status codes so that clients
+// can tell a rejected operation apart from an internal failure.
+func toStatusError(err error) error {
+	switch {
+	// The pagination package is shared with the user service, whose
+	// messages are in Indonesian.
+	case errors.Is(err, pagination.ErrInvalidPageSize):
+		return status.Error(codes.InvalidArgument, "page_size must not be negative")
+	case errors.Is(err, pagination.ErrInvalidPageToken):
+		return status.Error(codes.InvalidArgument, "invalid page_token")
+	case errors.Is(err, service.ErrWalletNotFound), errors.Is(err, service.ErrScheduleNotFound),
+		errors.Is(err, service.ErrPaymentRequestNotFound), errors.Is(err, service.ErrBatchNotFound),
+		errors.Is(err, service.ErrAdjustmentNotFound), errors.Is(err, service.ErrReconciliationRunNotFound),
+		errors.Is(err, service.ErrHeldTransferNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrInvalidAmount), errors.Is(err, service.ErrSameWallet),
+		errors.Is(err, service.ErrInvalidSchedule), errors.Is(err, service.ErrInvalidBatch),
+		errors.Is(err, service.ErrInvalidPeriod), errors.Is(err, service.ErrInvalidAdjustment),
+		errors.Is(err, service.ErrInvalidOwner):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrInsufficientBalance), errors.Is(err, service.ErrScheduleStateChange),
+		errors.Is(err, service.ErrPaymentRequestClosed), errors.Is(err, service.ErrPaymentRequestExpired),
+		errors.Is(err, service.ErrAdjustmentClosed), errors.Is(err, service.ErrAdjustmentStale),
+		errors.Is(err, service.ErrTransferHeld), errors.Is(err, service.ErrHeldTransferClosed):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, service.ErrNotPaymentRequestParty), errors.Is(err, service.ErrSelfReview),
+		errors.Is(err, service.ErrTransferBlocked):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, service.ErrLimitExceeded):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	default:
+		return err
+	}
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/susilo001/simple-wallet-system/wallet/entity"
+	pb "github.com/susilo001/simple-wallet-system/wallet/proto/wallet/v1"
+	"github.com/susilo001/simple-wallet-system/wallet/service"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (h *WalletHandler) WithdrawWallet(ctx context.Context, req *pb.WithdrawRequest) (*pb.MutationResponse, error) {
+	if err := h.walletService.WithdrawWallet(ctx, int(req.GetWalletId()), req.GetAmount()); err != nil {
+		h.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return &pb.MutationResponse{
+		Message: fmt.Sprintf("Successfully withdrew from wallet with ID %d", req.GetWalletId()),
+	}, nil
+}
+
+func (h *WalletHandler) QuoteTransfer(ctx context.Context, req *pb.QuoteTransferRequest) (*pb.FeeQuote, error) {
+	quote, err := h.walletService.QuoteTransfer(ctx, int(req.GetSenderId()), int(req.GetRecipientId()), req.GetAmount())
+	if err != nil {
+		h.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return toFeeQuoteProto(quote), nil
+}
+
+func (h *WalletHandler) QuoteWithdrawal(ctx context.Context, req *pb.QuoteWithdrawalRequest) (*pb.FeeQuote, error) {
+	quote, err := h.walletService.QuoteWithdrawal(ctx, int(req.GetWalletId()), req.GetAmount())
+	if err != nil {
+		h.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return toFeeQuoteProto(quote), nil
+}
+
+func (h *WalletHandler) GetFeeRules(ctx context.Context, _ *emptypb.Empty) (*pb.GetFeeRulesResponse, error) {
+	rules, err := h.walletService.GetFeeRules(ctx)
+	if err != nil {
+		h.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, err
+	}
+	var pbRules []*pb.FeeRule
+	for _, rule := range rules {
+		pbRules = append(pbRules, &pb.FeeRule{
+			Id:         int32(rule.ID),
+			Operation:  rule.Operation,
+			Tier:       rule.Tier,
+			MinAmount:  rule.MinAmount,
+			MaxAmount:  rule.MaxAmount,
+			FlatFee:    rule.FlatFee,
+			Percentage: rule.Percentage,
+			MinFee:     rule.MinFee,
+			MaxFee:     rule.MaxFee,
+			Priority:   int32(rule.Priority),
+			Active:     rule.Active,
+		})
+	}
+	return &pb.GetFeeRulesResponse{
+		Rules: pbRules,
+	}, nil
+}
+
+func (h *WalletHandler) SaveFeeRule(ctx context.Context, req *pb.SaveFeeRuleRequest) (*pb.MutationResponse, error) {
+	rule := req.GetRule()
+	saved, err := h.walletService.SaveFeeRule(ctx, entity.FeeRule{
+		ID:         int(rule.GetId()),
+		Operation:  rule.GetOperation(),
+		Tier:       rule.GetTier(),
+		MinAmount:  rule.GetMinAmount(),
+		MaxAmount:  rule.GetMaxAmount(),
+		FlatFee:    rule.GetFlatFee(),
+		Percentage: rule.GetPercentage(),
+		MinFee:     rule.GetMinFee(),
+		MaxFee:     rule.GetMaxFee(),
+		Priority:   int(rule.GetPriority()),
+		Active:     rule.GetActive(),
+	})
+	if err != nil {
+		h.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, err
+	}
+	return &pb.MutationResponse{
+		Message: fmt.Sprintf("Successfully saved fee rule with ID %d", saved.ID),
+	}, nil
+}
+
+func toFeeQuoteProto(quote service.FeeQuote) *pb.FeeQuote {
+	return &pb.FeeQuote{
+		Amount:        quote.Amount,
+		RuleId:        int32(quote.RuleID),
+		FlatFee:       quote.FlatFee,
+		PercentageFee: quote.PercentageFee,
+		CapAdjustment: quote.CapAdjustment,
+		Fee:           quote.Fee,
+		TotalDebit:    quote.TotalDebit,
+		Waived:        quote.Waived,
+		WaiverReason:  quote.WaiverReason,
+	}
+}
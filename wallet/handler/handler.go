@@ -3,49 +3,76 @@ package handler
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 
 	"github.com/susilo001/simple-wallet-system/wallet/entity"
+	"github.com/susilo001/simple-wallet-system/wallet/pagination"
 	pb "github.com/susilo001/simple-wallet-system/wallet/proto/wallet/v1"
 	"github.com/susilo001/simple-wallet-system/wallet/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type WalletHandler struct {
 	pb.UnimplementedWalletServiceServer
-	walletService service.IWalletService
+	walletService         service.IWalletService
+	scheduleService       service.IScheduledTransferService
+	paymentRequestService service.IPaymentRequestService
+	reconciliationService service.IReconciliationService
+	auditService          service.IAuditService
+	logger                *slog.Logger
 }
 
-func NewWalletHandler(walletService service.IWalletService) *WalletHandler {
+func NewWalletHandler(walletService service.IWalletService, scheduleService service.IScheduledTransferService, paymentRequestService service.IPaymentRequestService, reconciliationService service.IReconciliationService, auditService service.IAuditService, logger *slog.Logger) *WalletHandler {
 	return &WalletHandler{
-		walletService: walletService,
+		walletService:         walletService,
+		scheduleService:       scheduleService,
+		paymentRequestService: paymentRequestService,
+		reconciliationService: reconciliationService,
+		auditService:          auditService,
+		logger:                logger,
 	}
 }
 
 func (h *WalletHandler) GetWallet(ctx context.Context, req *pb.GetWalletRequest) (*pb.GetWalletResponse, error) {
 	wallet, err := h.walletService.GetWalletByID(ctx, int(req.GetWalletId()))
 	if err != nil {
-		log.Println(err)
+		h.logger.WarnContext(ctx, "request failed", "error", err)
 		return nil, err
 	}
 	res := &pb.GetWalletResponse{
-		Wallet: &pb.Wallet{
-			Id:        int32(wallet.ID),
-			UserId:    int32(wallet.UserID),
-			Balance:   wallet.Balance,
-			CreatedAt: timestamppb.New(wallet.CreatedAt),
-			UpdatedAt: timestamppb.New(wallet.UpdatedAt),
-		},
+		Wallet: toWalletProto(wallet),
 	}
 	return res, nil
 }
 
+func (h *WalletHandler) ListWallets(ctx context.Context, req *pb.ListWalletsRequest) (*pb.ListWalletsResponse, error) {
+	page, err := pagination.Parse(req.GetPageSize(), req.GetPageToken())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	wallets, total, err := h.walletService.GetWalletsByUserID(ctx, int(req.GetUserId()), page)
+	if err != nil {
+		h.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, err
+	}
+	var pbWallets []*pb.Wallet
+	for _, wallet := range wallets {
+		pbWallets = append(pbWallets, toWalletProto(wallet))
+	}
+	return &pb.ListWalletsResponse{
+		Wallets: pbWallets,
+		Page:    toPageProto(page, total),
+	}, nil
+}
+
 func (h *WalletHandler) CreateWallet(ctx context.Context, req *pb.CreateWalletRequest) (*pb.MutationResponse, error) {
 	createdWallet, err := h.walletService.CreateWallet(ctx, &entity.Wallet{
 		UserID: int(req.GetUserId()),
 	})
 	if err != nil {
-		log.Println(err)
+		h.logger.WarnContext(ctx, "request failed", "error", err)
 		return nil, err
 	}
 	return &pb.MutationResponse{
@@ -53,24 +80,17 @@ func (h *WalletHandler) CreateWallet(ctx context.Context, req *pb.CreateWalletRe
 	}, nil
 }
 
+// UpdateWallet used to overwrite the balance and owner of a wallet without
+// leaving a trace in the history. It is kept only to tell old clients what
+// to use instead.
 func (h *WalletHandler) UpdateWallet(ctx context.Context, req *pb.UpdateWalletRequest) (*pb.MutationResponse, error) {
-	updatedWallet, err := h.walletService.UpdateWallet(ctx, int(req.UserId), entity.Wallet{
-		UserID:  int(req.UserId),
-		Balance: req.Balance,
-	})
-	if err != nil {
-		log.Println(err)
-		return nil, err
-	}
-	return &pb.MutationResponse{
-		Message: fmt.Sprintf("Success updated wallet with ID %d", updatedWallet.ID),
-	}, nil
+	return nil, status.Error(codes.Unimplemented, "UpdateWallet is no longer supported: use ProposeBalanceAdjustment to change the balance and TransferWalletOwnership to change the owner")
 }
 
 func (h *WalletHandler) GetBalance(ctx context.Context, req *pb.GetBalanceRequest) (*pb.GetBalanceResponse, error) {
 	wallet, err := h.walletService.GetWalletByID(ctx, int(req.GetWalletId()))
 	if err != nil {
-		log.Println(err)
+		h.logger.WarnContext(ctx, "request failed", "error", err)
 		return nil, err
 	}
 	return &pb.GetBalanceResponse{
@@ -80,8 +100,8 @@ func (h *WalletHandler) GetBalance(ctx context.Context, req *pb.GetBalanceReques
 
 func (h *WalletHandler) TopUpWallet(ctx context.Context, req *pb.TopupRequest) (*pb.MutationResponse, error) {
 	if err := h.walletService.TopUpWallet(ctx, int(req.GetWalletId()), req.GetAmount()); err != nil {
-		log.Println(err)
-		return nil, err
+		h.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
 	}
 
 	return &pb.MutationResponse{
@@ -91,8 +111,8 @@ func (h *WalletHandler) TopUpWallet(ctx context.Context, req *pb.TopupRequest) (
 
 func (h *WalletHandler) Transfer(ctx context.Context, req *pb.TransferRequest) (*pb.MutationResponse, error) {
 	if err := h.walletService.Transfer(ctx, int(req.GetSenderId()), int(req.GetRecipientId()), req.GetAmount()); err != nil {
-		log.Println(err)
-		return nil, err
+		h.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
 	}
 	return &pb.MutationResponse{
 		Message: fmt.Sprintf("Successfully transferred from wallet ID %d to wallet ID %d", req.GetSenderId(), req.GetRecipientId()),
@@ -100,23 +120,53 @@ func (h *WalletHandler) Transfer(ctx context.Context, req *pb.TransferRequest) (
 }
 
 func (h *WalletHandler) GetTransactions(ctx context.Context, req *pb.GetTransactionsRequest) (*pb.GetTransactionsResponse, error) {
-	transactions, err := h.walletService.GetTransactions(ctx, int(req.GetWalletId()))
+	page, err := pagination.Parse(req.GetPageSize(), req.GetPageToken())
 	if err != nil {
-		log.Println(err)
+		return nil, toStatusError(err)
+	}
+	transactions, total, err := h.walletService.GetTransactions(ctx, int(req.GetWalletId()), page)
+	if err != nil {
+		h.logger.WarnContext(ctx, "request failed", "error", err)
 		return nil, err
 	}
 	var pbTransactions []*pb.Transaction
 	for _, transaction := range transactions {
-		pbTransactions = append(pbTransactions, &pb.Transaction{
-			Id:          int32(transaction.ID),
-			SenderId:    int32(transaction.SenderID),
-			RecipientId: int32(transaction.RecipientID),
-			Amount:      transaction.Amount,
-			CreatedAt:   timestamppb.New(transaction.CreatedAt),
-			UpdatedAt:   timestamppb.New(transaction.UpdatedAt),
-		})
+		pbTransactions = append(pbTransactions, toTransactionProto(transaction))
 	}
 	return &pb.GetTransactionsResponse{
 		Transactions: pbTransactions,
+		Page:         toPageProto(page, total),
 	}, nil
 }
+
+func toTransactionProto(transaction entity.Transaction) *pb.Transaction {
+	return &pb.Transaction{
+		Id:          int32(transaction.ID),
+		Type:        transaction.Type,
+		SenderId:    int32(transaction.SenderID),
+		RecipientId: int32(transaction.RecipientID),
+		Amount:      transaction.Amount,
+		RelatedId:   int32(transaction.RelatedID),
+		BatchId:     int32(transaction.BatchID),
+		CreatedAt:   timestamppb.New(transaction.CreatedAt),
+		UpdatedAt:   timestamppb.New(transaction.UpdatedAt),
+	}
+}
+
+func toWalletProto(wallet entity.Wallet) *pb.Wallet {
+	res := &pb.Wallet{
+		Id:           int32(wallet.ID),
+		UserId:       int32(wallet.UserID),
+		Balance:      wallet.Balance,
+		Tier:         wallet.Tier,
+		Internal:     wallet.Internal,
+		Status:       wallet.Status,
+		StatusReason: wallet.StatusReason,
+		CreatedAt:    timestamppb.New(wallet.CreatedAt),
+		UpdatedAt:    timestamppb.New(wallet.UpdatedAt),
+	}
+	if wallet.StatusChangedAt != nil {
+		res.StatusChangedAt = timestamppb.New(*wallet.StatusChangedAt)
+	}
+	return res
+}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/susilo001/simple-wallet-system/wallet/entity"
+	pb "github.com/susilo001/simple-wallet-system/wallet/proto/wallet/v1"
+)
+
+func (h *WalletHandler) SetWalletTier(ctx context.Context, req *pb.SetWalletTierRequest) (*pb.MutationResponse, error) {
+	if err := h.walletService.SetWalletTier(ctx, int(req.GetWalletId()), req.GetTier()); err != nil {
+		h.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return &pb.MutationResponse{
+		Message: fmt.Sprintf("Successfully set tier of wallet ID %d to %s", req.GetWalletId(), req.GetTier()),
+	}, nil
+}
+
+func (h *WalletHandler) GetWalletLimits(ctx context.Context, req *pb.GetWalletLimitsRequest) (*pb.GetWalletLimitsResponse, error) {
+	limits, err := h.walletService.GetWalletLimits(ctx, int(req.GetWalletId()))
+	if err != nil {
+		h.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return &pb.GetWalletLimitsResponse{
+		Limits: &pb.WalletLimits{
+			MaxSingleTransfer:   limits.Limit.MaxSingleTransfer,
+			DailyOutgoing:       limits.Limit.DailyOutgoing,
+			MonthlyOutgoing:     limits.Limit.MonthlyOutgoing,
+			MaxTransfersPerHour: int32(limits.Limit.MaxTransfersPerHour),
+			MaxBalance:          limits.Limit.MaxBalance,
+		},
+		Source:   limits.Source,
+		KycLevel: limits.KYCLevel,
+		Usage: &pb.WalletLimitUsage{
+			DailyOutgoing:     limits.DailyOutgoingUsed,
+			MonthlyOutgoing:   limits.MonthlyOutgoingUsed,
+			TransfersLastHour: int32(limits.TransfersLastHour),
+		},
+	}, nil
+}
+
+func (h *WalletHandler) SetWalletLimits(ctx context.Context, req *pb.SetWalletLimitsRequest) (*pb.MutationResponse, error) {
+	limits := req.GetLimits()
+	saved, err := h.walletService.SetWalletLimits(ctx, entity.WalletLimit{
+		WalletID:            int(req.GetWalletId()),
+		Tier:                req.GetTier(),
+		MaxSingleTransfer:   limits.GetMaxSingleTransfer(),
+		DailyOutgoing:       limits.GetDailyOutgoing(),
+		MonthlyOutgoing:     limits.GetMonthlyOutgoing(),
+		MaxTransfersPerHour: int(limits.GetMaxTransfersPerHour()),
+		MaxBalance:          limits.GetMaxBalance(),
+	})
+	if err != nil {
+		h.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return &pb.MutationResponse{
+		Message: fmt.Sprintf("Successfully saved wallet limits with ID %d", saved.ID),
+	}, nil
+}
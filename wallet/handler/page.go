@@ -0,0 +1,14 @@
+package handler
+
+import (
+	"github.com/susilo001/simple-wallet-system/wallet/pagination"
+	pb "github.com/susilo001/simple-wallet-system/wallet/proto/wallet/v1"
+)
+
+// toPageProto describes page of a list of total items.
+func toPageProto(page pagination.Page, total int) *pb.Page {
+	return &pb.Page{
+		NextPageToken: page.NextPageToken(total),
+		TotalSize:     int32(total),
+	}
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/susilo001/simple-wallet-system/wallet/entity"
+	"github.com/susilo001/simple-wallet-system/wallet/pagination"
+	pb "github.com/susilo001/simple-wallet-system/wallet/proto/wallet/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (h *WalletHandler) RequestPayment(ctx context.Context, req *pb.RequestPaymentRequest) (*pb.PaymentRequest, error) {
+	request, err := h.paymentRequestService.RequestPayment(ctx, int(req.GetRequesterWalletId()), int(req.GetPayerWalletId()),
+		req.GetAmount(), req.GetNote(), time.Duration(req.GetExpiresInSeconds())*time.Second)
+	if err != nil {
+		h.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return toPaymentRequestProto(request), nil
+}
+
+func (h *WalletHandler) CreatePaymentLink(ctx context.Context, req *pb.CreatePaymentLinkRequest) (*pb.PaymentRequest, error) {
+	request, err := h.paymentRequestService.CreatePaymentLink(ctx, int(req.GetRequesterWalletId()),
+		req.GetAmount(), req.GetNote(), time.Duration(req.GetExpiresInSeconds())*time.Second)
+	if err != nil {
+		h.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return toPaymentRequestProto(request), nil
+}
+
+func (h *WalletHandler) GetPaymentRequest(ctx context.Context, req *pb.GetPaymentRequestRequest) (*pb.PaymentRequest, error) {
+	var request entity.PaymentRequest
+	var err error
+	if req.GetCode() != "" {
+		request, err = h.paymentRequestService.GetPaymentLink(ctx, req.GetCode())
+	} else {
+		request, err = h.paymentRequestService.GetPaymentRequest(ctx, int(req.GetId()))
+	}
+	if err != nil {
+		h.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return toPaymentRequestProto(request), nil
+}
+
+func (h *WalletHandler) ListPaymentRequests(ctx context.Context, req *pb.ListPaymentRequestsRequest) (*pb.ListPaymentRequestsResponse, error) {
+	page, err := pagination.Parse(req.GetPageSize(), req.GetPageToken())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	requests, total, err := h.paymentRequestService.GetPaymentRequests(ctx, int(req.GetWalletId()), page)
+	if err != nil {
+		h.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, err
+	}
+	var pbRequests []*pb.PaymentRequest
+	for _, request := range requests {
+		pbRequests = append(pbRequests, toPaymentRequestProto(request))
+	}
+	return &pb.ListPaymentRequestsResponse{
+		PaymentRequests: pbRequests,
+		Page:            toPageProto(page, total),
+	}, nil
+}
+
+func (h *WalletHandler) AcceptPaymentRequest(ctx context.Context, req *pb.AcceptPaymentRequestRequest) (*pb.PaymentRequest, error) {
+	var request entity.PaymentRequest
+	var err error
+	if req.GetCode() != "" {
+		request, err = h.paymentRequestService.PayPaymentLink(ctx, req.GetCode(), int(req.GetPayerWalletId()))
+	} else {
+		request, err = h.paymentRequestService.AcceptPaymentRequest(ctx, int(req.GetId()), int(req.GetPayerWalletId()))
+	}
+	if err != nil {
+		h.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return toPaymentRequestProto(request), nil
+}
+
+func (h *WalletHandler) DeclinePaymentRequest(ctx context.Context, req *pb.DeclinePaymentRequestRequest) (*pb.PaymentRequest, error) {
+	request, err := h.paymentRequestService.DeclinePaymentRequest(ctx, int(req.GetId()), int(req.GetPayerWalletId()))
+	if err != nil {
+		h.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return toPaymentRequestProto(request), nil
+}
+
+func (h *WalletHandler) CancelPaymentRequest(ctx context.Context, req *pb.CancelPaymentRequestRequest) (*pb.PaymentRequest, error) {
+	request, err := h.paymentRequestService.CancelPaymentRequest(ctx, int(req.GetId()), int(req.GetRequesterWalletId()))
+	if err != nil {
+		h.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return toPaymentRequestProto(request), nil
+}
+
+func toPaymentRequestProto(request entity.PaymentRequest) *pb.PaymentRequest {
+	res := &pb.PaymentRequest{
+		Id:                int32(request.ID),
+		Kind:              request.Kind,
+		RequesterWalletId: int32(request.RequesterID),
+		PayerWalletId:     int32(request.PayerID),
+		Amount:            request.Amount,
+		Note:              request.Note,
+		Status:            request.Status,
+		ExpiresAt:         timestamppb.New(request.ExpiresAt),
+		CreatedAt:         timestamppb.New(request.CreatedAt),
+	}
+	if request.Code != nil {
+		res.Code = *request.Code
+	}
+	if request.PaidAt != nil {
+		res.PaidAt = timestamppb.New(*request.PaidAt)
+	}
+	if request.HeldTransferID != nil {
+		res.HeldTransferId = int32(*request.HeldTransferID)
+	}
+	return res
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+
+	pb "github.com/susilo001/simple-wallet-system/wallet/proto/wallet/v1"
+	"github.com/susilo001/simple-wallet-system/wallet/reconciliation"
+	"github.com/susilo001/simple-wallet-system/wallet/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (h *WalletHandler) Reconcile(ctx context.Context, req *pb.ReconcileRequest) (*pb.ReconciliationReportResponse, error) {
+	if err := checkReportFormat(req.GetFormat()); err != nil {
+		return nil, err
+	}
+
+	report, err := h.reconciliationService.Reconcile(ctx, req.GetProposeAdjustments())
+	if err != nil {
+		h.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return h.toReconciliationReportProto(ctx, report, req.GetFormat())
+}
+
+func (h *WalletHandler) GetReconciliationReport(ctx context.Context, req *pb.GetReconciliationReportRequest) (*pb.ReconciliationReportResponse, error) {
+	if err := checkReportFormat(req.GetFormat()); err != nil {
+		return nil, err
+	}
+
+	report, err := h.reconciliationService.GetReconciliationReport(ctx, int(req.GetRunId()))
+	if err != nil {
+		h.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return h.toReconciliationReportProto(ctx, report, req.GetFormat())
+}
+
+func checkReportFormat(format string) error {
+	if format != "" && format != reconciliation.FormatJSON && format != reconciliation.FormatCSV {
+		return status.Errorf(codes.InvalidArgument, "unknown report format %q", format)
+	}
+	return nil
+}
+
+func (h *WalletHandler) toReconciliationReportProto(ctx context.Context, report service.ReconciliationReport, format string) (*pb.ReconciliationReportResponse, error) {
+	run := report.Run
+	res := &pb.ReconciliationReportResponse{
+		Run: &pb.ReconciliationRun{
+			Id:                   int32(run.ID),
+			WalletsChecked:       int32(run.WalletsChecked),
+			DiscrepancyCount:     int32(run.DiscrepancyCount),
+			TotalLiveBalance:     run.TotalLiveBalance,
+			TotalComputedBalance: run.TotalComputedBalance,
+			ExternalNet:          run.ExternalNet,
+			Conserved:            run.Conserved,
+			AdjustmentsProposed:  int32(run.AdjustmentsProposed),
+			StartedAt:            timestamppb.New(run.StartedAt),
+			FinishedAt:           timestamppb.New(run.FinishedAt),
+		},
+	}
+	for _, d := range report.Discrepancies {
+		res.Discrepancies = append(res.Discrepancies, &pb.ReconciliationDiscrepancy{
+			WalletId:        int32(d.WalletID),
+			LiveBalance:     d.LiveBalance,
+			ComputedBalance: d.ComputedBalance,
+			Difference:      d.Difference,
+			WalletMissing:   d.WalletMissing,
+			AdjustmentId:    int32(d.AdjustmentID),
+		})
+	}
+
+	if format != "" {
+		var buf bytes.Buffer
+		contentType, err := reconciliation.Render(&buf, report, format)
+		if err != nil {
+			h.logger.WarnContext(ctx, "request failed", "error", err)
+			return nil, err
+		}
+		res.Document = buf.Bytes()
+		res.ContentType = contentType
+		res.Filename = reconciliation.Filename(report, format)
+	}
+	return res, nil
+}
@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/susilo001/simple-wallet-system/wallet/entity"
+	"github.com/susilo001/simple-wallet-system/wallet/pagination"
+	pb "github.com/susilo001/simple-wallet-system/wallet/proto/wallet/v1"
+	"github.com/susilo001/simple-wallet-system/wallet/risk"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (h *WalletHandler) ListHeldTransfers(ctx context.Context, req *pb.ListHeldTransfersRequest) (*pb.ListHeldTransfersResponse, error) {
+	page, err := pagination.Parse(req.GetPageSize(), req.GetPageToken())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	held, total, err := h.walletService.GetHeldTransfers(ctx, req.GetStatus(), page)
+	if err != nil {
+		h.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+
+	var pbHeld []*pb.HeldTransfer
+	for _, transfer := range held {
+		pbHeld = append(pbHeld, h.toHeldTransferProto(ctx, transfer))
+	}
+	return &pb.ListHeldTransfersResponse{
+		HeldTransfers: pbHeld,
+		Page:          toPageProto(page, total),
+	}, nil
+}
+
+func (h *WalletHandler) ReviewHeldTransfer(ctx context.Context, req *pb.ReviewHeldTransferRequest) (*pb.HeldTransfer, error) {
+	held, err := h.walletService.ReviewHeldTransfer(ctx, int(req.GetHeldTransferId()), req.GetApprove(), req.GetReviewer())
+	if err != nil {
+		h.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return h.toHeldTransferProto(ctx, held), nil
+}
+
+func (h *WalletHandler) GetRiskMetrics(ctx context.Context, req *emptypb.Empty) (*pb.RiskMetrics, error) {
+	stats := h.walletService.GetRiskStats()
+	res := &pb.RiskMetrics{Decisions: map[string]int64{}}
+	for _, rule := range stats.Rules {
+		res.Rules = append(res.Rules, &pb.RiskRuleMetrics{
+			Rule:        rule.Rule,
+			Evaluations: rule.Evaluations,
+			Hits:        rule.Hits,
+		})
+	}
+	for decision, count := range stats.Decisions {
+		res.Decisions[string(decision)] = count
+	}
+	return res, nil
+}
+
+func (h *WalletHandler) toHeldTransferProto(ctx context.Context, held entity.HeldTransfer) *pb.HeldTransfer {
+	res := &pb.HeldTransfer{
+		Id:            int32(held.ID),
+		SenderId:      int32(held.SenderID),
+		RecipientId:   int32(held.RecipientID),
+		Amount:        held.Amount,
+		Status:        held.Status,
+		RiskScore:     int32(held.RiskScore),
+		ReviewedBy:    held.ReviewedBy,
+		TransactionId: int32(held.TransactionID),
+		CreatedAt:     timestamppb.New(held.CreatedAt),
+	}
+	var hits []risk.Hit
+	if err := json.Unmarshal([]byte(held.RiskHits), &hits); err != nil {
+		h.logger.ErrorContext(ctx, "Error decoding risk hits of held transfer", "held_transfer_id", held.ID, "error", err)
+	}
+	for _, hit := range hits {
+		res.RiskHits = append(res.RiskHits, &pb.RiskHit{
+			Rule:   hit.Rule,
+			Score:  int32(hit.Score),
+			Reason: hit.Reason,
+		})
+	}
+	if held.ReviewedAt != nil {
+		res.ReviewedAt = timestamppb.New(*held.ReviewedAt)
+	}
+	return res
+}
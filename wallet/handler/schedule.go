@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/susilo001/simple-wallet-system/wallet/entity"
+	"github.com/susilo001/simple-wallet-system/wallet/pagination"
+	pb "github.com/susilo001/simple-wallet-system/wallet/proto/wallet/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (h *WalletHandler) ScheduleTransfer(ctx context.Context, req *pb.ScheduleTransferRequest) (*pb.ScheduledTransfer, error) {
+	schedule := entity.ScheduledTransfer{
+		SenderID:        int(req.GetSenderId()),
+		RecipientID:     int(req.GetRecipientId()),
+		Amount:          req.GetAmount(),
+		IntervalSeconds: int(req.GetIntervalSeconds()),
+		CronExpr:        req.GetCron(),
+	}
+	if req.EndAt != nil {
+		endAt := req.GetEndAt().AsTime()
+		schedule.EndAt = &endAt
+	}
+	var startAt time.Time
+	if req.StartAt != nil {
+		startAt = req.GetStartAt().AsTime()
+	}
+
+	created, err := h.scheduleService.ScheduleTransfer(ctx, schedule, startAt)
+	if err != nil {
+		h.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return toScheduledTransferProto(created), nil
+}
+
+func (h *WalletHandler) ListScheduledTransfers(ctx context.Context, req *pb.ListScheduledTransfersRequest) (*pb.ListScheduledTransfersResponse, error) {
+	page, err := pagination.Parse(req.GetPageSize(), req.GetPageToken())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	schedules, total, err := h.scheduleService.GetScheduledTransfers(ctx, int(req.GetWalletId()), page)
+	if err != nil {
+		h.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, err
+	}
+	var pbSchedules []*pb.ScheduledTransfer
+	for _, schedule := range schedules {
+		pbSchedules = append(pbSchedules, toScheduledTransferProto(schedule))
+	}
+	return &pb.ListScheduledTransfersResponse{
+		ScheduledTransfers: pbSchedules,
+		Page:               toPageProto(page, total),
+	}, nil
+}
+
+func (h *WalletHandler) PauseScheduledTransfer(ctx context.Context, req *pb.ScheduledTransferRequest) (*pb.ScheduledTransfer, error) {
+	schedule, err := h.scheduleService.PauseScheduledTransfer(ctx, int(req.GetId()))
+	if err != nil {
+		h.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return toScheduledTransferProto(schedule), nil
+}
+
+func (h *WalletHandler) ResumeScheduledTransfer(ctx context.Context, req *pb.ScheduledTransferRequest) (*pb.ScheduledTransfer, error) {
+	schedule, err := h.scheduleService.ResumeScheduledTransfer(ctx, int(req.GetId()))
+	if err != nil {
+		h.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return toScheduledTransferProto(schedule), nil
+}
+
+func (h *WalletHandler) CancelScheduledTransfer(ctx context.Context, req *pb.ScheduledTransferRequest) (*pb.ScheduledTransfer, error) {
+	schedule, err := h.scheduleService.CancelScheduledTransfer(ctx, int(req.GetId()))
+	if err != nil {
+		h.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return toScheduledTransferProto(schedule), nil
+}
+
+func toScheduledTransferProto(schedule entity.ScheduledTransfer) *pb.ScheduledTransfer {
+	res := &pb.ScheduledTransfer{
+		Id:              int32(schedule.ID),
+		SenderId:        int32(schedule.SenderID),
+		RecipientId:     int32(schedule.RecipientID),
+		Amount:          schedule.Amount,
+		Kind:            schedule.Kind,
+		IntervalSeconds: int32(schedule.IntervalSeconds),
+		Cron:            schedule.CronExpr,
+		Status:          schedule.Status,
+		NextRunAt:       timestamppb.New(schedule.NextRunAt),
+		Attempts:        int32(schedule.Attempts),
+		ExecutedCount:   int32(schedule.ExecutedCount),
+		LastError:       schedule.LastError,
+		CreatedAt:       timestamppb.New(schedule.CreatedAt),
+	}
+	if schedule.EndAt != nil {
+		res.EndAt = timestamppb.New(*schedule.EndAt)
+	}
+	if schedule.LastRunAt != nil {
+		res.LastRunAt = timestamppb.New(*schedule.LastRunAt)
+	}
+	if schedule.HeldTransferID != nil {
+		res.HeldTransferId = int32(*schedule.HeldTransferID)
+	}
+	return res
+}
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+
+	pb "github.com/susilo001/simple-wallet-system/wallet/proto/wallet/v1"
+	"github.com/susilo001/simple-wallet-system/wallet/statement"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (h *WalletHandler) GetStatement(ctx context.Context, req *pb.GetStatementRequest) (*pb.GetStatementResponse, error) {
+	format := req.GetFormat()
+	if format != "" && format != statement.FormatCSV && format != statement.FormatPDF {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown statement format %q", format)
+	}
+
+	s, err := h.walletService.GetStatement(ctx, int(req.GetWalletId()), req.GetFrom().AsTime(), req.GetTo().AsTime())
+	if err != nil {
+		h.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+
+	var pbLines []*pb.StatementLine
+	for _, line := range s.Lines {
+		pbLines = append(pbLines, &pb.StatementLine{
+			Transaction:          toTransactionProto(line.Transaction),
+			CounterpartyWalletId: int32(line.Counterparty),
+			Credit:               line.Credit,
+			Debit:                line.Debit,
+			RunningBalance:       line.RunningBalance,
+		})
+	}
+	res := &pb.GetStatementResponse{
+		Statement: &pb.Statement{
+			WalletId:       int32(s.Wallet.ID),
+			From:           timestamppb.New(s.From),
+			To:             timestamppb.New(s.To),
+			OpeningBalance: s.OpeningBalance,
+			Lines:          pbLines,
+			TotalCredits:   s.TotalCredits,
+			TotalDebits:    s.TotalDebits,
+			ClosingBalance: s.ClosingBalance,
+			GeneratedAt:    timestamppb.New(s.GeneratedAt),
+		},
+	}
+
+	if format != "" {
+		var buf bytes.Buffer
+		contentType, err := statement.Render(&buf, s, format)
+		if err != nil {
+			h.logger.WarnContext(ctx, "request failed", "error", err)
+			return nil, err
+		}
+		res.Document = buf.Bytes()
+		res.ContentType = contentType
+		res.Filename = statement.Filename(s, format)
+	}
+	return res, nil
+}
@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"context"
+
+	pb "github.com/susilo001/simple-wallet-system/wallet/proto/wallet/v1"
+)
+
+func (h *WalletHandler) FreezeWallet(ctx context.Context, req *pb.FreezeWalletRequest) (*pb.Wallet, error) {
+	wallet, err := h.walletService.FreezeWallet(ctx, int(req.GetWalletId()), req.GetStatus(), req.GetReason())
+	if err != nil {
+		h.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return toWalletProto(wallet), nil
+}
+
+func (h *WalletHandler) UnfreezeWallet(ctx context.Context, req *pb.UnfreezeWalletRequest) (*pb.Wallet, error) {
+	wallet, err := h.walletService.UnfreezeWallet(ctx, int(req.GetWalletId()), req.GetReason())
+	if err != nil {
+		h.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return toWalletProto(wallet), nil
+}
+
+func (h *WalletHandler) CloseWallet(ctx context.Context, req *pb.CloseWalletRequest) (*pb.Wallet, error) {
+	wallet, err := h.walletService.CloseWallet(ctx, int(req.GetWalletId()), req.GetReason())
+	if err != nil {
+		h.logger.WarnContext(ctx, "request failed", "error", err)
+		return nil, toStatusError(err)
+	}
+	return toWalletProto(wallet), nil
+}
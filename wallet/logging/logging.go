@@ -0,0 +1,170 @@
+// Package logging sets up the structured logger. Records logged with the
+// context of a gRPC call carry its request ID, which the gateway generates
+// and passes on in the x-request-id metadata, so that one request can be
+// followed across the services' logs.
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// RequestIDMetadataKey is the gRPC metadata carrying the request ID.
+const RequestIDMetadataKey = "x-request-id"
+
+// redacted replaces the values of sensitive attributes.
+const redacted = "[REDACTED]"
+
+// sensitiveKeys are attribute keys whose values are never logged.
+var sensitiveKeys = map[string]bool{
+	"password":      true,
+	"new_password":  true,
+	"token":         true,
+	"access_token":  true,
+	"refresh_token": true,
+	"secret":        true,
+	"code":          true,
+	"key":           true,
+	"api_key":       true,
+	"authorization": true,
+}
+
+// New returns a logger writing to w at level ("debug", "info", "warn" or
+// "error"), as JSON lines if json is set and as text otherwise. Sensitive
+// attributes are redacted and email addresses masked.
+func New(w io.Writer, level string, json bool) *slog.Logger {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		lvl = slog.LevelInfo
+	}
+	opts := &slog.HandlerOptions{Level: lvl, ReplaceAttr: redact}
+	var handler slog.Handler = slog.NewTextHandler(w, opts)
+	if json {
+		handler = slog.NewJSONHandler(w, opts)
+	}
+	return slog.New(contextHandler{handler})
+}
+
+func redact(groups []string, a slog.Attr) slog.Attr {
+	key := strings.ToLower(a.Key)
+	switch {
+	case sensitiveKeys[key]:
+		return slog.String(a.Key, redacted)
+	case key == "email":
+		return slog.String(a.Key, MaskEmail(a.Value.String()))
+	}
+	return a
+}
+
+// MaskEmail keeps the first letter and the domain of an email address,
+// enough to tell users apart in logs without exposing the address.
+func MaskEmail(email string) string {
+	local, domain, ok := strings.Cut(email, "@")
+	if !ok || local == "" {
+		return redacted
+	}
+	return local[:1] + "***@" + domain
+}
+
+type requestIDKey struct{}
+type methodKey struct{}
+
+// WithRequestID returns a copy of ctx carrying the request ID.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestID returns the request ID carried by ctx, if any.
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// NewRequestID returns a random request ID, for work that did not come
+// through the gateway.
+func NewRequestID() string {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+// contextHandler adds the request ID, gRPC method and trace ID carried by a
+// record's context to the record.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if requestID := RequestID(ctx); requestID != "" {
+		r.AddAttrs(slog.String("request_id", requestID))
+	}
+	if method, ok := ctx.Value(methodKey{}).(string); ok {
+		r.AddAttrs(slog.String("method", method))
+	}
+	if span := trace.SpanContextFromContext(ctx); span.HasTraceID() {
+		r.AddAttrs(slog.String("trace_id", span.TraceID().String()))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
+
+// UnaryServerInterceptor puts the request ID from the call's metadata, or a
+// new one if it has none, and the method in the call's context, and logs
+// every call once it has been handled. It should run first so that the
+// other interceptors log with the request ID.
+func UnaryServerInterceptor(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		requestID := ""
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(RequestIDMetadataKey); len(values) > 0 {
+				requestID = values[0]
+			}
+		}
+		if requestID == "" {
+			requestID = NewRequestID()
+		}
+		ctx = WithRequestID(ctx, requestID)
+		ctx = context.WithValue(ctx, methodKey{}, info.FullMethod)
+
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		code := status.Code(err)
+		level := slog.LevelInfo
+		switch code {
+		case codes.OK:
+		case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable:
+			level = slog.LevelError
+		default:
+			level = slog.LevelWarn
+		}
+		logger.Log(ctx, level, "handled call", "grpc_code", code.String(), "duration", time.Since(start))
+		return resp, err
+	}
+}
+
+// OutgoingContext returns a copy of ctx that passes its request ID on to
+// the services it calls.
+func OutgoingContext(ctx context.Context) context.Context {
+	if requestID := RequestID(ctx); requestID != "" {
+		return metadata.AppendToOutgoingContext(ctx, RequestIDMetadataKey, requestID)
+	}
+	return ctx
+}
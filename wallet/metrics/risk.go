@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/susilo001/simple-wallet-system/wallet/risk"
+)
+
+var (
+	riskEvaluationsDesc = prometheus.NewDesc("wallet_risk_rule_evaluations_total",
+		"Transfers evaluated by each risk rule.", []string{"rule"}, nil)
+	riskHitsDesc = prometheus.NewDesc("wallet_risk_rule_hits_total",
+		"Transfers matched by each risk rule.", []string{"rule"}, nil)
+	riskDecisionsDesc = prometheus.NewDesc("wallet_risk_decisions_total",
+		"Risk assessments, by decision.", []string{"decision"}, nil)
+)
+
+// riskCollector exports the counters the risk engine already keeps, read
+// on every scrape.
+type riskCollector struct {
+	stats func() risk.Stats
+}
+
+// NewRiskCollector exports the risk engine counters returned by stats,
+// such as IWalletService.GetRiskStats.
+func NewRiskCollector(stats func() risk.Stats) prometheus.Collector {
+	return riskCollector{stats: stats}
+}
+
+func (c riskCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- riskEvaluationsDesc
+	ch <- riskHitsDesc
+	ch <- riskDecisionsDesc
+}
+
+func (c riskCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.stats()
+	for _, rule := range stats.Rules {
+		ch <- prometheus.MustNewConstMetric(riskEvaluationsDesc, prometheus.CounterValue, float64(rule.Evaluations), rule.Rule)
+		ch <- prometheus.MustNewConstMetric(riskHitsDesc, prometheus.CounterValue, float64(rule.Hits), rule.Rule)
+	}
+	for decision, count := range stats.Decisions {
+		ch <- prometheus.MustNewConstMetric(riskDecisionsDesc, prometheus.CounterValue, float64(count), string(decision))
+	}
+}
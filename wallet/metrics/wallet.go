@@ -0,0 +1,49 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Kinds of transfer, for the kind label.
+const (
+	TransferSingle   = "single"
+	TransferBatch    = "batch"
+	TransferReleased = "released"
+)
+
+// amountBuckets span amounts from 10 thousand to 100 million.
+var amountBuckets = prometheus.ExponentialBuckets(10_000, 10, 5)
+
+var (
+	transferAmount = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "wallet_transfer_amount",
+		Help:    "Amounts of completed transfers, by kind. Its count is the transfer volume and its sum the total amount.",
+		Buckets: amountBuckets,
+	}, []string{"kind"})
+	topUpAmount = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "wallet_topup_amount",
+		Help:    "Amounts of completed top-ups. Its count is the number of top-ups and its sum the total amount.",
+		Buckets: amountBuckets,
+	})
+	insufficientBalance = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "wallet_insufficient_balance_rejections_total",
+		Help: "Operations rejected for insufficient balance, by operation.",
+	}, []string{"operation"})
+)
+
+// TransferCompleted records a committed transfer of the given kind.
+func TransferCompleted(kind string, amount float64) {
+	transferAmount.WithLabelValues(kind).Observe(amount)
+}
+
+// TopUpCompleted records a committed top-up.
+func TopUpCompleted(amount float64) {
+	topUpAmount.Observe(amount)
+}
+
+// InsufficientBalance records an operation, such as "transfer" or
+// "withdrawal", rejected for insufficient balance.
+func InsufficientBalance(operation string) {
+	insufficientBalance.WithLabelValues(operation).Inc()
+}
@@ -0,0 +1,90 @@
+// Package pagination reads page_size and page_token from list requests and
+// fetches one page from the database with LIMIT and OFFSET. Every service
+// pages its lists with the same rules and the same page token format.
+package pagination
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// DefaultPageSize is used when page_size is not set.
+	DefaultPageSize = 50
+	// MaxPageSize is the largest page_size; larger values are lowered to
+	// it.
+	MaxPageSize = 100
+)
+
+var (
+	// ErrInvalidPageSize is returned for a negative page_size.
+	ErrInvalidPageSize = errors.New("page_size must not be negative")
+	// ErrInvalidPageToken is returned for a page_token that was not made by
+	// NextPageToken.
+	ErrInvalidPageToken = errors.New("invalid page_token")
+)
+
+// Page is one page of a list: at most Limit items starting at item
+// Offset.
+type Page struct {
+	Limit  int
+	Offset int
+}
+
+// Parse turns the page_size and page_token of a request into a Page. A
+// page_size of 0 means DefaultPageSize. The page token holds the offset of
+// the page; clients must treat it as opaque.
+func Parse(pageSize int32, pageToken string) (Page, error) {
+	if pageSize < 0 {
+		return Page{}, ErrInvalidPageSize
+	}
+	page := Page{Limit: DefaultPageSize}
+	if pageSize > 0 {
+		page.Limit = min(int(pageSize), MaxPageSize)
+	}
+	if pageToken != "" {
+		raw, err := base64.RawURLEncoding.DecodeString(pageToken)
+		if err == nil {
+			page.Offset, err = strconv.Atoi(string(raw))
+		}
+		if err != nil || page.Offset < 0 {
+			return Page{}, ErrInvalidPageToken
+		}
+	}
+	return page, nil
+}
+
+// NextPageToken returns the page token of the page after p in a list of
+// total items, or an empty string if p is the last page.
+func (p Page) NextPageToken(total int) string {
+	end := p.Offset + p.Limit
+	if end >= total {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(end)))
+}
+
+// Find fills dest, a pointer to a slice, with the rows of page p of query
+// and returns the number of rows in the whole query. The query must be
+// ordered so that its pages do not overlap.
+func Find(query *gorm.DB, p Page, dest any) (int, error) {
+	query = query.Model(dest).Session(&gorm.Session{})
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return 0, err
+	}
+	if err := query.Limit(p.Limit).Offset(p.Offset).Find(dest).Error; err != nil {
+		return 0, err
+	}
+	return int(total), nil
+}
+
+// Slice returns the items of page p of a list already in memory.
+func Slice[T any](items []T, p Page) []T {
+	start := min(p.Offset, len(items))
+	end := min(start+p.Limit, len(items))
+	return items[start:end]
+}
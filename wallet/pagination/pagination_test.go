@@ -0,0 +1,94 @@
+package pagination
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name      string
+		pageSize  int32
+		pageToken string
+		want      Page
+		wantErr   error
+	}{
+		{name: "default", want: Page{Limit: DefaultPageSize}},
+		{name: "requested size", pageSize: 10, want: Page{Limit: 10}},
+		{name: "capped at the maximum", pageSize: 1000, want: Page{Limit: MaxPageSize}},
+		{name: "token from NextPageToken", pageSize: 10, pageToken: Page{Limit: 10}.NextPageToken(25), want: Page{Limit: 10, Offset: 10}},
+		{name: "negative size", pageSize: -1, wantErr: ErrInvalidPageSize},
+		{name: "token not base64", pageToken: "!!", wantErr: ErrInvalidPageToken},
+		{name: "token not a number", pageToken: "YWJj", wantErr: ErrInvalidPageToken},
+		{name: "negative offset", pageToken: "LTE", wantErr: ErrInvalidPageToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.pageSize, tt.pageToken)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Parse() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Parse() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNextPageToken(t *testing.T) {
+	tests := []struct {
+		name     string
+		page     Page
+		total    int
+		wantNext bool
+	}{
+		{name: "more pages", page: Page{Limit: 10}, total: 11, wantNext: true},
+		{name: "exactly the last page", page: Page{Limit: 10}, total: 10},
+		{name: "empty list", page: Page{Limit: 10}, total: 0},
+		{name: "offset past the end", page: Page{Limit: 10, Offset: 30}, total: 20},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := tt.page.NextPageToken(tt.total)
+			if (token != "") != tt.wantNext {
+				t.Fatalf("NextPageToken(%d) = %q, want next page %v", tt.total, token, tt.wantNext)
+			}
+			if token == "" {
+				return
+			}
+			next, err := Parse(int32(tt.page.Limit), token)
+			if err != nil {
+				t.Fatalf("Parse(NextPageToken) error = %v", err)
+			}
+			if want := tt.page.Offset + tt.page.Limit; next.Offset != want {
+				t.Errorf("next offset = %d, want %d", next.Offset, want)
+			}
+		})
+	}
+}
+
+func TestSlice(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+	tests := []struct {
+		name string
+		page Page
+		want []int
+	}{
+		{name: "first page", page: Page{Limit: 2}, want: []int{1, 2}},
+		{name: "short last page", page: Page{Limit: 2, Offset: 4}, want: []int{5}},
+		{name: "offset past the end", page: Page{Limit: 2, Offset: 9}, want: []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Slice(items, tt.page)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Slice() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("Slice() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,9 @@
+package wallet
+
+import _ "embed"
+
+// OpenAPI is the OpenAPI v2 document of the HTTP rules in wallet.proto,
+// generated by buf generate together with the code in this package.
+//
+//go:embed wallet.swagger.json
+var OpenAPI []byte
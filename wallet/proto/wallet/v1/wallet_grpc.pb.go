@@ -11,6 +11,7 @@ import (
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -23,12 +24,58 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type WalletServiceClient interface {
 	GetWallet(ctx context.Context, in *GetWalletRequest, opts ...grpc.CallOption) (*GetWalletResponse, error)
+	ListWallets(ctx context.Context, in *ListWalletsRequest, opts ...grpc.CallOption) (*ListWalletsResponse, error)
 	CreateWallet(ctx context.Context, in *CreateWalletRequest, opts ...grpc.CallOption) (*MutationResponse, error)
+	// Deprecated: Do not use.
+	// Deprecated: balances only change through transactions and approved
+	// balance adjustments, and owners through TransferWalletOwnership.
 	UpdateWallet(ctx context.Context, in *UpdateWalletRequest, opts ...grpc.CallOption) (*MutationResponse, error)
 	GetBalance(ctx context.Context, in *GetBalanceRequest, opts ...grpc.CallOption) (*GetBalanceResponse, error)
 	TopUpWallet(ctx context.Context, in *TopupRequest, opts ...grpc.CallOption) (*MutationResponse, error)
 	Transfer(ctx context.Context, in *TransferRequest, opts ...grpc.CallOption) (*MutationResponse, error)
 	GetTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (*GetTransactionsResponse, error)
+	SetWalletTier(ctx context.Context, in *SetWalletTierRequest, opts ...grpc.CallOption) (*MutationResponse, error)
+	GetWalletLimits(ctx context.Context, in *GetWalletLimitsRequest, opts ...grpc.CallOption) (*GetWalletLimitsResponse, error)
+	SetWalletLimits(ctx context.Context, in *SetWalletLimitsRequest, opts ...grpc.CallOption) (*MutationResponse, error)
+	WithdrawWallet(ctx context.Context, in *WithdrawRequest, opts ...grpc.CallOption) (*MutationResponse, error)
+	QuoteTransfer(ctx context.Context, in *QuoteTransferRequest, opts ...grpc.CallOption) (*FeeQuote, error)
+	QuoteWithdrawal(ctx context.Context, in *QuoteWithdrawalRequest, opts ...grpc.CallOption) (*FeeQuote, error)
+	GetFeeRules(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetFeeRulesResponse, error)
+	SaveFeeRule(ctx context.Context, in *SaveFeeRuleRequest, opts ...grpc.CallOption) (*MutationResponse, error)
+	ScheduleTransfer(ctx context.Context, in *ScheduleTransferRequest, opts ...grpc.CallOption) (*ScheduledTransfer, error)
+	ListScheduledTransfers(ctx context.Context, in *ListScheduledTransfersRequest, opts ...grpc.CallOption) (*ListScheduledTransfersResponse, error)
+	PauseScheduledTransfer(ctx context.Context, in *ScheduledTransferRequest, opts ...grpc.CallOption) (*ScheduledTransfer, error)
+	ResumeScheduledTransfer(ctx context.Context, in *ScheduledTransferRequest, opts ...grpc.CallOption) (*ScheduledTransfer, error)
+	CancelScheduledTransfer(ctx context.Context, in *ScheduledTransferRequest, opts ...grpc.CallOption) (*ScheduledTransfer, error)
+	RequestPayment(ctx context.Context, in *RequestPaymentRequest, opts ...grpc.CallOption) (*PaymentRequest, error)
+	CreatePaymentLink(ctx context.Context, in *CreatePaymentLinkRequest, opts ...grpc.CallOption) (*PaymentRequest, error)
+	GetPaymentRequest(ctx context.Context, in *GetPaymentRequestRequest, opts ...grpc.CallOption) (*PaymentRequest, error)
+	ListPaymentRequests(ctx context.Context, in *ListPaymentRequestsRequest, opts ...grpc.CallOption) (*ListPaymentRequestsResponse, error)
+	AcceptPaymentRequest(ctx context.Context, in *AcceptPaymentRequestRequest, opts ...grpc.CallOption) (*PaymentRequest, error)
+	DeclinePaymentRequest(ctx context.Context, in *DeclinePaymentRequestRequest, opts ...grpc.CallOption) (*PaymentRequest, error)
+	CancelPaymentRequest(ctx context.Context, in *CancelPaymentRequestRequest, opts ...grpc.CallOption) (*PaymentRequest, error)
+	BatchTransfer(ctx context.Context, in *BatchTransferRequest, opts ...grpc.CallOption) (*BatchTransferResponse, error)
+	GetTransferBatch(ctx context.Context, in *GetTransferBatchRequest, opts ...grpc.CallOption) (*GetTransferBatchResponse, error)
+	GetStatement(ctx context.Context, in *GetStatementRequest, opts ...grpc.CallOption) (*GetStatementResponse, error)
+	GetBalanceAsOf(ctx context.Context, in *GetBalanceAsOfRequest, opts ...grpc.CallOption) (*GetBalanceAsOfResponse, error)
+	GetBalanceHistory(ctx context.Context, in *GetBalanceHistoryRequest, opts ...grpc.CallOption) (*GetBalanceHistoryResponse, error)
+	VerifyBalance(ctx context.Context, in *GetBalanceRequest, opts ...grpc.CallOption) (*BalanceCheck, error)
+	Reconcile(ctx context.Context, in *ReconcileRequest, opts ...grpc.CallOption) (*ReconciliationReportResponse, error)
+	GetReconciliationReport(ctx context.Context, in *GetReconciliationReportRequest, opts ...grpc.CallOption) (*ReconciliationReportResponse, error)
+	ListBalanceAdjustments(ctx context.Context, in *ListBalanceAdjustmentsRequest, opts ...grpc.CallOption) (*ListBalanceAdjustmentsResponse, error)
+	ProposeBalanceAdjustment(ctx context.Context, in *ProposeBalanceAdjustmentRequest, opts ...grpc.CallOption) (*BalanceAdjustment, error)
+	ReviewBalanceAdjustment(ctx context.Context, in *ReviewBalanceAdjustmentRequest, opts ...grpc.CallOption) (*BalanceAdjustment, error)
+	TransferWalletOwnership(ctx context.Context, in *TransferWalletOwnershipRequest, opts ...grpc.CallOption) (*Wallet, error)
+	ListAuditEntries(ctx context.Context, in *ListAuditEntriesRequest, opts ...grpc.CallOption) (*ListAuditEntriesResponse, error)
+	FreezeWallet(ctx context.Context, in *FreezeWalletRequest, opts ...grpc.CallOption) (*Wallet, error)
+	UnfreezeWallet(ctx context.Context, in *UnfreezeWalletRequest, opts ...grpc.CallOption) (*Wallet, error)
+	// CloseWallet is final; the balance must be zero.
+	CloseWallet(ctx context.Context, in *CloseWalletRequest, opts ...grpc.CallOption) (*Wallet, error)
+	ListHeldTransfers(ctx context.Context, in *ListHeldTransfersRequest, opts ...grpc.CallOption) (*ListHeldTransfersResponse, error)
+	// ReviewHeldTransfer releases (executes) or rejects a transfer held by
+	// the risk checks.
+	ReviewHeldTransfer(ctx context.Context, in *ReviewHeldTransferRequest, opts ...grpc.CallOption) (*HeldTransfer, error)
+	GetRiskMetrics(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*RiskMetrics, error)
 }
 
 type walletServiceClient struct {
@@ -48,6 +95,15 @@ func (c *walletServiceClient) GetWallet(ctx context.Context, in *GetWalletReques
 	return out, nil
 }
 
+func (c *walletServiceClient) ListWallets(ctx context.Context, in *ListWalletsRequest, opts ...grpc.CallOption) (*ListWalletsResponse, error) {
+	out := new(ListWalletsResponse)
+	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/ListWallets", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *walletServiceClient) CreateWallet(ctx context.Context, in *CreateWalletRequest, opts ...grpc.CallOption) (*MutationResponse, error) {
 	out := new(MutationResponse)
 	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/CreateWallet", in, out, opts...)
@@ -57,225 +113,1463 @@ func (c *walletServiceClient) CreateWallet(ctx context.Context, in *CreateWallet
 	return out, nil
 }
 
-func (c *walletServiceClient) UpdateWallet(ctx context.Context, in *UpdateWalletRequest, opts ...grpc.CallOption) (*MutationResponse, error) {
-	out := new(MutationResponse)
-	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/UpdateWallet", in, out, opts...)
-	if err != nil {
+// Deprecated: Do not use.
+func (c *walletServiceClient) UpdateWallet(ctx context.Context, in *UpdateWalletRequest, opts ...grpc.CallOption) (*MutationResponse, error) {
+	out := new(MutationResponse)
+	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/UpdateWallet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) GetBalance(ctx context.Context, in *GetBalanceRequest, opts ...grpc.CallOption) (*GetBalanceResponse, error) {
+	out := new(GetBalanceResponse)
+	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/GetBalance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) TopUpWallet(ctx context.Context, in *TopupRequest, opts ...grpc.CallOption) (*MutationResponse, error) {
+	out := new(MutationResponse)
+	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/TopUpWallet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) Transfer(ctx context.Context, in *TransferRequest, opts ...grpc.CallOption) (*MutationResponse, error) {
+	out := new(MutationResponse)
+	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/Transfer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) GetTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (*GetTransactionsResponse, error) {
+	out := new(GetTransactionsResponse)
+	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/GetTransactions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) SetWalletTier(ctx context.Context, in *SetWalletTierRequest, opts ...grpc.CallOption) (*MutationResponse, error) {
+	out := new(MutationResponse)
+	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/SetWalletTier", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) GetWalletLimits(ctx context.Context, in *GetWalletLimitsRequest, opts ...grpc.CallOption) (*GetWalletLimitsResponse, error) {
+	out := new(GetWalletLimitsResponse)
+	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/GetWalletLimits", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) SetWalletLimits(ctx context.Context, in *SetWalletLimitsRequest, opts ...grpc.CallOption) (*MutationResponse, error) {
+	out := new(MutationResponse)
+	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/SetWalletLimits", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) WithdrawWallet(ctx context.Context, in *WithdrawRequest, opts ...grpc.CallOption) (*MutationResponse, error) {
+	out := new(MutationResponse)
+	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/WithdrawWallet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) QuoteTransfer(ctx context.Context, in *QuoteTransferRequest, opts ...grpc.CallOption) (*FeeQuote, error) {
+	out := new(FeeQuote)
+	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/QuoteTransfer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) QuoteWithdrawal(ctx context.Context, in *QuoteWithdrawalRequest, opts ...grpc.CallOption) (*FeeQuote, error) {
+	out := new(FeeQuote)
+	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/QuoteWithdrawal", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) GetFeeRules(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetFeeRulesResponse, error) {
+	out := new(GetFeeRulesResponse)
+	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/GetFeeRules", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) SaveFeeRule(ctx context.Context, in *SaveFeeRuleRequest, opts ...grpc.CallOption) (*MutationResponse, error) {
+	out := new(MutationResponse)
+	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/SaveFeeRule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ScheduleTransfer(ctx context.Context, in *ScheduleTransferRequest, opts ...grpc.CallOption) (*ScheduledTransfer, error) {
+	out := new(ScheduledTransfer)
+	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/ScheduleTransfer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ListScheduledTransfers(ctx context.Context, in *ListScheduledTransfersRequest, opts ...grpc.CallOption) (*ListScheduledTransfersResponse, error) {
+	out := new(ListScheduledTransfersResponse)
+	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/ListScheduledTransfers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) PauseScheduledTransfer(ctx context.Context, in *ScheduledTransferRequest, opts ...grpc.CallOption) (*ScheduledTransfer, error) {
+	out := new(ScheduledTransfer)
+	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/PauseScheduledTransfer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ResumeScheduledTransfer(ctx context.Context, in *ScheduledTransferRequest, opts ...grpc.CallOption) (*ScheduledTransfer, error) {
+	out := new(ScheduledTransfer)
+	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/ResumeScheduledTransfer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) CancelScheduledTransfer(ctx context.Context, in *ScheduledTransferRequest, opts ...grpc.CallOption) (*ScheduledTransfer, error) {
+	out := new(ScheduledTransfer)
+	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/CancelScheduledTransfer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) RequestPayment(ctx context.Context, in *RequestPaymentRequest, opts ...grpc.CallOption) (*PaymentRequest, error) {
+	out := new(PaymentRequest)
+	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/RequestPayment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) CreatePaymentLink(ctx context.Context, in *CreatePaymentLinkRequest, opts ...grpc.CallOption) (*PaymentRequest, error) {
+	out := new(PaymentRequest)
+	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/CreatePaymentLink", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) GetPaymentRequest(ctx context.Context, in *GetPaymentRequestRequest, opts ...grpc.CallOption) (*PaymentRequest, error) {
+	out := new(PaymentRequest)
+	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/GetPaymentRequest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ListPaymentRequests(ctx context.Context, in *ListPaymentRequestsRequest, opts ...grpc.CallOption) (*ListPaymentRequestsResponse, error) {
+	out := new(ListPaymentRequestsResponse)
+	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/ListPaymentRequests", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) AcceptPaymentRequest(ctx context.Context, in *AcceptPaymentRequestRequest, opts ...grpc.CallOption) (*PaymentRequest, error) {
+	out := new(PaymentRequest)
+	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/AcceptPaymentRequest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) DeclinePaymentRequest(ctx context.Context, in *DeclinePaymentRequestRequest, opts ...grpc.CallOption) (*PaymentRequest, error) {
+	out := new(PaymentRequest)
+	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/DeclinePaymentRequest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) CancelPaymentRequest(ctx context.Context, in *CancelPaymentRequestRequest, opts ...grpc.CallOption) (*PaymentRequest, error) {
+	out := new(PaymentRequest)
+	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/CancelPaymentRequest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) BatchTransfer(ctx context.Context, in *BatchTransferRequest, opts ...grpc.CallOption) (*BatchTransferResponse, error) {
+	out := new(BatchTransferResponse)
+	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/BatchTransfer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) GetTransferBatch(ctx context.Context, in *GetTransferBatchRequest, opts ...grpc.CallOption) (*GetTransferBatchResponse, error) {
+	out := new(GetTransferBatchResponse)
+	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/GetTransferBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) GetStatement(ctx context.Context, in *GetStatementRequest, opts ...grpc.CallOption) (*GetStatementResponse, error) {
+	out := new(GetStatementResponse)
+	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/GetStatement", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) GetBalanceAsOf(ctx context.Context, in *GetBalanceAsOfRequest, opts ...grpc.CallOption) (*GetBalanceAsOfResponse, error) {
+	out := new(GetBalanceAsOfResponse)
+	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/GetBalanceAsOf", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) GetBalanceHistory(ctx context.Context, in *GetBalanceHistoryRequest, opts ...grpc.CallOption) (*GetBalanceHistoryResponse, error) {
+	out := new(GetBalanceHistoryResponse)
+	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/GetBalanceHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) VerifyBalance(ctx context.Context, in *GetBalanceRequest, opts ...grpc.CallOption) (*BalanceCheck, error) {
+	out := new(BalanceCheck)
+	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/VerifyBalance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) Reconcile(ctx context.Context, in *ReconcileRequest, opts ...grpc.CallOption) (*ReconciliationReportResponse, error) {
+	out := new(ReconciliationReportResponse)
+	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/Reconcile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) GetReconciliationReport(ctx context.Context, in *GetReconciliationReportRequest, opts ...grpc.CallOption) (*ReconciliationReportResponse, error) {
+	out := new(ReconciliationReportResponse)
+	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/GetReconciliationReport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ListBalanceAdjustments(ctx context.Context, in *ListBalanceAdjustmentsRequest, opts ...grpc.CallOption) (*ListBalanceAdjustmentsResponse, error) {
+	out := new(ListBalanceAdjustmentsResponse)
+	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/ListBalanceAdjustments", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ProposeBalanceAdjustment(ctx context.Context, in *ProposeBalanceAdjustmentRequest, opts ...grpc.CallOption) (*BalanceAdjustment, error) {
+	out := new(BalanceAdjustment)
+	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/ProposeBalanceAdjustment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ReviewBalanceAdjustment(ctx context.Context, in *ReviewBalanceAdjustmentRequest, opts ...grpc.CallOption) (*BalanceAdjustment, error) {
+	out := new(BalanceAdjustment)
+	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/ReviewBalanceAdjustment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) TransferWalletOwnership(ctx context.Context, in *TransferWalletOwnershipRequest, opts ...grpc.CallOption) (*Wallet, error) {
+	out := new(Wallet)
+	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/TransferWalletOwnership", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ListAuditEntries(ctx context.Context, in *ListAuditEntriesRequest, opts ...grpc.CallOption) (*ListAuditEntriesResponse, error) {
+	out := new(ListAuditEntriesResponse)
+	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/ListAuditEntries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) FreezeWallet(ctx context.Context, in *FreezeWalletRequest, opts ...grpc.CallOption) (*Wallet, error) {
+	out := new(Wallet)
+	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/FreezeWallet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) UnfreezeWallet(ctx context.Context, in *UnfreezeWalletRequest, opts ...grpc.CallOption) (*Wallet, error) {
+	out := new(Wallet)
+	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/UnfreezeWallet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) CloseWallet(ctx context.Context, in *CloseWalletRequest, opts ...grpc.CallOption) (*Wallet, error) {
+	out := new(Wallet)
+	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/CloseWallet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ListHeldTransfers(ctx context.Context, in *ListHeldTransfersRequest, opts ...grpc.CallOption) (*ListHeldTransfersResponse, error) {
+	out := new(ListHeldTransfersResponse)
+	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/ListHeldTransfers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ReviewHeldTransfer(ctx context.Context, in *ReviewHeldTransferRequest, opts ...grpc.CallOption) (*HeldTransfer, error) {
+	out := new(HeldTransfer)
+	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/ReviewHeldTransfer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) GetRiskMetrics(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*RiskMetrics, error) {
+	out := new(RiskMetrics)
+	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/GetRiskMetrics", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WalletServiceServer is the server API for WalletService service.
+// All implementations must embed UnimplementedWalletServiceServer
+// for forward compatibility
+type WalletServiceServer interface {
+	GetWallet(context.Context, *GetWalletRequest) (*GetWalletResponse, error)
+	ListWallets(context.Context, *ListWalletsRequest) (*ListWalletsResponse, error)
+	CreateWallet(context.Context, *CreateWalletRequest) (*MutationResponse, error)
+	// Deprecated: Do not use.
+	// Deprecated: balances only change through transactions and approved
+	// balance adjustments, and owners through TransferWalletOwnership.
+	UpdateWallet(context.Context, *UpdateWalletRequest) (*MutationResponse, error)
+	GetBalance(context.Context, *GetBalanceRequest) (*GetBalanceResponse, error)
+	TopUpWallet(context.Context, *TopupRequest) (*MutationResponse, error)
+	Transfer(context.Context, *TransferRequest) (*MutationResponse, error)
+	GetTransactions(context.Context, *GetTransactionsRequest) (*GetTransactionsResponse, error)
+	SetWalletTier(context.Context, *SetWalletTierRequest) (*MutationResponse, error)
+	GetWalletLimits(context.Context, *GetWalletLimitsRequest) (*GetWalletLimitsResponse, error)
+	SetWalletLimits(context.Context, *SetWalletLimitsRequest) (*MutationResponse, error)
+	WithdrawWallet(context.Context, *WithdrawRequest) (*MutationResponse, error)
+	QuoteTransfer(context.Context, *QuoteTransferRequest) (*FeeQuote, error)
+	QuoteWithdrawal(context.Context, *QuoteWithdrawalRequest) (*FeeQuote, error)
+	GetFeeRules(context.Context, *emptypb.Empty) (*GetFeeRulesResponse, error)
+	SaveFeeRule(context.Context, *SaveFeeRuleRequest) (*MutationResponse, error)
+	ScheduleTransfer(context.Context, *ScheduleTransferRequest) (*ScheduledTransfer, error)
+	ListScheduledTransfers(context.Context, *ListScheduledTransfersRequest) (*ListScheduledTransfersResponse, error)
+	PauseScheduledTransfer(context.Context, *ScheduledTransferRequest) (*ScheduledTransfer, error)
+	ResumeScheduledTransfer(context.Context, *ScheduledTransferRequest) (*ScheduledTransfer, error)
+	CancelScheduledTransfer(context.Context, *ScheduledTransferRequest) (*ScheduledTransfer, error)
+	RequestPayment(context.Context, *RequestPaymentRequest) (*PaymentRequest, error)
+	CreatePaymentLink(context.Context, *CreatePaymentLinkRequest) (*PaymentRequest, error)
+	GetPaymentRequest(context.Context, *GetPaymentRequestRequest) (*PaymentRequest, error)
+	ListPaymentRequests(context.Context, *ListPaymentRequestsRequest) (*ListPaymentRequestsResponse, error)
+	AcceptPaymentRequest(context.Context, *AcceptPaymentRequestRequest) (*PaymentRequest, error)
+	DeclinePaymentRequest(context.Context, *DeclinePaymentRequestRequest) (*PaymentRequest, error)
+	CancelPaymentRequest(context.Context, *CancelPaymentRequestRequest) (*PaymentRequest, error)
+	BatchTransfer(context.Context, *BatchTransferRequest) (*BatchTransferResponse, error)
+	GetTransferBatch(context.Context, *GetTransferBatchRequest) (*GetTransferBatchResponse, error)
+	GetStatement(context.Context, *GetStatementRequest) (*GetStatementResponse, error)
+	GetBalanceAsOf(context.Context, *GetBalanceAsOfRequest) (*GetBalanceAsOfResponse, error)
+	GetBalanceHistory(context.Context, *GetBalanceHistoryRequest) (*GetBalanceHistoryResponse, error)
+	VerifyBalance(context.Context, *GetBalanceRequest) (*BalanceCheck, error)
+	Reconcile(context.Context, *ReconcileRequest) (*ReconciliationReportResponse, error)
+	GetReconciliationReport(context.Context, *GetReconciliationReportRequest) (*ReconciliationReportResponse, error)
+	ListBalanceAdjustments(context.Context, *ListBalanceAdjustmentsRequest) (*ListBalanceAdjustmentsResponse, error)
+	ProposeBalanceAdjustment(context.Context, *ProposeBalanceAdjustmentRequest) (*BalanceAdjustment, error)
+	ReviewBalanceAdjustment(context.Context, *ReviewBalanceAdjustmentRequest) (*BalanceAdjustment, error)
+	TransferWalletOwnership(context.Context, *TransferWalletOwnershipRequest) (*Wallet, error)
+	ListAuditEntries(context.Context, *ListAuditEntriesRequest) (*ListAuditEntriesResponse, error)
+	FreezeWallet(context.Context, *FreezeWalletRequest) (*Wallet, error)
+	UnfreezeWallet(context.Context, *UnfreezeWalletRequest) (*Wallet, error)
+	// CloseWallet is final; the balance must be zero.
+	CloseWallet(context.Context, *CloseWalletRequest) (*Wallet, error)
+	ListHeldTransfers(context.Context, *ListHeldTransfersRequest) (*ListHeldTransfersResponse, error)
+	// ReviewHeldTransfer releases (executes) or rejects a transfer held by
+	// the risk checks.
+	ReviewHeldTransfer(context.Context, *ReviewHeldTransferRequest) (*HeldTransfer, error)
+	GetRiskMetrics(context.Context, *emptypb.Empty) (*RiskMetrics, error)
+	mustEmbedUnimplementedWalletServiceServer()
+}
+
+// UnimplementedWalletServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedWalletServiceServer struct {
+}
+
+func (UnimplementedWalletServiceServer) GetWallet(context.Context, *GetWalletRequest) (*GetWalletResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWallet not implemented")
+}
+func (UnimplementedWalletServiceServer) ListWallets(context.Context, *ListWalletsRequest) (*ListWalletsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWallets not implemented")
+}
+func (UnimplementedWalletServiceServer) CreateWallet(context.Context, *CreateWalletRequest) (*MutationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWallet not implemented")
+}
+func (UnimplementedWalletServiceServer) UpdateWallet(context.Context, *UpdateWalletRequest) (*MutationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateWallet not implemented")
+}
+func (UnimplementedWalletServiceServer) GetBalance(context.Context, *GetBalanceRequest) (*GetBalanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBalance not implemented")
+}
+func (UnimplementedWalletServiceServer) TopUpWallet(context.Context, *TopupRequest) (*MutationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TopUpWallet not implemented")
+}
+func (UnimplementedWalletServiceServer) Transfer(context.Context, *TransferRequest) (*MutationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Transfer not implemented")
+}
+func (UnimplementedWalletServiceServer) GetTransactions(context.Context, *GetTransactionsRequest) (*GetTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransactions not implemented")
+}
+func (UnimplementedWalletServiceServer) SetWalletTier(context.Context, *SetWalletTierRequest) (*MutationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetWalletTier not implemented")
+}
+func (UnimplementedWalletServiceServer) GetWalletLimits(context.Context, *GetWalletLimitsRequest) (*GetWalletLimitsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWalletLimits not implemented")
+}
+func (UnimplementedWalletServiceServer) SetWalletLimits(context.Context, *SetWalletLimitsRequest) (*MutationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetWalletLimits not implemented")
+}
+func (UnimplementedWalletServiceServer) WithdrawWallet(context.Context, *WithdrawRequest) (*MutationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WithdrawWallet not implemented")
+}
+func (UnimplementedWalletServiceServer) QuoteTransfer(context.Context, *QuoteTransferRequest) (*FeeQuote, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QuoteTransfer not implemented")
+}
+func (UnimplementedWalletServiceServer) QuoteWithdrawal(context.Context, *QuoteWithdrawalRequest) (*FeeQuote, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QuoteWithdrawal not implemented")
+}
+func (UnimplementedWalletServiceServer) GetFeeRules(context.Context, *emptypb.Empty) (*GetFeeRulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFeeRules not implemented")
+}
+func (UnimplementedWalletServiceServer) SaveFeeRule(context.Context, *SaveFeeRuleRequest) (*MutationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveFeeRule not implemented")
+}
+func (UnimplementedWalletServiceServer) ScheduleTransfer(context.Context, *ScheduleTransferRequest) (*ScheduledTransfer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ScheduleTransfer not implemented")
+}
+func (UnimplementedWalletServiceServer) ListScheduledTransfers(context.Context, *ListScheduledTransfersRequest) (*ListScheduledTransfersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListScheduledTransfers not implemented")
+}
+func (UnimplementedWalletServiceServer) PauseScheduledTransfer(context.Context, *ScheduledTransferRequest) (*ScheduledTransfer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseScheduledTransfer not implemented")
+}
+func (UnimplementedWalletServiceServer) ResumeScheduledTransfer(context.Context, *ScheduledTransferRequest) (*ScheduledTransfer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeScheduledTransfer not implemented")
+}
+func (UnimplementedWalletServiceServer) CancelScheduledTransfer(context.Context, *ScheduledTransferRequest) (*ScheduledTransfer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelScheduledTransfer not implemented")
+}
+func (UnimplementedWalletServiceServer) RequestPayment(context.Context, *RequestPaymentRequest) (*PaymentRequest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestPayment not implemented")
+}
+func (UnimplementedWalletServiceServer) CreatePaymentLink(context.Context, *CreatePaymentLinkRequest) (*PaymentRequest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePaymentLink not implemented")
+}
+func (UnimplementedWalletServiceServer) GetPaymentRequest(context.Context, *GetPaymentRequestRequest) (*PaymentRequest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPaymentRequest not implemented")
+}
+func (UnimplementedWalletServiceServer) ListPaymentRequests(context.Context, *ListPaymentRequestsRequest) (*ListPaymentRequestsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPaymentRequests not implemented")
+}
+func (UnimplementedWalletServiceServer) AcceptPaymentRequest(context.Context, *AcceptPaymentRequestRequest) (*PaymentRequest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptPaymentRequest not implemented")
+}
+func (UnimplementedWalletServiceServer) DeclinePaymentRequest(context.Context, *DeclinePaymentRequestRequest) (*PaymentRequest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeclinePaymentRequest not implemented")
+}
+func (UnimplementedWalletServiceServer) CancelPaymentRequest(context.Context, *CancelPaymentRequestRequest) (*PaymentRequest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelPaymentRequest not implemented")
+}
+func (UnimplementedWalletServiceServer) BatchTransfer(context.Context, *BatchTransferRequest) (*BatchTransferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchTransfer not implemented")
+}
+func (UnimplementedWalletServiceServer) GetTransferBatch(context.Context, *GetTransferBatchRequest) (*GetTransferBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransferBatch not implemented")
+}
+func (UnimplementedWalletServiceServer) GetStatement(context.Context, *GetStatementRequest) (*GetStatementResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatement not implemented")
+}
+func (UnimplementedWalletServiceServer) GetBalanceAsOf(context.Context, *GetBalanceAsOfRequest) (*GetBalanceAsOfResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBalanceAsOf not implemented")
+}
+func (UnimplementedWalletServiceServer) GetBalanceHistory(context.Context, *GetBalanceHistoryRequest) (*GetBalanceHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBalanceHistory not implemented")
+}
+func (UnimplementedWalletServiceServer) VerifyBalance(context.Context, *GetBalanceRequest) (*BalanceCheck, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyBalance not implemented")
+}
+func (UnimplementedWalletServiceServer) Reconcile(context.Context, *ReconcileRequest) (*ReconciliationReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reconcile not implemented")
+}
+func (UnimplementedWalletServiceServer) GetReconciliationReport(context.Context, *GetReconciliationReportRequest) (*ReconciliationReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReconciliationReport not implemented")
+}
+func (UnimplementedWalletServiceServer) ListBalanceAdjustments(context.Context, *ListBalanceAdjustmentsRequest) (*ListBalanceAdjustmentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBalanceAdjustments not implemented")
+}
+func (UnimplementedWalletServiceServer) ProposeBalanceAdjustment(context.Context, *ProposeBalanceAdjustmentRequest) (*BalanceAdjustment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProposeBalanceAdjustment not implemented")
+}
+func (UnimplementedWalletServiceServer) ReviewBalanceAdjustment(context.Context, *ReviewBalanceAdjustmentRequest) (*BalanceAdjustment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReviewBalanceAdjustment not implemented")
+}
+func (UnimplementedWalletServiceServer) TransferWalletOwnership(context.Context, *TransferWalletOwnershipRequest) (*Wallet, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransferWalletOwnership not implemented")
+}
+func (UnimplementedWalletServiceServer) ListAuditEntries(context.Context, *ListAuditEntriesRequest) (*ListAuditEntriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditEntries not implemented")
+}
+func (UnimplementedWalletServiceServer) FreezeWallet(context.Context, *FreezeWalletRequest) (*Wallet, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FreezeWallet not implemented")
+}
+func (UnimplementedWalletServiceServer) UnfreezeWallet(context.Context, *UnfreezeWalletRequest) (*Wallet, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnfreezeWallet not implemented")
+}
+func (UnimplementedWalletServiceServer) CloseWallet(context.Context, *CloseWalletRequest) (*Wallet, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloseWallet not implemented")
+}
+func (UnimplementedWalletServiceServer) ListHeldTransfers(context.Context, *ListHeldTransfersRequest) (*ListHeldTransfersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListHeldTransfers not implemented")
+}
+func (UnimplementedWalletServiceServer) ReviewHeldTransfer(context.Context, *ReviewHeldTransferRequest) (*HeldTransfer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReviewHeldTransfer not implemented")
+}
+func (UnimplementedWalletServiceServer) GetRiskMetrics(context.Context, *emptypb.Empty) (*RiskMetrics, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRiskMetrics not implemented")
+}
+func (UnimplementedWalletServiceServer) mustEmbedUnimplementedWalletServiceServer() {}
+
+// UnsafeWalletServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WalletServiceServer will
+// result in compilation errors.
+type UnsafeWalletServiceServer interface {
+	mustEmbedUnimplementedWalletServiceServer()
+}
+
+func RegisterWalletServiceServer(s grpc.ServiceRegistrar, srv WalletServiceServer) {
+	s.RegisterService(&WalletService_ServiceDesc, srv)
+}
+
+func _WalletService_GetWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWalletRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetWallet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.wallet.v1.WalletService/GetWallet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetWallet(ctx, req.(*GetWalletRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ListWallets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWalletsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ListWallets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.wallet.v1.WalletService/ListWallets",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ListWallets(ctx, req.(*ListWalletsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_CreateWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWalletRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).CreateWallet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.wallet.v1.WalletService/CreateWallet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).CreateWallet(ctx, req.(*CreateWalletRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_UpdateWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateWalletRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).UpdateWallet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.wallet.v1.WalletService/UpdateWallet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).UpdateWallet(ctx, req.(*UpdateWalletRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.wallet.v1.WalletService/GetBalance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetBalance(ctx, req.(*GetBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_TopUpWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TopupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).TopUpWallet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.wallet.v1.WalletService/TopUpWallet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).TopUpWallet(ctx, req.(*TopupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_Transfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).Transfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.wallet.v1.WalletService/Transfer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).Transfer(ctx, req.(*TransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.wallet.v1.WalletService/GetTransactions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetTransactions(ctx, req.(*GetTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_SetWalletTier_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetWalletTierRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).SetWalletTier(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.wallet.v1.WalletService/SetWalletTier",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).SetWalletTier(ctx, req.(*SetWalletTierRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetWalletLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWalletLimitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetWalletLimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.wallet.v1.WalletService/GetWalletLimits",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetWalletLimits(ctx, req.(*GetWalletLimitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_SetWalletLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetWalletLimitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).SetWalletLimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.wallet.v1.WalletService/SetWalletLimits",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).SetWalletLimits(ctx, req.(*SetWalletLimitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_WithdrawWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WithdrawRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).WithdrawWallet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.wallet.v1.WalletService/WithdrawWallet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).WithdrawWallet(ctx, req.(*WithdrawRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_QuoteTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuoteTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).QuoteTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.wallet.v1.WalletService/QuoteTransfer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).QuoteTransfer(ctx, req.(*QuoteTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_QuoteWithdrawal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuoteWithdrawalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).QuoteWithdrawal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.wallet.v1.WalletService/QuoteWithdrawal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).QuoteWithdrawal(ctx, req.(*QuoteWithdrawalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetFeeRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetFeeRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.wallet.v1.WalletService/GetFeeRules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetFeeRules(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_SaveFeeRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveFeeRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).SaveFeeRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.wallet.v1.WalletService/SaveFeeRule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).SaveFeeRule(ctx, req.(*SaveFeeRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ScheduleTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ScheduleTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.wallet.v1.WalletService/ScheduleTransfer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ScheduleTransfer(ctx, req.(*ScheduleTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ListScheduledTransfers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListScheduledTransfersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ListScheduledTransfers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.wallet.v1.WalletService/ListScheduledTransfers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ListScheduledTransfers(ctx, req.(*ListScheduledTransfersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_PauseScheduledTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduledTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).PauseScheduledTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.wallet.v1.WalletService/PauseScheduledTransfer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).PauseScheduledTransfer(ctx, req.(*ScheduledTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ResumeScheduledTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduledTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ResumeScheduledTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.wallet.v1.WalletService/ResumeScheduledTransfer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ResumeScheduledTransfer(ctx, req.(*ScheduledTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_CancelScheduledTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduledTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).CancelScheduledTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.wallet.v1.WalletService/CancelScheduledTransfer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).CancelScheduledTransfer(ctx, req.(*ScheduledTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_RequestPayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestPaymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).RequestPayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.wallet.v1.WalletService/RequestPayment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).RequestPayment(ctx, req.(*RequestPaymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_CreatePaymentLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePaymentLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).CreatePaymentLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.wallet.v1.WalletService/CreatePaymentLink",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).CreatePaymentLink(ctx, req.(*CreatePaymentLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetPaymentRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPaymentRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetPaymentRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.wallet.v1.WalletService/GetPaymentRequest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetPaymentRequest(ctx, req.(*GetPaymentRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ListPaymentRequests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPaymentRequestsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ListPaymentRequests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.wallet.v1.WalletService/ListPaymentRequests",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ListPaymentRequests(ctx, req.(*ListPaymentRequestsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_AcceptPaymentRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceptPaymentRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).AcceptPaymentRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.wallet.v1.WalletService/AcceptPaymentRequest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).AcceptPaymentRequest(ctx, req.(*AcceptPaymentRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_DeclinePaymentRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeclinePaymentRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).DeclinePaymentRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.wallet.v1.WalletService/DeclinePaymentRequest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).DeclinePaymentRequest(ctx, req.(*DeclinePaymentRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_CancelPaymentRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelPaymentRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).CancelPaymentRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.wallet.v1.WalletService/CancelPaymentRequest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).CancelPaymentRequest(ctx, req.(*CancelPaymentRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_BatchTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).BatchTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.wallet.v1.WalletService/BatchTransfer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).BatchTransfer(ctx, req.(*BatchTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetTransferBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransferBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetTransferBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.wallet.v1.WalletService/GetTransferBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetTransferBatch(ctx, req.(*GetTransferBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetStatement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetStatement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.wallet.v1.WalletService/GetStatement",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetStatement(ctx, req.(*GetStatementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetBalanceAsOf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBalanceAsOfRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetBalanceAsOf(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.wallet.v1.WalletService/GetBalanceAsOf",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetBalanceAsOf(ctx, req.(*GetBalanceAsOfRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetBalanceHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBalanceHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetBalanceHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.wallet.v1.WalletService/GetBalanceHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetBalanceHistory(ctx, req.(*GetBalanceHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_VerifyBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBalanceRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(WalletServiceServer).VerifyBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.wallet.v1.WalletService/VerifyBalance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).VerifyBalance(ctx, req.(*GetBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *walletServiceClient) GetBalance(ctx context.Context, in *GetBalanceRequest, opts ...grpc.CallOption) (*GetBalanceResponse, error) {
-	out := new(GetBalanceResponse)
-	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/GetBalance", in, out, opts...)
-	if err != nil {
+func _WalletService_Reconcile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReconcileRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(WalletServiceServer).Reconcile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.wallet.v1.WalletService/Reconcile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).Reconcile(ctx, req.(*ReconcileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *walletServiceClient) TopUpWallet(ctx context.Context, in *TopupRequest, opts ...grpc.CallOption) (*MutationResponse, error) {
-	out := new(MutationResponse)
-	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/TopUpWallet", in, out, opts...)
-	if err != nil {
+func _WalletService_GetReconciliationReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReconciliationReportRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetReconciliationReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.wallet.v1.WalletService/GetReconciliationReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetReconciliationReport(ctx, req.(*GetReconciliationReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *walletServiceClient) Transfer(ctx context.Context, in *TransferRequest, opts ...grpc.CallOption) (*MutationResponse, error) {
-	out := new(MutationResponse)
-	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/Transfer", in, out, opts...)
-	if err != nil {
+func _WalletService_ListBalanceAdjustments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBalanceAdjustmentsRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ListBalanceAdjustments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.wallet.v1.WalletService/ListBalanceAdjustments",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ListBalanceAdjustments(ctx, req.(*ListBalanceAdjustmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *walletServiceClient) GetTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (*GetTransactionsResponse, error) {
-	out := new(GetTransactionsResponse)
-	err := c.cc.Invoke(ctx, "/proto.wallet.v1.WalletService/GetTransactions", in, out, opts...)
-	if err != nil {
+func _WalletService_ProposeBalanceAdjustment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProposeBalanceAdjustmentRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-// WalletServiceServer is the server API for WalletService service.
-// All implementations must embed UnimplementedWalletServiceServer
-// for forward compatibility
-type WalletServiceServer interface {
-	GetWallet(context.Context, *GetWalletRequest) (*GetWalletResponse, error)
-	CreateWallet(context.Context, *CreateWalletRequest) (*MutationResponse, error)
-	UpdateWallet(context.Context, *UpdateWalletRequest) (*MutationResponse, error)
-	GetBalance(context.Context, *GetBalanceRequest) (*GetBalanceResponse, error)
-	TopUpWallet(context.Context, *TopupRequest) (*MutationResponse, error)
-	Transfer(context.Context, *TransferRequest) (*MutationResponse, error)
-	GetTransactions(context.Context, *GetTransactionsRequest) (*GetTransactionsResponse, error)
-	mustEmbedUnimplementedWalletServiceServer()
-}
-
-// UnimplementedWalletServiceServer must be embedded to have forward compatible implementations.
-type UnimplementedWalletServiceServer struct {
-}
-
-func (UnimplementedWalletServiceServer) GetWallet(context.Context, *GetWalletRequest) (*GetWalletResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetWallet not implemented")
-}
-func (UnimplementedWalletServiceServer) CreateWallet(context.Context, *CreateWalletRequest) (*MutationResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateWallet not implemented")
-}
-func (UnimplementedWalletServiceServer) UpdateWallet(context.Context, *UpdateWalletRequest) (*MutationResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateWallet not implemented")
-}
-func (UnimplementedWalletServiceServer) GetBalance(context.Context, *GetBalanceRequest) (*GetBalanceResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetBalance not implemented")
-}
-func (UnimplementedWalletServiceServer) TopUpWallet(context.Context, *TopupRequest) (*MutationResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method TopUpWallet not implemented")
-}
-func (UnimplementedWalletServiceServer) Transfer(context.Context, *TransferRequest) (*MutationResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Transfer not implemented")
-}
-func (UnimplementedWalletServiceServer) GetTransactions(context.Context, *GetTransactionsRequest) (*GetTransactionsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetTransactions not implemented")
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ProposeBalanceAdjustment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.wallet.v1.WalletService/ProposeBalanceAdjustment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ProposeBalanceAdjustment(ctx, req.(*ProposeBalanceAdjustmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedWalletServiceServer) mustEmbedUnimplementedWalletServiceServer() {}
 
-// UnsafeWalletServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to WalletServiceServer will
-// result in compilation errors.
-type UnsafeWalletServiceServer interface {
-	mustEmbedUnimplementedWalletServiceServer()
+func _WalletService_ReviewBalanceAdjustment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewBalanceAdjustmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ReviewBalanceAdjustment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.wallet.v1.WalletService/ReviewBalanceAdjustment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ReviewBalanceAdjustment(ctx, req.(*ReviewBalanceAdjustmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterWalletServiceServer(s grpc.ServiceRegistrar, srv WalletServiceServer) {
-	s.RegisterService(&WalletService_ServiceDesc, srv)
+func _WalletService_TransferWalletOwnership_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferWalletOwnershipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).TransferWalletOwnership(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.wallet.v1.WalletService/TransferWalletOwnership",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).TransferWalletOwnership(ctx, req.(*TransferWalletOwnershipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _WalletService_GetWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetWalletRequest)
+func _WalletService_ListAuditEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditEntriesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WalletServiceServer).GetWallet(ctx, in)
+		return srv.(WalletServiceServer).ListAuditEntries(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/proto.wallet.v1.WalletService/GetWallet",
+		FullMethod: "/proto.wallet.v1.WalletService/ListAuditEntries",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletServiceServer).GetWallet(ctx, req.(*GetWalletRequest))
+		return srv.(WalletServiceServer).ListAuditEntries(ctx, req.(*ListAuditEntriesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WalletService_CreateWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateWalletRequest)
+func _WalletService_FreezeWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FreezeWalletRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WalletServiceServer).CreateWallet(ctx, in)
+		return srv.(WalletServiceServer).FreezeWallet(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/proto.wallet.v1.WalletService/CreateWallet",
+		FullMethod: "/proto.wallet.v1.WalletService/FreezeWallet",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletServiceServer).CreateWallet(ctx, req.(*CreateWalletRequest))
+		return srv.(WalletServiceServer).FreezeWallet(ctx, req.(*FreezeWalletRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WalletService_UpdateWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateWalletRequest)
+func _WalletService_UnfreezeWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnfreezeWalletRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WalletServiceServer).UpdateWallet(ctx, in)
+		return srv.(WalletServiceServer).UnfreezeWallet(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/proto.wallet.v1.WalletService/UpdateWallet",
+		FullMethod: "/proto.wallet.v1.WalletService/UnfreezeWallet",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletServiceServer).UpdateWallet(ctx, req.(*UpdateWalletRequest))
+		return srv.(WalletServiceServer).UnfreezeWallet(ctx, req.(*UnfreezeWalletRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WalletService_GetBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetBalanceRequest)
+func _WalletService_CloseWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloseWalletRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WalletServiceServer).GetBalance(ctx, in)
+		return srv.(WalletServiceServer).CloseWallet(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/proto.wallet.v1.WalletService/GetBalance",
+		FullMethod: "/proto.wallet.v1.WalletService/CloseWallet",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletServiceServer).GetBalance(ctx, req.(*GetBalanceRequest))
+		return srv.(WalletServiceServer).CloseWallet(ctx, req.(*CloseWalletRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WalletService_TopUpWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TopupRequest)
+func _WalletService_ListHeldTransfers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListHeldTransfersRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WalletServiceServer).TopUpWallet(ctx, in)
+		return srv.(WalletServiceServer).ListHeldTransfers(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/proto.wallet.v1.WalletService/TopUpWallet",
+		FullMethod: "/proto.wallet.v1.WalletService/ListHeldTransfers",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletServiceServer).TopUpWallet(ctx, req.(*TopupRequest))
+		return srv.(WalletServiceServer).ListHeldTransfers(ctx, req.(*ListHeldTransfersRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WalletService_Transfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TransferRequest)
+func _WalletService_ReviewHeldTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewHeldTransferRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WalletServiceServer).Transfer(ctx, in)
+		return srv.(WalletServiceServer).ReviewHeldTransfer(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/proto.wallet.v1.WalletService/Transfer",
+		FullMethod: "/proto.wallet.v1.WalletService/ReviewHeldTransfer",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletServiceServer).Transfer(ctx, req.(*TransferRequest))
+		return srv.(WalletServiceServer).ReviewHeldTransfer(ctx, req.(*ReviewHeldTransferRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WalletService_GetTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetTransactionsRequest)
+func _WalletService_GetRiskMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WalletServiceServer).GetTransactions(ctx, in)
+		return srv.(WalletServiceServer).GetRiskMetrics(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/proto.wallet.v1.WalletService/GetTransactions",
+		FullMethod: "/proto.wallet.v1.WalletService/GetRiskMetrics",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletServiceServer).GetTransactions(ctx, req.(*GetTransactionsRequest))
+		return srv.(WalletServiceServer).GetRiskMetrics(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -291,6 +1585,10 @@ var WalletService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetWallet",
 			Handler:    _WalletService_GetWallet_Handler,
 		},
+		{
+			MethodName: "ListWallets",
+			Handler:    _WalletService_ListWallets_Handler,
+		},
 		{
 			MethodName: "CreateWallet",
 			Handler:    _WalletService_CreateWallet_Handler,
@@ -315,6 +1613,162 @@ var WalletService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetTransactions",
 			Handler:    _WalletService_GetTransactions_Handler,
 		},
+		{
+			MethodName: "SetWalletTier",
+			Handler:    _WalletService_SetWalletTier_Handler,
+		},
+		{
+			MethodName: "GetWalletLimits",
+			Handler:    _WalletService_GetWalletLimits_Handler,
+		},
+		{
+			MethodName: "SetWalletLimits",
+			Handler:    _WalletService_SetWalletLimits_Handler,
+		},
+		{
+			MethodName: "WithdrawWallet",
+			Handler:    _WalletService_WithdrawWallet_Handler,
+		},
+		{
+			MethodName: "QuoteTransfer",
+			Handler:    _WalletService_QuoteTransfer_Handler,
+		},
+		{
+			MethodName: "QuoteWithdrawal",
+			Handler:    _WalletService_QuoteWithdrawal_Handler,
+		},
+		{
+			MethodName: "GetFeeRules",
+			Handler:    _WalletService_GetFeeRules_Handler,
+		},
+		{
+			MethodName: "SaveFeeRule",
+			Handler:    _WalletService_SaveFeeRule_Handler,
+		},
+		{
+			MethodName: "ScheduleTransfer",
+			Handler:    _WalletService_ScheduleTransfer_Handler,
+		},
+		{
+			MethodName: "ListScheduledTransfers",
+			Handler:    _WalletService_ListScheduledTransfers_Handler,
+		},
+		{
+			MethodName: "PauseScheduledTransfer",
+			Handler:    _WalletService_PauseScheduledTransfer_Handler,
+		},
+		{
+			MethodName: "ResumeScheduledTransfer",
+			Handler:    _WalletService_ResumeScheduledTransfer_Handler,
+		},
+		{
+			MethodName: "CancelScheduledTransfer",
+			Handler:    _WalletService_CancelScheduledTransfer_Handler,
+		},
+		{
+			MethodName: "RequestPayment",
+			Handler:    _WalletService_RequestPayment_Handler,
+		},
+		{
+			MethodName: "CreatePaymentLink",
+			Handler:    _WalletService_CreatePaymentLink_Handler,
+		},
+		{
+			MethodName: "GetPaymentRequest",
+			Handler:    _WalletService_GetPaymentRequest_Handler,
+		},
+		{
+			MethodName: "ListPaymentRequests",
+			Handler:    _WalletService_ListPaymentRequests_Handler,
+		},
+		{
+			MethodName: "AcceptPaymentRequest",
+			Handler:    _WalletService_AcceptPaymentRequest_Handler,
+		},
+		{
+			MethodName: "DeclinePaymentRequest",
+			Handler:    _WalletService_DeclinePaymentRequest_Handler,
+		},
+		{
+			MethodName: "CancelPaymentRequest",
+			Handler:    _WalletService_CancelPaymentRequest_Handler,
+		},
+		{
+			MethodName: "BatchTransfer",
+			Handler:    _WalletService_BatchTransfer_Handler,
+		},
+		{
+			MethodName: "GetTransferBatch",
+			Handler:    _WalletService_GetTransferBatch_Handler,
+		},
+		{
+			MethodName: "GetStatement",
+			Handler:    _WalletService_GetStatement_Handler,
+		},
+		{
+			MethodName: "GetBalanceAsOf",
+			Handler:    _WalletService_GetBalanceAsOf_Handler,
+		},
+		{
+			MethodName: "GetBalanceHistory",
+			Handler:    _WalletService_GetBalanceHistory_Handler,
+		},
+		{
+			MethodName: "VerifyBalance",
+			Handler:    _WalletService_VerifyBalance_Handler,
+		},
+		{
+			MethodName: "Reconcile",
+			Handler:    _WalletService_Reconcile_Handler,
+		},
+		{
+			MethodName: "GetReconciliationReport",
+			Handler:    _WalletService_GetReconciliationReport_Handler,
+		},
+		{
+			MethodName: "ListBalanceAdjustments",
+			Handler:    _WalletService_ListBalanceAdjustments_Handler,
+		},
+		{
+			MethodName: "ProposeBalanceAdjustment",
+			Handler:    _WalletService_ProposeBalanceAdjustment_Handler,
+		},
+		{
+			MethodName: "ReviewBalanceAdjustment",
+			Handler:    _WalletService_ReviewBalanceAdjustment_Handler,
+		},
+		{
+			MethodName: "TransferWalletOwnership",
+			Handler:    _WalletService_TransferWalletOwnership_Handler,
+		},
+		{
+			MethodName: "ListAuditEntries",
+			Handler:    _WalletService_ListAuditEntries_Handler,
+		},
+		{
+			MethodName: "FreezeWallet",
+			Handler:    _WalletService_FreezeWallet_Handler,
+		},
+		{
+			MethodName: "UnfreezeWallet",
+			Handler:    _WalletService_UnfreezeWallet_Handler,
+		},
+		{
+			MethodName: "CloseWallet",
+			Handler:    _WalletService_CloseWallet_Handler,
+		},
+		{
+			MethodName: "ListHeldTransfers",
+			Handler:    _WalletService_ListHeldTransfers_Handler,
+		},
+		{
+			MethodName: "ReviewHeldTransfer",
+			Handler:    _WalletService_ReviewHeldTransfer_Handler,
+		},
+		{
+			MethodName: "GetRiskMetrics",
+			Handler:    _WalletService_GetRiskMetrics_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/wallet/v1/wallet.proto",
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/susilo001/simple-wallet-system/wallet/reconciliation"
+	"github.com/susilo001/simple-wallet-system/wallet/service"
+)
+
+// runReconcile implements the reconcile subcommand:
+//
+//	wallet reconcile [-format json|csv] [-propose]
+//
+// It writes the report to stdout and returns exit status 1 when a wallet
+// does not match its history or money is not conserved.
+func runReconcile(reconciliationService service.IReconciliationService, args []string) int {
+	flags := flag.NewFlagSet("reconcile", flag.ExitOnError)
+	format := flags.String("format", reconciliation.FormatJSON, "report format: json or csv")
+	propose := flags.Bool("propose", false, "open a pending adjustment for each discrepancy")
+	_ = flags.Parse(args)
+
+	report, err := reconciliationService.Reconcile(context.Background(), *propose)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	if _, err := reconciliation.Render(os.Stdout, report, *format); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	if report.Run.DiscrepancyCount > 0 || !report.Run.Conserved {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,79 @@
+// Package reconciliation renders reconciliation reports as JSON and CSV.
+package reconciliation
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/susilo001/simple-wallet-system/wallet/service"
+)
+
+const (
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+)
+
+// Render writes r to w in the given format and returns the content type of
+// the document.
+func Render(w io.Writer, r service.ReconciliationReport, format string) (string, error) {
+	switch format {
+	case FormatJSON:
+		return "application/json", JSON(w, r)
+	case FormatCSV:
+		return "text/csv", CSV(w, r)
+	default:
+		return "", fmt.Errorf("unknown report format %q", format)
+	}
+}
+
+// Filename returns the suggested download name of a report document.
+func Filename(r service.ReconciliationReport, format string) string {
+	return fmt.Sprintf("reconciliation-%d-%s.%s", r.Run.ID, r.Run.StartedAt.Format("20060102T150405Z"), format)
+}
+
+func JSON(w io.Writer, r service.ReconciliationReport) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// CSV writes one row per discrepancy followed by a total row over all
+// wallets and an external_net row, so that conservation can be checked by
+// comparing the live balance of the last two rows.
+func CSV(w io.Writer, r service.ReconciliationReport) error {
+	cw := csv.NewWriter(w)
+	rows := [][]string{
+		{"record", "wallet_id", "live_balance", "computed_balance", "difference", "wallet_missing", "adjustment_id"},
+	}
+	for _, d := range r.Discrepancies {
+		adjustmentID := ""
+		if d.AdjustmentID != 0 {
+			adjustmentID = strconv.Itoa(d.AdjustmentID)
+		}
+		rows = append(rows, []string{
+			"discrepancy",
+			strconv.Itoa(d.WalletID),
+			money(d.LiveBalance),
+			money(d.ComputedBalance),
+			money(d.Difference),
+			strconv.FormatBool(d.WalletMissing),
+			adjustmentID,
+		})
+	}
+	rows = append(rows,
+		[]string{"total", "", money(r.Run.TotalLiveBalance), money(r.Run.TotalComputedBalance),
+			money(r.Run.TotalLiveBalance - r.Run.TotalComputedBalance), "", ""},
+		[]string{"external_net", "", money(r.Run.ExternalNet), money(r.Run.ExternalNet), "", "", ""},
+	)
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+func money(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
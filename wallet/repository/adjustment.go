@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/susilo001/simple-wallet-system/wallet/entity"
+	"github.com/susilo001/simple-wallet-system/wallet/pagination"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (r *walletRepository) CreateBalanceAdjustment(ctx context.Context, adjustment *entity.BalanceAdjustment) error {
+	if err := r.db.WithContext(ctx).Create(adjustment).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error creating balance adjustment", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (r *walletRepository) GetBalanceAdjustmentForUpdate(ctx context.Context, id int) (entity.BalanceAdjustment, error) {
+	var adjustment entity.BalanceAdjustment
+	if err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).First(&adjustment, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.BalanceAdjustment{}, nil
+		}
+		r.logger.ErrorContext(ctx, "Error getting balance adjustment for update", "error", err)
+		return entity.BalanceAdjustment{}, err
+	}
+	return adjustment, nil
+}
+
+func (r *walletRepository) GetBalanceAdjustments(ctx context.Context, status string, page pagination.Page) ([]entity.BalanceAdjustment, int, error) {
+	var adjustments []entity.BalanceAdjustment
+	query := r.db.WithContext(ctx).Order("id")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	total, err := pagination.Find(query, page, &adjustments)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error getting balance adjustments", "error", err)
+		return nil, 0, err
+	}
+	return adjustments, total, nil
+}
+
+func (r *walletRepository) HasPendingAdjustment(ctx context.Context, walletID int, source string) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&entity.BalanceAdjustment{}).
+		Where("wallet_id = ? AND source = ? AND status = ?", walletID, source, entity.AdjustmentStatusPending).
+		Count(&count).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error checking pending adjustments", "error", err)
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *walletRepository) UpdateBalanceAdjustment(ctx context.Context, adjustment *entity.BalanceAdjustment) error {
+	if err := r.db.WithContext(ctx).Save(adjustment).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error updating balance adjustment", "error", err)
+		return err
+	}
+	return nil
+}
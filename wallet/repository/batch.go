@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/susilo001/simple-wallet-system/wallet/entity"
+	"gorm.io/gorm"
+)
+
+func (r *walletRepository) CreateTransferBatch(ctx context.Context, batch *entity.TransferBatch) error {
+	if err := r.db.WithContext(ctx).Create(batch).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error creating transfer batch", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (r *walletRepository) UpdateTransferBatch(ctx context.Context, batch *entity.TransferBatch) error {
+	if err := r.db.WithContext(ctx).Save(batch).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error updating transfer batch", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (r *walletRepository) GetTransferBatchByID(ctx context.Context, id int) (entity.TransferBatch, error) {
+	var batch entity.TransferBatch
+	if err := r.db.WithContext(ctx).First(&batch, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.TransferBatch{}, nil
+		}
+		r.logger.ErrorContext(ctx, "Error getting transfer batch by ID", "error", err)
+		return entity.TransferBatch{}, err
+	}
+	return batch, nil
+}
+
+func (r *walletRepository) GetTransactionsByBatchID(ctx context.Context, batchID int) ([]entity.Transaction, error) {
+	var transactions []entity.Transaction
+	if err := r.db.WithContext(ctx).Where("batch_id = ?", batchID).Order("id").Find(&transactions).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error getting transactions by batch ID", "error", err)
+		return nil, err
+	}
+	return transactions, nil
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/susilo001/simple-wallet-system/wallet/entity"
+)
+
+func (r *walletRepository) GetFeeRules(ctx context.Context) ([]entity.FeeRule, error) {
+	var rules []entity.FeeRule
+	if err := r.db.WithContext(ctx).Order("id").Find(&rules).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error getting fee rules", "error", err)
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (r *walletRepository) SaveFeeRule(ctx context.Context, rule *entity.FeeRule) error {
+	if err := r.db.WithContext(ctx).Save(rule).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error saving fee rule", "error", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/susilo001/simple-wallet-system/wallet/entity"
+	"gorm.io/gorm"
+)
+
+func (r *walletRepository) GetLimitByWalletID(ctx context.Context, walletID int) (entity.WalletLimit, error) {
+	var limit entity.WalletLimit
+	if err := r.db.WithContext(ctx).Where("wallet_id = ?", walletID).First(&limit).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.WalletLimit{}, nil
+		}
+		r.logger.ErrorContext(ctx, "Error getting limit by wallet ID", "error", err)
+		return entity.WalletLimit{}, err
+	}
+	return limit, nil
+}
+
+func (r *walletRepository) GetLimitByTier(ctx context.Context, tier string) (entity.WalletLimit, error) {
+	var limit entity.WalletLimit
+	if err := r.db.WithContext(ctx).Where("wallet_id = 0 AND tier = ?", tier).First(&limit).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.WalletLimit{}, nil
+		}
+		r.logger.ErrorContext(ctx, "Error getting limit by tier", "error", err)
+		return entity.WalletLimit{}, err
+	}
+	return limit, nil
+}
+
+func (r *walletRepository) SaveLimit(ctx context.Context, limit *entity.WalletLimit) error {
+	if err := r.db.WithContext(ctx).Save(limit).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error saving limit", "error", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/susilo001/simple-wallet-system/wallet/entity"
+	"github.com/susilo001/simple-wallet-system/wallet/pagination"
+	"github.com/susilo001/simple-wallet-system/wallet/service"
+	"gorm.io/gorm"
+)
+
+type paymentRequestRepository struct {
+	db     GormDBIface
+	logger *slog.Logger
+}
+
+func NewPaymentRequestRepository(db GormDBIface, logger *slog.Logger) service.IPaymentRequestRepository {
+	return &paymentRequestRepository{db: db, logger: logger}
+}
+
+func (r *paymentRequestRepository) CreatePaymentRequest(ctx context.Context, request *entity.PaymentRequest) error {
+	if err := r.db.WithContext(ctx).Create(request).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error creating payment request", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (r *paymentRequestRepository) GetPaymentRequestByID(ctx context.Context, id int) (entity.PaymentRequest, error) {
+	var request entity.PaymentRequest
+	if err := r.db.WithContext(ctx).First(&request, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.PaymentRequest{}, nil
+		}
+		r.logger.ErrorContext(ctx, "Error getting payment request by ID", "error", err)
+		return entity.PaymentRequest{}, err
+	}
+	return request, nil
+}
+
+func (r *paymentRequestRepository) GetPaymentRequestByCode(ctx context.Context, code string) (entity.PaymentRequest, error) {
+	var request entity.PaymentRequest
+	if err := r.db.WithContext(ctx).Where("code = ?", code).First(&request).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.PaymentRequest{}, nil
+		}
+		r.logger.ErrorContext(ctx, "Error getting payment request by code", "error", err)
+		return entity.PaymentRequest{}, err
+	}
+	return request, nil
+}
+
+func (r *paymentRequestRepository) GetPaymentRequestsByWalletID(ctx context.Context, walletID int, page pagination.Page) ([]entity.PaymentRequest, int, error) {
+	var requests []entity.PaymentRequest
+	total, err := pagination.Find(r.db.WithContext(ctx).Where("requester_id = ? OR payer_id = ?", walletID, walletID).Order("id DESC"), page, &requests)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error getting payment requests", "error", err)
+		return nil, 0, err
+	}
+	return requests, total, nil
+}
+
+func (r *paymentRequestRepository) UpdatePaymentRequestStatus(ctx context.Context, request *entity.PaymentRequest, from string) (bool, error) {
+	result := r.db.WithContext(ctx).Model(request).
+		Where("status = ?", from).
+		Select("status", "payer_id", "paid_at", "held_transfer_id").
+		Updates(request)
+	if result.Error != nil {
+		r.logger.ErrorContext(ctx, "Error updating payment request status", "error", result.Error)
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *paymentRequestRepository) ExpirePaymentRequests(ctx context.Context, now time.Time) (int, error) {
+	result := r.db.WithContext(ctx).Model(&entity.PaymentRequest{}).
+		Where("status = ? AND expires_at <= ?", entity.PaymentRequestStatusPending, now).
+		Update("status", entity.PaymentRequestStatusExpired)
+	if result.Error != nil {
+		r.logger.ErrorContext(ctx, "Error expiring payment requests", "error", result.Error)
+		return 0, result.Error
+	}
+	return int(result.RowsAffected), nil
+}
+
+// The wallet repository settles held payment requests in the transaction
+// that reviews the held transfer.
+
+func (r *walletRepository) GetPaymentRequestByHeldTransferID(ctx context.Context, heldTransferID int) (entity.PaymentRequest, error) {
+	var request entity.PaymentRequest
+	if err := r.db.WithContext(ctx).Where("held_transfer_id = ?", heldTransferID).First(&request).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.PaymentRequest{}, nil
+		}
+		r.logger.ErrorContext(ctx, "Error getting payment request by held transfer ID", "error", err)
+		return entity.PaymentRequest{}, err
+	}
+	return request, nil
+}
+
+func (r *walletRepository) UpdatePaymentRequestStatus(ctx context.Context, request *entity.PaymentRequest, from string) (bool, error) {
+	return (&paymentRequestRepository{db: r.db, logger: r.logger}).UpdatePaymentRequestStatus(ctx, request, from)
+}
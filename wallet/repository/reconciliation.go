@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/susilo001/simple-wallet-system/wallet/entity"
+	"github.com/susilo001/simple-wallet-system/wallet/service"
+	"gorm.io/gorm"
+)
+
+type reconciliationRepository struct {
+	db     GormDBIface
+	logger *slog.Logger
+}
+
+func NewReconciliationRepository(db GormDBIface, logger *slog.Logger) service.IReconciliationRepository {
+	return &reconciliationRepository{db: db, logger: logger}
+}
+
+func (r *reconciliationRepository) GetNetAmountsByWallet(ctx context.Context) (map[int]float64, error) {
+	var rows []struct {
+		WalletID int
+		Net      float64
+	}
+	if err := r.db.WithContext(ctx).Raw(`SELECT wallet_id, SUM(net) AS net FROM (
+		SELECT recipient_id AS wallet_id, amount AS net FROM transactions WHERE recipient_id <> 0
+		UNION ALL
+		SELECT sender_id AS wallet_id, -amount AS net FROM transactions WHERE sender_id <> 0
+	) AS movements GROUP BY wallet_id`).Scan(&rows).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error getting net amounts by wallet", "error", err)
+		return nil, err
+	}
+
+	nets := make(map[int]float64, len(rows))
+	for _, row := range rows {
+		nets[row.WalletID] = row.Net
+	}
+	return nets, nil
+}
+
+func (r *reconciliationRepository) GetExternalNet(ctx context.Context) (float64, error) {
+	var net float64
+	if err := r.db.WithContext(ctx).Model(&entity.Transaction{}).
+		Select("COALESCE(SUM(CASE WHEN sender_id = 0 THEN amount ELSE 0 END) - SUM(CASE WHEN recipient_id = 0 THEN amount ELSE 0 END), 0)").
+		Scan(&net).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error getting external net", "error", err)
+		return 0, err
+	}
+	return net, nil
+}
+
+func (r *reconciliationRepository) SaveReconciliationRun(ctx context.Context, run *entity.ReconciliationRun, discrepancies []entity.ReconciliationDiscrepancy) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(run).Error; err != nil {
+			return err
+		}
+		for i := range discrepancies {
+			discrepancies[i].RunID = run.ID
+			if err := tx.Save(&discrepancies[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error saving reconciliation run", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (r *reconciliationRepository) GetReconciliationRunByID(ctx context.Context, id int) (entity.ReconciliationRun, []entity.ReconciliationDiscrepancy, error) {
+	var run entity.ReconciliationRun
+	if err := r.db.WithContext(ctx).First(&run, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.ReconciliationRun{}, nil, nil
+		}
+		r.logger.ErrorContext(ctx, "Error getting reconciliation run by ID", "error", err)
+		return entity.ReconciliationRun{}, nil, err
+	}
+
+	var discrepancies []entity.ReconciliationDiscrepancy
+	if err := r.db.WithContext(ctx).Where("run_id = ?", id).Order("wallet_id").Find(&discrepancies).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error getting reconciliation discrepancies", "error", err)
+		return entity.ReconciliationRun{}, nil, err
+	}
+	return run, discrepancies, nil
+}
@@ -3,12 +3,15 @@ package repository
 import (
 	"context"
 	"errors"
-	"log"
+	"fmt"
+	"log/slog"
 	"time"
 
 	"github.com/susilo001/simple-wallet-system/wallet/entity"
+	"github.com/susilo001/simple-wallet-system/wallet/pagination"
 	"github.com/susilo001/simple-wallet-system/wallet/service"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type GormDBIface interface {
@@ -21,16 +24,23 @@ type GormDBIface interface {
 }
 
 type walletRepository struct {
-	db GormDBIface
+	db     GormDBIface
+	logger *slog.Logger
 }
 
-func NewWalletRepository(db GormDBIface) service.IWalletRepository {
-	return &walletRepository{db: db}
+func NewWalletRepository(db GormDBIface, logger *slog.Logger) service.IWalletRepository {
+	return &walletRepository{db: db, logger: logger}
+}
+
+func (r *walletRepository) Transaction(ctx context.Context, fn func(repo service.IWalletRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&walletRepository{db: tx, logger: r.logger})
+	})
 }
 
 func (r *walletRepository) CreateWallet(ctx context.Context, wallet *entity.Wallet) (entity.Wallet, error) {
 	if err := r.db.WithContext(ctx).Create(wallet).Error; err != nil {
-		log.Printf("Error creating wallet: %v\n", err)
+		r.logger.ErrorContext(ctx, "Error creating wallet", "error", err)
 		return entity.Wallet{}, err
 	}
 	return *wallet, nil
@@ -42,134 +52,195 @@ func (r *walletRepository) GetWalletByID(ctx context.Context, id int) (entity.Wa
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return entity.Wallet{}, nil
 		}
-		log.Printf("Error getting wallet by ID: %v\n", err)
+		r.logger.ErrorContext(ctx, "Error getting wallet by ID", "error", err)
 		return entity.Wallet{}, err
 	}
 	return wallet, nil
 }
 
-func (r *walletRepository) UpdateWallet(ctx context.Context, id int, wallet entity.Wallet) (entity.Wallet, error) {
-	var existingWallet entity.Wallet
-	if err := r.db.WithContext(ctx).First(&existingWallet, id).Error; err != nil {
-		log.Printf("Error finding wallet to update: %v\n", err)
-		return entity.Wallet{}, err
-	}
-
-	existingWallet.UserID = wallet.UserID
-	existingWallet.Balance = wallet.Balance
-	if err := r.db.WithContext(ctx).Save(&existingWallet).Error; err != nil {
-		log.Printf("Error updating wallet: %v\n", err)
+func (r *walletRepository) GetWalletForUpdate(ctx context.Context, id int) (entity.Wallet, error) {
+	var wallet entity.Wallet
+	if err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).First(&wallet, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.Wallet{}, fmt.Errorf("wallet %d: %w", id, service.ErrWalletNotFound)
+		}
+		r.logger.ErrorContext(ctx, "Error locking wallet by ID", "error", err)
 		return entity.Wallet{}, err
 	}
-	return existingWallet, nil
+	return wallet, nil
 }
 
-func (r *walletRepository) DeleteWallet(ctx context.Context, id int) error {
-	if err := r.db.WithContext(ctx).Delete(&entity.Wallet{}, id).Error; err != nil {
-		log.Printf("Error deleting wallet: %v\n", err)
+func (r *walletRepository) SetWalletOwner(ctx context.Context, id int, userID int) error {
+	if err := r.db.WithContext(ctx).Model(&entity.Wallet{}).Where("id = ?", id).Update("user_id", userID).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error setting wallet owner", "error", err)
 		return err
 	}
 	return nil
 }
 
-func (r *walletRepository) GetAllWallets(ctx context.Context) ([]entity.Wallet, error) {
-	var wallets []entity.Wallet
-	if err := r.db.WithContext(ctx).Find(&wallets).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return wallets, nil
-		}
-		log.Printf("Error getting all wallets: %v\n", err)
-		return nil, err
+func (r *walletRepository) SetWalletStatus(ctx context.Context, id int, status string, reason string, changedAt time.Time) error {
+	if err := r.db.WithContext(ctx).Model(&entity.Wallet{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":            status,
+		"status_reason":     reason,
+		"status_changed_at": changedAt,
+	}).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error setting wallet status", "error", err)
+		return err
 	}
-	return wallets, nil
+	return nil
 }
 
-func (r *walletRepository) TopUpWallet(ctx context.Context, walletID int, amount float64) error {
-	var wallet entity.Wallet
-	if err := r.db.WithContext(ctx).First(&wallet, walletID).Error; err != nil {
-		log.Printf("Error finding wallet for top-up: %v\n", err)
+func (r *walletRepository) UpdateBalance(ctx context.Context, id int, balance float64) error {
+	if err := r.db.WithContext(ctx).Model(&entity.Wallet{}).Where("id = ?", id).Update("balance", balance).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error updating wallet balance", "error", err)
 		return err
 	}
+	return nil
+}
 
-	wallet.Balance += amount
-	if err := r.db.WithContext(ctx).Save(&wallet).Error; err != nil {
-		log.Printf("Error updating wallet balance for top-up: %v\n", err)
-		return err
+// AddToBalance increments the balance in a single statement, without reading
+// the row first. It is used for wallets that are credited by many concurrent
+// operations, such as the house wallet.
+func (r *walletRepository) AddToBalance(ctx context.Context, id int, delta float64) error {
+	result := r.db.WithContext(ctx).Model(&entity.Wallet{}).Where("id = ?", id).Update("balance", gorm.Expr("balance + ?", delta))
+	if result.Error != nil {
+		r.logger.ErrorContext(ctx, "Error adding to wallet balance", "error", result.Error)
+		return result.Error
 	}
-
-	return r.createTransaction(ctx, walletID, 0, amount)
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("wallet %d: %w", id, service.ErrWalletNotFound)
+	}
+	return nil
 }
 
-func (r *walletRepository) Transfer(ctx context.Context, senderID int, recipientID int, amount float64) error {
-	tx := r.db.WithContext(ctx).Begin()
-	if tx.Error != nil {
-		log.Printf("Error beginning transaction: %v\n", tx.Error)
-		return tx.Error
+// EnsureInternalWallet returns the internal wallet owned by userID, creating
+// it if it does not exist yet.
+func (r *walletRepository) EnsureInternalWallet(ctx context.Context, userID int) (entity.Wallet, error) {
+	wallet := entity.Wallet{UserID: userID, Tier: entity.InternalWalletTier, Internal: true}
+	if err := r.db.WithContext(ctx).Where("internal = ? AND user_id = ?", true, userID).FirstOrCreate(&wallet).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error ensuring internal wallet", "error", err)
+		return entity.Wallet{}, err
 	}
+	return wallet, nil
+}
 
-	var senderWallet entity.Wallet
-	if err := tx.First(&senderWallet, recipientID).Error; err != nil {
-		log.Printf("Error finding sender's wallet for transfer: %v\n", err)
-		tx.Rollback()
-		return err
+func (r *walletRepository) SetWalletTier(ctx context.Context, id int, tier string) error {
+	result := r.db.WithContext(ctx).Model(&entity.Wallet{}).Where("id = ?", id).Update("tier", tier)
+	if result.Error != nil {
+		r.logger.ErrorContext(ctx, "Error updating wallet tier", "error", result.Error)
+		return result.Error
 	}
-
-	if senderWallet.Balance < amount {
-		tx.Rollback()
-		return errors.New("insufficient balance")
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("wallet %d: %w", id, service.ErrWalletNotFound)
 	}
+	return nil
+}
 
-	senderWallet.Balance -= amount
-	if err := tx.Save(&senderWallet).Error; err != nil {
-		log.Printf("Error updating sender's wallet balance: %v\n", err)
-		tx.Rollback()
+func (r *walletRepository) DeleteWallet(ctx context.Context, id int) error {
+	if err := r.db.WithContext(ctx).Delete(&entity.Wallet{}, id).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error deleting wallet", "error", err)
 		return err
 	}
+	return nil
+}
 
-	var toWallet entity.Wallet
-	if err := tx.First(&toWallet, recipientID).Error; err != nil {
-		log.Printf("Error finding receiver's wallet for transfer: %v\n", err)
-		tx.Rollback()
-		return err
+func (r *walletRepository) GetAllWallets(ctx context.Context) ([]entity.Wallet, error) {
+	var wallets []entity.Wallet
+	if err := r.db.WithContext(ctx).Find(&wallets).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return wallets, nil
+		}
+		r.logger.ErrorContext(ctx, "Error getting all wallets", "error", err)
+		return nil, err
+	}
+	return wallets, nil
+}
+
+func (r *walletRepository) GetWalletsByUserID(ctx context.Context, userID int, page pagination.Page) ([]entity.Wallet, int, error) {
+	var wallets []entity.Wallet
+	total, err := pagination.Find(r.db.WithContext(ctx).Where("user_id = ?", userID).Order("id"), page, &wallets)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error getting wallets of user", "error", err)
+		return nil, 0, err
 	}
+	return wallets, total, nil
+}
 
-	toWallet.Balance += amount
-	if err := tx.Save(&toWallet).Error; err != nil {
-		log.Printf("Error updating receiver's wallet balance: %v\n", err)
-		tx.Rollback()
-		return err
+func (r *walletRepository) GetTransactions(ctx context.Context, walletID int, page pagination.Page) ([]entity.Transaction, int, error) {
+	var transactions []entity.Transaction
+	total, err := pagination.Find(r.db.WithContext(ctx).Where("sender_id = ? OR recipient_id = ?", walletID, walletID).Order("id"), page, &transactions)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error getting transactions", "error", err)
+		return nil, 0, err
 	}
+	return transactions, total, nil
+}
 
-	if err := r.createTransaction(ctx, senderID, recipientID, amount); err != nil {
-		tx.Rollback()
+func (r *walletRepository) CreateTransaction(ctx context.Context, transaction *entity.Transaction) error {
+	if err := r.db.WithContext(ctx).Create(transaction).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error creating transaction", "error", err)
 		return err
 	}
-
-	return tx.Commit().Error
+	return nil
 }
 
-func (r *walletRepository) GetTransactions(ctx context.Context, walletID int) ([]entity.Transaction, error) {
+func (r *walletRepository) GetTransactionsBetween(ctx context.Context, walletID int, from time.Time, to time.Time) ([]entity.Transaction, error) {
 	var transactions []entity.Transaction
-	if err := r.db.WithContext(ctx).Where("sender_id = ? OR recipient_id = ?", walletID, walletID).Find(&transactions).Error; err != nil {
-		log.Printf("Error getting transactions: %v\n", err)
+	if err := r.db.WithContext(ctx).
+		Where("(sender_id = ? OR recipient_id = ?) AND created_at >= ? AND created_at < ?", walletID, walletID, from, to).
+		Order("created_at, id").
+		Find(&transactions).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error getting transactions between", "error", err)
 		return nil, err
 	}
 	return transactions, nil
 }
 
-func (r *walletRepository) createTransaction(ctx context.Context, senderID int, recipientID int, amount float64) error {
-	transaction := entity.Transaction{
-		SenderID:    senderID,
-		RecipientID: recipientID,
-		Amount:      amount,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+func (r *walletRepository) GetTransactionByIdempotencyKey(ctx context.Context, key string) (entity.Transaction, error) {
+	var transaction entity.Transaction
+	if err := r.db.WithContext(ctx).Where("idempotency_key = ?", key).First(&transaction).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.Transaction{}, nil
+		}
+		r.logger.ErrorContext(ctx, "Error getting transaction by idempotency key", "error", err)
+		return entity.Transaction{}, err
 	}
+	return transaction, nil
+}
 
-	if err := r.db.WithContext(ctx).Create(&transaction).Error; err != nil {
-		log.Printf("Error creating transaction: %v\n", err)
+// SumOutgoing returns the total amount and number of transfers and
+// withdrawals made by the wallet since the given time. Fees are not counted.
+func (r *walletRepository) SumOutgoing(ctx context.Context, walletID int, since time.Time) (float64, int, error) {
+	var result struct {
+		Total float64
+		Count int
+	}
+	err := r.db.WithContext(ctx).Model(&entity.Transaction{}).
+		Select("COALESCE(SUM(amount), 0) AS total, COUNT(*) AS count").
+		Where("sender_id = ? AND type IN ? AND created_at >= ?", walletID,
+			[]string{entity.TransactionTypeTransfer, entity.TransactionTypeWithdrawal}, since).
+		Scan(&result).Error
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error summing outgoing transactions", "error", err)
+		return 0, 0, err
+	}
+	return result.Total, result.Count, nil
+}
+
+// BackfillTransactionTypes types the transactions written before the type
+// column existed. Those top-ups were recorded with the wallet as sender and
+// no recipient; they are rewritten to credit the wallet as recipient.
+func BackfillTransactionTypes(db *gorm.DB) error {
+	if err := db.Model(&entity.Transaction{}).
+		Where("(type = '' OR type IS NULL) AND recipient_id = 0").
+		Updates(map[string]interface{}{
+			"type":         entity.TransactionTypeTopUp,
+			"recipient_id": gorm.Expr("sender_id"),
+			"sender_id":    0,
+		}).Error; err != nil {
 		return err
 	}
-
-	return nil
+	return db.Model(&entity.Transaction{}).
+		Where("type = '' OR type IS NULL").
+		Update("type", entity.TransactionTypeTransfer).Error
 }
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/susilo001/simple-wallet-system/wallet/entity"
+	"github.com/susilo001/simple-wallet-system/wallet/pagination"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (r *walletRepository) CountTransfersTo(ctx context.Context, senderID int, recipientID int) (int, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&entity.Transaction{}).
+		Where("type = ? AND sender_id = ? AND recipient_id = ?", entity.TransactionTypeTransfer, senderID, recipientID).
+		Count(&count).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error counting transfers to recipient", "error", err)
+		return 0, err
+	}
+	return int(count), nil
+}
+
+func (r *walletRepository) GetTransferStats(ctx context.Context, walletID int, since time.Time) (int, float64, error) {
+	var result struct {
+		Count   int
+		Average float64
+	}
+	err := r.db.WithContext(ctx).Model(&entity.Transaction{}).
+		Select("COUNT(*) AS count, COALESCE(AVG(amount), 0) AS average").
+		Where("type = ? AND sender_id = ? AND created_at >= ?", entity.TransactionTypeTransfer, walletID, since).
+		Scan(&result).Error
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error getting transfer stats", "error", err)
+		return 0, 0, err
+	}
+	return result.Count, result.Average, nil
+}
+
+func (r *walletRepository) CreateHeldTransfer(ctx context.Context, held *entity.HeldTransfer) error {
+	if err := r.db.WithContext(ctx).Create(held).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error creating held transfer", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (r *walletRepository) GetPendingHeldTransferByKey(ctx context.Context, key string) (entity.HeldTransfer, error) {
+	var held entity.HeldTransfer
+	if err := r.db.WithContext(ctx).
+		Where("idempotency_key = ? AND status = ?", key, entity.HeldTransferStatusPending).
+		First(&held).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.HeldTransfer{}, nil
+		}
+		r.logger.ErrorContext(ctx, "Error getting held transfer by idempotency key", "error", err)
+		return entity.HeldTransfer{}, err
+	}
+	return held, nil
+}
+
+func (r *walletRepository) GetHeldTransferByID(ctx context.Context, id int) (entity.HeldTransfer, error) {
+	var held entity.HeldTransfer
+	if err := r.db.WithContext(ctx).First(&held, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.HeldTransfer{}, nil
+		}
+		r.logger.ErrorContext(ctx, "Error getting held transfer by ID", "error", err)
+		return entity.HeldTransfer{}, err
+	}
+	return held, nil
+}
+
+func (r *walletRepository) GetHeldTransferForUpdate(ctx context.Context, id int) (entity.HeldTransfer, error) {
+	var held entity.HeldTransfer
+	if err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).First(&held, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.HeldTransfer{}, nil
+		}
+		r.logger.ErrorContext(ctx, "Error getting held transfer for update", "error", err)
+		return entity.HeldTransfer{}, err
+	}
+	return held, nil
+}
+
+func (r *walletRepository) GetHeldTransfers(ctx context.Context, status string, page pagination.Page) ([]entity.HeldTransfer, int, error) {
+	var held []entity.HeldTransfer
+	query := r.db.WithContext(ctx).Order("id")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	total, err := pagination.Find(query, page, &held)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error getting held transfers", "error", err)
+		return nil, 0, err
+	}
+	return held, total, nil
+}
+
+func (r *walletRepository) UpdateHeldTransfer(ctx context.Context, held *entity.HeldTransfer) error {
+	if err := r.db.WithContext(ctx).Save(held).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error updating held transfer", "error", err)
+		return err
+	}
+	return nil
+}
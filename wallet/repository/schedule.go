@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/susilo001/simple-wallet-system/wallet/entity"
+	"github.com/susilo001/simple-wallet-system/wallet/pagination"
+	"github.com/susilo001/simple-wallet-system/wallet/service"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type scheduledTransferRepository struct {
+	db     GormDBIface
+	logger *slog.Logger
+}
+
+func NewScheduledTransferRepository(db GormDBIface, logger *slog.Logger) service.IScheduledTransferRepository {
+	return &scheduledTransferRepository{db: db, logger: logger}
+}
+
+func (r *scheduledTransferRepository) CreateScheduledTransfer(ctx context.Context, schedule *entity.ScheduledTransfer) error {
+	if err := r.db.WithContext(ctx).Create(schedule).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error creating scheduled transfer", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (r *scheduledTransferRepository) GetScheduledTransferByID(ctx context.Context, id int) (entity.ScheduledTransfer, error) {
+	var schedule entity.ScheduledTransfer
+	if err := r.db.WithContext(ctx).First(&schedule, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.ScheduledTransfer{}, nil
+		}
+		r.logger.ErrorContext(ctx, "Error getting scheduled transfer by ID", "error", err)
+		return entity.ScheduledTransfer{}, err
+	}
+	return schedule, nil
+}
+
+func (r *scheduledTransferRepository) GetScheduledTransfersByWalletID(ctx context.Context, walletID int, page pagination.Page) ([]entity.ScheduledTransfer, int, error) {
+	var schedules []entity.ScheduledTransfer
+	total, err := pagination.Find(r.db.WithContext(ctx).Where("sender_id = ?", walletID).Order("id"), page, &schedules)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error getting scheduled transfers", "error", err)
+		return nil, 0, err
+	}
+	return schedules, total, nil
+}
+
+func (r *scheduledTransferRepository) UpdateScheduledTransfer(ctx context.Context, schedule *entity.ScheduledTransfer) error {
+	if err := r.db.WithContext(ctx).Save(schedule).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error updating scheduled transfer", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (r *scheduledTransferRepository) ClaimDueScheduledTransfers(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]entity.ScheduledTransfer, error) {
+	var schedules []entity.ScheduledTransfer
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_run_at <= ? AND held_transfer_id IS NULL", entity.ScheduleStatusActive, now).
+			Order("next_run_at").Limit(limit).
+			Find(&schedules).Error; err != nil {
+			return err
+		}
+		if len(schedules) == 0 {
+			return nil
+		}
+
+		ids := make([]int, len(schedules))
+		for i, schedule := range schedules {
+			ids[i] = schedule.ID
+		}
+		return tx.Model(&entity.ScheduledTransfer{}).Where("id IN ?", ids).Update("next_run_at", now.Add(lease)).Error
+	})
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error claiming due scheduled transfers", "error", err)
+		return nil, err
+	}
+	return schedules, nil
+}
+
+func (r *scheduledTransferRepository) SaveScheduledTransferRun(ctx context.Context, schedule *entity.ScheduledTransfer) error {
+	err := r.db.WithContext(ctx).Model(schedule).
+		Where("status = ?", entity.ScheduleStatusActive).
+		Select("status", "occurrence_at", "next_run_at", "attempts", "executed_count", "last_run_at", "last_error", "held_transfer_id").
+		Updates(schedule).Error
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error saving scheduled transfer run", "error", err)
+		return err
+	}
+	return nil
+}
+
+// The wallet repository settles held scheduled transfers in the transaction
+// that reviews the held transfer.
+
+func (r *walletRepository) GetScheduledTransferByHeldTransferID(ctx context.Context, heldTransferID int) (entity.ScheduledTransfer, error) {
+	var schedule entity.ScheduledTransfer
+	if err := r.db.WithContext(ctx).Where("held_transfer_id = ?", heldTransferID).First(&schedule).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.ScheduledTransfer{}, nil
+		}
+		r.logger.ErrorContext(ctx, "Error getting scheduled transfer by held transfer ID", "error", err)
+		return entity.ScheduledTransfer{}, err
+	}
+	return schedule, nil
+}
+
+func (r *walletRepository) UpdateScheduledTransfer(ctx context.Context, schedule *entity.ScheduledTransfer) error {
+	return (&scheduledTransferRepository{db: r.db, logger: r.logger}).UpdateScheduledTransfer(ctx, schedule)
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/susilo001/simple-wallet-system/wallet/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (r *walletRepository) GetLatestSnapshotAtOrBefore(ctx context.Context, walletID int, t time.Time) (entity.BalanceSnapshot, error) {
+	var snapshot entity.BalanceSnapshot
+	if err := r.db.WithContext(ctx).
+		Where("wallet_id = ? AND taken_at <= ?", walletID, t).
+		Order("taken_at DESC").
+		First(&snapshot).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.BalanceSnapshot{}, nil
+		}
+		r.logger.ErrorContext(ctx, "Error getting latest balance snapshot", "error", err)
+		return entity.BalanceSnapshot{}, err
+	}
+	return snapshot, nil
+}
+
+func (r *walletRepository) CreateBalanceSnapshot(ctx context.Context, snapshot *entity.BalanceSnapshot) error {
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(snapshot).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error creating balance snapshot", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (r *walletRepository) GetNetAmountBetween(ctx context.Context, walletID int, from time.Time, to time.Time) (float64, error) {
+	var net float64
+	if err := r.db.WithContext(ctx).Model(&entity.Transaction{}).
+		Select("COALESCE(SUM(CASE WHEN recipient_id = ? THEN amount ELSE 0 END) - SUM(CASE WHEN sender_id = ? THEN amount ELSE 0 END), 0)", walletID, walletID).
+		Where("(sender_id = ? OR recipient_id = ?) AND created_at >= ? AND created_at < ?", walletID, walletID, from, to).
+		Scan(&net).Error; err != nil {
+		r.logger.ErrorContext(ctx, "Error getting net amount between", "error", err)
+		return 0, err
+	}
+	return net, nil
+}
@@ -0,0 +1,161 @@
+// Package risk scores transfers before any money moves. An Engine runs a
+// set of rules; every rule that matches adds its score, and the total
+// decides whether the transfer is allowed, held for manual review or
+// blocked.
+package risk
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/susilo001/simple-wallet-system/wallet/entity"
+)
+
+type Decision string
+
+const (
+	DecisionAllow  Decision = "ALLOW"
+	DecisionReview Decision = "REVIEW"
+	DecisionBlock  Decision = "BLOCK"
+)
+
+// Transfer is the transfer being assessed.
+type Transfer struct {
+	Sender    entity.Wallet
+	Recipient entity.Wallet
+	Amount    float64
+	At        time.Time
+}
+
+// History answers the questions rules ask about a wallet's past.
+type History interface {
+	// CountTransfersTo returns how many transfers senderID has made to
+	// recipientID.
+	CountTransfersTo(ctx context.Context, senderID int, recipientID int) (int, error)
+	// GetTransferStats returns the number and average amount of the
+	// transfers walletID has sent since since.
+	GetTransferStats(ctx context.Context, walletID int, since time.Time) (count int, average float64, err error)
+	// SumOutgoing returns the total and number of the transfers and
+	// withdrawals walletID has made since since.
+	SumOutgoing(ctx context.Context, walletID int, since time.Time) (total float64, count int, err error)
+}
+
+// Rule is one risk check. Evaluate returns whether the rule matched the
+// transfer and, if so, why.
+type Rule interface {
+	Name() string
+	Score() int
+	Evaluate(ctx context.Context, t Transfer, history History) (hit bool, reason string, err error)
+}
+
+// Hit is a rule that matched a transfer.
+type Hit struct {
+	Rule   string `json:"rule"`
+	Score  int    `json:"score"`
+	Reason string `json:"reason"`
+}
+
+type Assessment struct {
+	Score    int      `json:"score"`
+	Decision Decision `json:"decision"`
+	Hits     []Hit    `json:"hits"`
+}
+
+// RuleStats counts how often a rule was evaluated and how often it matched
+// since the engine was created.
+type RuleStats struct {
+	Rule        string
+	Evaluations int64
+	Hits        int64
+}
+
+// Stats summarises the engine's work since it was created.
+type Stats struct {
+	Rules     []RuleStats
+	Decisions map[Decision]int64
+}
+
+// Engine assesses transfers with a fixed set of rules. It is safe for
+// concurrent use.
+type Engine struct {
+	rules          []Rule
+	reviewAt       int
+	blockAt        int
+	mu             sync.Mutex
+	evaluations    map[string]int64
+	hits           map[string]int64
+	decisionCounts map[Decision]int64
+}
+
+// NewEngine creates an engine that holds transfers scoring at least
+// reviewAt for review and blocks those scoring at least blockAt.
+func NewEngine(reviewAt int, blockAt int, rules ...Rule) *Engine {
+	return &Engine{
+		rules:          rules,
+		reviewAt:       reviewAt,
+		blockAt:        blockAt,
+		evaluations:    map[string]int64{},
+		hits:           map[string]int64{},
+		decisionCounts: map[Decision]int64{},
+	}
+}
+
+// Assess runs every rule against t. A rule that fails makes the whole
+// assessment fail rather than letting the transfer through unchecked.
+func (e *Engine) Assess(ctx context.Context, t Transfer, history History) (Assessment, error) {
+	var assessment Assessment
+	for _, rule := range e.rules {
+		hit, reason, err := rule.Evaluate(ctx, t, history)
+		if err != nil {
+			return Assessment{}, fmt.Errorf("risk rule %s: %w", rule.Name(), err)
+		}
+		e.count(e.evaluations, rule.Name())
+		if hit {
+			e.count(e.hits, rule.Name())
+			assessment.Score += rule.Score()
+			assessment.Hits = append(assessment.Hits, Hit{Rule: rule.Name(), Score: rule.Score(), Reason: reason})
+		}
+	}
+
+	switch {
+	case assessment.Score >= e.blockAt:
+		assessment.Decision = DecisionBlock
+	case assessment.Score >= e.reviewAt:
+		assessment.Decision = DecisionReview
+	default:
+		assessment.Decision = DecisionAllow
+	}
+
+	e.mu.Lock()
+	e.decisionCounts[assessment.Decision]++
+	e.mu.Unlock()
+	return assessment, nil
+}
+
+// Stats returns the rule hit counters, in rule order, and the number of
+// each decision made.
+func (e *Engine) Stats() Stats {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	stats := Stats{Decisions: map[Decision]int64{}}
+	for _, rule := range e.rules {
+		stats.Rules = append(stats.Rules, RuleStats{
+			Rule:        rule.Name(),
+			Evaluations: e.evaluations[rule.Name()],
+			Hits:        e.hits[rule.Name()],
+		})
+	}
+	for decision, count := range e.decisionCounts {
+		stats.Decisions[decision] = count
+	}
+	return stats
+}
+
+func (e *Engine) count(counter map[string]int64, name string) {
+	e.mu.Lock()
+	counter[name]++
+	e.mu.Unlock()
+}
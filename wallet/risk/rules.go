@@ -0,0 +1,90 @@
+package risk
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// NewRecipientRule matches the first transfer from a wallet to a recipient.
+type NewRecipientRule struct {
+	Points int
+}
+
+func (r NewRecipientRule) Name() string { return "new_recipient" }
+func (r NewRecipientRule) Score() int   { return r.Points }
+
+func (r NewRecipientRule) Evaluate(ctx context.Context, t Transfer, history History) (bool, string, error) {
+	count, err := history.CountTransfersTo(ctx, t.Sender.ID, t.Recipient.ID)
+	if err != nil {
+		return false, "", err
+	}
+	if count > 0 {
+		return false, "", nil
+	}
+	return true, fmt.Sprintf("first transfer to wallet %d", t.Recipient.ID), nil
+}
+
+// UnusualAmountRule matches a transfer of more than Multiplier times the
+// sender's average transfer over Lookback. Senders with fewer than
+// MinTransfers transfers in that period have no usual amount yet.
+type UnusualAmountRule struct {
+	Points       int
+	Multiplier   float64
+	Lookback     time.Duration
+	MinTransfers int
+}
+
+func (r UnusualAmountRule) Name() string { return "unusual_amount" }
+func (r UnusualAmountRule) Score() int   { return r.Points }
+
+func (r UnusualAmountRule) Evaluate(ctx context.Context, t Transfer, history History) (bool, string, error) {
+	count, average, err := history.GetTransferStats(ctx, t.Sender.ID, t.At.Add(-r.Lookback))
+	if err != nil {
+		return false, "", err
+	}
+	if count < r.MinTransfers || t.Amount <= average*r.Multiplier {
+		return false, "", nil
+	}
+	return true, fmt.Sprintf("amount %.2f is more than %g times the average of %.2f", t.Amount, r.Multiplier, average), nil
+}
+
+// RapidTransfersRule matches when the sender has already made MaxCount or
+// more outgoing transfers and withdrawals within Window.
+type RapidTransfersRule struct {
+	Points   int
+	Window   time.Duration
+	MaxCount int
+}
+
+func (r RapidTransfersRule) Name() string { return "rapid_transfers" }
+func (r RapidTransfersRule) Score() int   { return r.Points }
+
+func (r RapidTransfersRule) Evaluate(ctx context.Context, t Transfer, history History) (bool, string, error) {
+	_, count, err := history.SumOutgoing(ctx, t.Sender.ID, t.At.Add(-r.Window))
+	if err != nil {
+		return false, "", err
+	}
+	if count < r.MaxCount {
+		return false, "", nil
+	}
+	return true, fmt.Sprintf("%d outgoing transactions in the last %s", count, r.Window), nil
+}
+
+// NewAccountRule matches transfers from a wallet created less than MinAge
+// ago.
+type NewAccountRule struct {
+	Points int
+	MinAge time.Duration
+}
+
+func (r NewAccountRule) Name() string { return "new_account" }
+func (r NewAccountRule) Score() int   { return r.Points }
+
+func (r NewAccountRule) Evaluate(ctx context.Context, t Transfer, history History) (bool, string, error) {
+	age := t.At.Sub(t.Sender.CreatedAt)
+	if age >= r.MinAge {
+		return false, "", nil
+	}
+	return true, fmt.Sprintf("wallet created %s ago", age.Round(time.Minute)), nil
+}
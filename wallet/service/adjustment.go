@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/susilo001/simple-wallet-system/wallet/entity"
+	"github.com/susilo001/simple-wallet-system/wallet/pagination"
+)
+
+// GetBalanceAdjustments returns a page of the balance adjustments in the
+// given status, or in any status when status is empty, and their count.
+func (s *walletService) GetBalanceAdjustments(ctx context.Context, status string, page pagination.Page) ([]entity.BalanceAdjustment, int, error) {
+	adjustments, total, err := s.walletRepo.GetBalanceAdjustments(ctx, status, page)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get balance adjustments: %w", err)
+	}
+	return adjustments, total, nil
+}
+
+// ProposeBalanceAdjustment opens a pending manual adjustment on behalf of
+// the user proposerID. Nothing changes until another user approves it.
+func (s *walletService) ProposeBalanceAdjustment(ctx context.Context, walletID int, amount float64, reason string, proposerID int) (entity.BalanceAdjustment, error) {
+	amount = roundCents(amount)
+	switch {
+	case amount == 0:
+		return entity.BalanceAdjustment{}, fmt.Errorf("failed to propose balance adjustment: %w: amount must not be zero", ErrInvalidAdjustment)
+	case reason == "":
+		return entity.BalanceAdjustment{}, fmt.Errorf("failed to propose balance adjustment: %w: reason is required", ErrInvalidAdjustment)
+	case proposerID == 0:
+		return entity.BalanceAdjustment{}, fmt.Errorf("failed to propose balance adjustment: %w: proposer is required", ErrInvalidAdjustment)
+	}
+	if _, err := s.getExistingWallet(ctx, walletID); err != nil {
+		return entity.BalanceAdjustment{}, fmt.Errorf("failed to propose balance adjustment: %w", err)
+	}
+
+	adjustment := entity.BalanceAdjustment{
+		WalletID:   walletID,
+		Amount:     amount,
+		Source:     entity.AdjustmentSourceManual,
+		Status:     entity.AdjustmentStatusPending,
+		Reason:     reason,
+		ProposedBy: fmt.Sprintf("user:%d", proposerID),
+		// The proposer is kept as a user ID so that the same user cannot
+		// review it under another name, e.g. through an API key.
+		ProposedByUserID: proposerID,
+	}
+	if err := s.walletRepo.CreateBalanceAdjustment(ctx, &adjustment); err != nil {
+		return entity.BalanceAdjustment{}, fmt.Errorf("failed to propose balance adjustment: %w", err)
+	}
+	return adjustment, nil
+}
+
+// ReviewBalanceAdjustment approves or rejects a pending adjustment on
+// behalf of the user reviewerID, who must not be its proposer. On approval the adjustment is
+// re-checked against the wallet and posted as an ADJUSTMENT transaction in
+// the same database transaction.
+func (s *walletService) ReviewBalanceAdjustment(ctx context.Context, id int, approve bool, reviewerID int) (entity.BalanceAdjustment, error) {
+	if reviewerID == 0 {
+		return entity.BalanceAdjustment{}, fmt.Errorf("failed to review balance adjustment: %w: reviewer is required", ErrInvalidAdjustment)
+	}
+
+	var adjustment entity.BalanceAdjustment
+	err := s.walletRepo.Transaction(ctx, func(repo IWalletRepository) error {
+		var err error
+		adjustment, err = repo.GetBalanceAdjustmentForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if adjustment.ID == 0 {
+			return fmt.Errorf("adjustment %d: %w", id, ErrAdjustmentNotFound)
+		}
+		if adjustment.Status != entity.AdjustmentStatusPending {
+			return fmt.Errorf("adjustment %d is %s: %w", id, adjustment.Status, ErrAdjustmentClosed)
+		}
+		// Manual adjustments proposed before proposers were recorded by user
+		// ID cannot be checked and have to be proposed again.
+		if adjustment.Source == entity.AdjustmentSourceManual && adjustment.ProposedByUserID == 0 {
+			return fmt.Errorf("%w: adjustment %d has no proposer user ID, propose it again", ErrInvalidAdjustment, id)
+		}
+		if adjustment.ProposedByUserID == reviewerID {
+			return fmt.Errorf("user %d proposed adjustment %d: %w", reviewerID, id, ErrSelfReview)
+		}
+
+		now := time.Now().UTC()
+		adjustment.ReviewedBy = fmt.Sprintf("user:%d", reviewerID)
+		adjustment.ReviewedByUserID = reviewerID
+		adjustment.ReviewedAt = &now
+		if !approve {
+			adjustment.Status = entity.AdjustmentStatusRejected
+			return repo.UpdateBalanceAdjustment(ctx, &adjustment)
+		}
+
+		if err := s.applyAdjustment(ctx, repo, &adjustment); err != nil {
+			return err
+		}
+		adjustment.Status = entity.AdjustmentStatusApproved
+		return repo.UpdateBalanceAdjustment(ctx, &adjustment)
+	})
+	if err != nil {
+		return entity.BalanceAdjustment{}, fmt.Errorf("failed to review balance adjustment: %w", err)
+	}
+	return adjustment, nil
+}
+
+func (s *walletService) applyAdjustment(ctx context.Context, repo IWalletRepository, adjustment *entity.BalanceAdjustment) error {
+	wallet, err := repo.GetWalletForUpdate(ctx, adjustment.WalletID)
+	if err != nil {
+		return err
+	}
+	if wallet.ID == 0 {
+		return fmt.Errorf("wallet %d: %w", adjustment.WalletID, ErrWalletNotFound)
+	}
+	// Corrections are still possible on a frozen wallet, which is often
+	// frozen because its balance is disputed.
+	if walletStatus(wallet) == entity.WalletStatusClosed {
+		return fmt.Errorf("wallet %d: %w", wallet.ID, ErrWalletClosed)
+	}
+
+	switch adjustment.Source {
+	case entity.AdjustmentSourceManual:
+		balance := roundCents(wallet.Balance + adjustment.Amount)
+		if balance < 0 {
+			return ErrInsufficientBalance
+		}
+		if err := repo.UpdateBalance(ctx, wallet.ID, balance); err != nil {
+			return err
+		}
+	case entity.AdjustmentSourceReconciliation:
+		// The stored balance already holds the amount; only the history is
+		// missing it. Approve only if that is still exactly the case.
+		computed, err := balanceAt(ctx, repo, wallet.ID, time.Now().UTC())
+		if err != nil {
+			return err
+		}
+		if difference := roundCents(wallet.Balance - computed); difference != adjustment.Amount {
+			return fmt.Errorf("wallet %d now differs from its history by %.2f, not %.2f: %w", wallet.ID, difference, adjustment.Amount, ErrAdjustmentStale)
+		}
+	default:
+		return fmt.Errorf("unknown adjustment source %q: %w", adjustment.Source, ErrInvalidAdjustment)
+	}
+
+	transaction := entity.Transaction{
+		Type:   entity.TransactionTypeAdjustment,
+		Amount: math.Abs(adjustment.Amount),
+	}
+	if adjustment.Amount > 0 {
+		transaction.RecipientID = wallet.ID
+	} else {
+		transaction.SenderID = wallet.ID
+	}
+	if err := repo.CreateTransaction(ctx, &transaction); err != nil {
+		return err
+	}
+	adjustment.TransactionID = transaction.ID
+	return nil
+}
+
+// TransferWalletOwnership moves a wallet to another user. The balance and
+// history stay with the wallet.
+func (s *walletService) TransferWalletOwnership(ctx context.Context, walletID int, newUserID int) (entity.Wallet, error) {
+	if newUserID <= 0 {
+		return entity.Wallet{}, fmt.Errorf("failed to transfer wallet ownership: %w: user ID must be positive", ErrInvalidOwner)
+	}
+
+	var wallet entity.Wallet
+	err := s.walletRepo.Transaction(ctx, func(repo IWalletRepository) error {
+		var err error
+		wallet, err = repo.GetWalletForUpdate(ctx, walletID)
+		if err != nil {
+			return err
+		}
+		if wallet.ID == 0 {
+			return fmt.Errorf("wallet %d: %w", walletID, ErrWalletNotFound)
+		}
+		if wallet.Internal {
+			return fmt.Errorf("%w: internal wallets cannot change owner", ErrInvalidOwner)
+		}
+		if wallet.UserID == newUserID {
+			return fmt.Errorf("%w: wallet already belongs to user %d", ErrInvalidOwner, newUserID)
+		}
+
+		if err := repo.SetWalletOwner(ctx, wallet.ID, newUserID); err != nil {
+			return err
+		}
+		wallet.UserID = newUserID
+		return nil
+	})
+	if err != nil {
+		return entity.Wallet{}, fmt.Errorf("failed to transfer wallet ownership: %w", err)
+	}
+	return wallet, nil
+}
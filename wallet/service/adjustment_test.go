@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/susilo001/simple-wallet-system/wallet/entity"
+)
+
+func TestReviewBalanceAdjustmentFourEyes(t *testing.T) {
+	tests := []struct {
+		name       string
+		adjustment entity.BalanceAdjustment
+		reviewerID int
+		wantErr    error
+	}{
+		{
+			name:       "proposer reviews",
+			adjustment: entity.BalanceAdjustment{ID: 1, Source: entity.AdjustmentSourceManual, Status: entity.AdjustmentStatusPending, ProposedBy: "user:5", ProposedByUserID: 5},
+			reviewerID: 5,
+			wantErr:    ErrSelfReview,
+		},
+		{
+			name:       "another user reviews",
+			adjustment: entity.BalanceAdjustment{ID: 1, Source: entity.AdjustmentSourceManual, Status: entity.AdjustmentStatusPending, ProposedBy: "user:5", ProposedByUserID: 5},
+			reviewerID: 6,
+		},
+		{
+			name:       "proposal of the reconciliation job",
+			adjustment: entity.BalanceAdjustment{ID: 1, Source: entity.AdjustmentSourceReconciliation, Status: entity.AdjustmentStatusPending, ProposedBy: "reconciliation"},
+			reviewerID: 6,
+		},
+		{
+			name:       "manual proposal without proposer user ID",
+			adjustment: entity.BalanceAdjustment{ID: 1, Source: entity.AdjustmentSourceManual, Status: entity.AdjustmentStatusPending, ProposedBy: "user:5/api-key:2"},
+			reviewerID: 5,
+			wantErr:    ErrInvalidAdjustment,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeWalletRepository{adjustments: map[int]entity.BalanceAdjustment{1: tt.adjustment}}
+			s := &walletService{walletRepo: repo}
+
+			// Rejecting checks the reviewer without touching the wallet.
+			got, err := s.ReviewBalanceAdjustment(context.Background(), 1, false, tt.reviewerID)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ReviewBalanceAdjustment() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReviewBalanceAdjustment() error = %v", err)
+			}
+			if got.Status != entity.AdjustmentStatusRejected || got.ReviewedByUserID != tt.reviewerID {
+				t.Errorf("adjustment = %s reviewed by user %d, want REJECTED by user %d", got.Status, got.ReviewedByUserID, tt.reviewerID)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/susilo001/simple-wallet-system/wallet/entity"
+)
+
+const (
+	DefaultAuditPageSize = 100
+	MaxAuditPageSize     = 1000
+)
+
+// auditVerifyBatchSize is how many entries the verifier reads at a time.
+const auditVerifyBatchSize = 500
+
+// AuditFilter selects audit entries. Zero fields do not filter.
+type AuditFilter struct {
+	Actor     string
+	Action    string
+	Target    string
+	RequestID string
+	From      time.Time
+	To        time.Time
+	// AfterSequence pages through the log: only entries with a greater
+	// sequence are returned.
+	AfterSequence int64
+	Limit         int
+}
+
+// AuditVerification is the result of checking the audit hash chain.
+type AuditVerification struct {
+	Entries int64
+	Valid   bool
+	// BrokenAt is the sequence of the first entry that fails the check.
+	BrokenAt int64
+	Problem  string
+}
+
+type IAuditService interface {
+	Record(ctx context.Context, entry entity.AuditEntry) (entity.AuditEntry, error)
+	ListAuditEntries(ctx context.Context, filter AuditFilter) ([]entity.AuditEntry, error)
+	VerifyAuditLog(ctx context.Context) (AuditVerification, error)
+}
+
+type IAuditRepository interface {
+	Transaction(ctx context.Context, fn func(repo IAuditRepository) error) error
+	// GetAuditChainHeadForUpdate locks the chain head, creating it first if
+	// the log is empty.
+	GetAuditChainHeadForUpdate(ctx context.Context) (entity.AuditChainHead, error)
+	GetAuditChainHead(ctx context.Context) (entity.AuditChainHead, error)
+	SaveAuditChainHead(ctx context.Context, head *entity.AuditChainHead) error
+	CreateAuditEntry(ctx context.Context, entry *entity.AuditEntry) error
+	GetAuditEntries(ctx context.Context, filter AuditFilter) ([]entity.AuditEntry, error)
+}
+
+type auditService struct {
+	auditRepo IAuditRepository
+}
+
+func NewAuditService(auditRepo IAuditRepository) IAuditService {
+	return &auditService{auditRepo: auditRepo}
+}
+
+// Record appends entry to the audit log, linking it to the current head of
+// the chain.
+func (s *auditService) Record(ctx context.Context, entry entity.AuditEntry) (entity.AuditEntry, error) {
+	err := s.auditRepo.Transaction(ctx, func(repo IAuditRepository) error {
+		head, err := repo.GetAuditChainHeadForUpdate(ctx)
+		if err != nil {
+			return err
+		}
+
+		entry.ID = 0
+		entry.Sequence = head.Sequence + 1
+		entry.PrevHash = head.Hash
+		// The database keeps microseconds; hash what will be read back.
+		entry.CreatedAt = time.Now().UTC().Truncate(time.Microsecond)
+		entry.Hash = auditHash(entry)
+		if err := repo.CreateAuditEntry(ctx, &entry); err != nil {
+			return err
+		}
+
+		head.Sequence = entry.Sequence
+		head.Hash = entry.Hash
+		return repo.SaveAuditChainHead(ctx, &head)
+	})
+	if err != nil {
+		return entity.AuditEntry{}, fmt.Errorf("failed to record audit entry: %w", err)
+	}
+	return entry, nil
+}
+
+func (s *auditService) ListAuditEntries(ctx context.Context, filter AuditFilter) ([]entity.AuditEntry, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultAuditPageSize
+	}
+	if filter.Limit > MaxAuditPageSize {
+		filter.Limit = MaxAuditPageSize
+	}
+
+	entries, err := s.auditRepo.GetAuditEntries(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit entries: %w", err)
+	}
+	return entries, nil
+}
+
+// VerifyAuditLog walks the whole log in sequence order and checks that the
+// sequence has no gaps, that every entry links to the hash of the one
+// before it, that every hash matches the entry's content and that the last
+// entry is the chain head.
+func (s *auditService) VerifyAuditLog(ctx context.Context) (AuditVerification, error) {
+	head, err := s.auditRepo.GetAuditChainHead(ctx)
+	if err != nil {
+		return AuditVerification{}, fmt.Errorf("failed to verify audit log: %w", err)
+	}
+
+	var result AuditVerification
+	var last entity.AuditEntry
+	for {
+		entries, err := s.auditRepo.GetAuditEntries(ctx, AuditFilter{AfterSequence: last.Sequence, Limit: auditVerifyBatchSize})
+		if err != nil {
+			return AuditVerification{}, fmt.Errorf("failed to verify audit log: %w", err)
+		}
+
+		for _, entry := range entries {
+			result.Entries++
+			switch {
+			case entry.Sequence != last.Sequence+1:
+				return broken(result, last.Sequence+1, fmt.Sprintf("entry %d is missing", last.Sequence+1)), nil
+			case entry.PrevHash != last.Hash:
+				return broken(result, entry.Sequence, "previous hash does not match the entry before it"), nil
+			case entry.Hash != auditHash(entry):
+				return broken(result, entry.Sequence, "hash does not match the entry's content"), nil
+			}
+			last = entry
+		}
+		if len(entries) < auditVerifyBatchSize {
+			break
+		}
+	}
+
+	if last.Sequence != head.Sequence || last.Hash != head.Hash {
+		return broken(result, last.Sequence+1, fmt.Sprintf("log ends at entry %d but the chain head is at entry %d", last.Sequence, head.Sequence)), nil
+	}
+	result.Valid = true
+	return result, nil
+}
+
+func broken(result AuditVerification, sequence int64, problem string) AuditVerification {
+	result.BrokenAt = sequence
+	result.Problem = problem
+	return result
+}
+
+// auditHash hashes every field of an entry except ID and Hash. Fields are
+// length-prefixed so that moving text from one field to the next changes
+// the hash.
+func auditHash(entry entity.AuditEntry) string {
+	h := sha256.New()
+	for _, field := range []string{
+		entry.PrevHash,
+		strconv.FormatInt(entry.Sequence, 10),
+		entry.CreatedAt.UTC().Format(time.RFC3339Nano),
+		entry.Actor,
+		entry.Action,
+		entry.Target,
+		entry.Request,
+		entry.Before,
+		entry.After,
+		entry.Outcome,
+		entry.Error,
+		entry.RequestID,
+	} {
+		fmt.Fprintf(h, "%d:%s\n", len(field), field)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
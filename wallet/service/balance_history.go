@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/susilo001/simple-wallet-system/wallet/entity"
+	"github.com/susilo001/simple-wallet-system/wallet/logging"
+)
+
+// MaxBalanceHistoryDays caps the number of points in one balance history.
+const MaxBalanceHistoryDays = 366
+
+// snapshotSettleDelay keeps the snapshot worker from snapshotting a midnight
+// while transactions started just before it may still be committing.
+const snapshotSettleDelay = 5 * time.Minute
+
+// BalancePoint is the balance of a wallet at the end of Date (UTC).
+type BalancePoint struct {
+	Date    time.Time
+	Balance float64
+}
+
+// BalanceCheck compares the stored balance of a wallet with the balance
+// derived from its transaction history.
+type BalanceCheck struct {
+	WalletID        int
+	LiveBalance     float64
+	ComputedBalance float64
+	Difference      float64
+	Consistent      bool
+	CheckedAt       time.Time
+}
+
+type BalanceHistory struct {
+	WalletID int
+	Points   []BalancePoint
+	Check    BalanceCheck
+}
+
+// GetBalanceAsOf returns the balance of a wallet at the given time, derived
+// from every transaction created before it.
+func (s *walletService) GetBalanceAsOf(ctx context.Context, walletID int, at time.Time) (float64, error) {
+	at = at.UTC()
+	if at.After(time.Now()) {
+		return 0, fmt.Errorf("failed to get balance as of %s: %w: time is in the future", at.Format(time.RFC3339), ErrInvalidPeriod)
+	}
+	if _, err := s.getExistingWallet(ctx, walletID); err != nil {
+		return 0, fmt.Errorf("failed to get balance as of %s: %w", at.Format(time.RFC3339), err)
+	}
+
+	balance, err := balanceAt(ctx, s.walletRepo, walletID, at)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get balance as of %s: %w", at.Format(time.RFC3339), err)
+	}
+	return balance, nil
+}
+
+// GetBalanceHistory returns the end-of-day balance of a wallet for every UTC
+// day from the day of from to the day of to, both included, together with a
+// check of the derived current balance against the stored one.
+func (s *walletService) GetBalanceHistory(ctx context.Context, walletID int, from time.Time, to time.Time) (BalanceHistory, error) {
+	start, end := startOfDay(from.UTC()), startOfDay(to.UTC()).AddDate(0, 0, 1)
+	if tomorrow := startOfDay(time.Now().UTC()).AddDate(0, 0, 1); end.After(tomorrow) {
+		end = tomorrow
+	}
+	if !end.After(start) {
+		return BalanceHistory{}, fmt.Errorf("failed to get balance history: %w: period end must not be before its start", ErrInvalidPeriod)
+	}
+	if days := int(end.Sub(start).Hours() / 24); days > MaxBalanceHistoryDays {
+		return BalanceHistory{}, fmt.Errorf("failed to get balance history: %w: period must not exceed %d days", ErrInvalidPeriod, MaxBalanceHistoryDays)
+	}
+
+	check, err := s.VerifyBalance(ctx, walletID)
+	if err != nil {
+		return BalanceHistory{}, fmt.Errorf("failed to get balance history: %w", err)
+	}
+
+	balance, err := balanceAt(ctx, s.walletRepo, walletID, start)
+	if err != nil {
+		return BalanceHistory{}, fmt.Errorf("failed to get balance history: %w", err)
+	}
+	transactions, err := s.walletRepo.GetTransactionsBetween(ctx, walletID, start, end)
+	if err != nil {
+		return BalanceHistory{}, fmt.Errorf("failed to get balance history: %w", err)
+	}
+
+	history := BalanceHistory{WalletID: walletID, Check: check}
+	next := 0
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		dayEnd := day.AddDate(0, 0, 1)
+		for ; next < len(transactions) && transactions[next].CreatedAt.Before(dayEnd); next++ {
+			balance = roundCents(balance + signedAmount(transactions[next], walletID))
+		}
+		history.Points = append(history.Points, BalancePoint{Date: day, Balance: balance})
+	}
+	return history, nil
+}
+
+// VerifyBalance derives the current balance of a wallet from its history and
+// compares it with the stored balance. The wallet is locked meanwhile so no
+// transfer lands between the two reads.
+func (s *walletService) VerifyBalance(ctx context.Context, walletID int) (BalanceCheck, error) {
+	var check BalanceCheck
+	err := s.walletRepo.Transaction(ctx, func(repo IWalletRepository) error {
+		wallet, err := repo.GetWalletForUpdate(ctx, walletID)
+		if err != nil {
+			return err
+		}
+		if wallet.ID == 0 {
+			return fmt.Errorf("wallet %d: %w", walletID, ErrWalletNotFound)
+		}
+
+		now := time.Now().UTC()
+		computed, err := balanceAt(ctx, repo, walletID, now)
+		if err != nil {
+			return err
+		}
+		check = BalanceCheck{
+			WalletID:        walletID,
+			LiveBalance:     wallet.Balance,
+			ComputedBalance: computed,
+			Difference:      roundCents(wallet.Balance - computed),
+			CheckedAt:       now,
+		}
+		check.Consistent = check.Difference == 0
+		return nil
+	})
+	if err != nil {
+		return BalanceCheck{}, fmt.Errorf("failed to verify balance: %w", err)
+	}
+	return check, nil
+}
+
+// TakeBalanceSnapshots stores the balance of every wallet as of at, skipping
+// wallets that already have a snapshot for that time.
+func (s *walletService) TakeBalanceSnapshots(ctx context.Context, at time.Time) (int, error) {
+	at = at.UTC()
+	wallets, err := s.walletRepo.GetAllWallets(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to take balance snapshots: %w", err)
+	}
+
+	count := 0
+	for _, wallet := range wallets {
+		latest, err := s.walletRepo.GetLatestSnapshotAtOrBefore(ctx, wallet.ID, at)
+		if err != nil {
+			return count, fmt.Errorf("failed to take balance snapshots: %w", err)
+		}
+		if latest.TakenAt.Equal(at) {
+			continue
+		}
+		net, err := s.walletRepo.GetNetAmountBetween(ctx, wallet.ID, latest.TakenAt, at)
+		if err != nil {
+			return count, fmt.Errorf("failed to take balance snapshots: %w", err)
+		}
+
+		snapshot := entity.BalanceSnapshot{WalletID: wallet.ID, TakenAt: at, Balance: roundCents(latest.Balance + net)}
+		if err := s.walletRepo.CreateBalanceSnapshot(ctx, &snapshot); err != nil {
+			return count, fmt.Errorf("failed to take balance snapshots: %w", err)
+		}
+		count++
+	}
+	return count, nil
+}
+
+// RunBalanceSnapshots snapshots every wallet at the most recent UTC midnight
+// every interval until ctx is cancelled.
+func (s *walletService) RunBalanceSnapshots(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		at := startOfDay(time.Now().UTC().Add(-snapshotSettleDelay))
+		runCtx := logging.WithRequestID(ctx, logging.NewRequestID())
+		if count, err := s.TakeBalanceSnapshots(runCtx, at); err != nil {
+			s.logger.ErrorContext(runCtx, "Error taking balance snapshots", "error", err)
+		} else if count > 0 {
+			s.logger.InfoContext(runCtx, "Took balance snapshots", "count", count, "as_of", at)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// balanceAt derives the balance of a wallet from the latest snapshot at or
+// before at plus every transaction created since then and before at.
+func balanceAt(ctx context.Context, repo IWalletRepository, walletID int, at time.Time) (float64, error) {
+	snapshot, err := repo.GetLatestSnapshotAtOrBefore(ctx, walletID, at)
+	if err != nil {
+		return 0, err
+	}
+	net, err := repo.GetNetAmountBetween(ctx, walletID, snapshot.TakenAt, at)
+	if err != nil {
+		return 0, err
+	}
+	return roundCents(snapshot.Balance + net), nil
+}
+
+// signedAmount is the effect of a transaction on the balance of a wallet:
+// positive when the wallet received it, negative when it sent it.
+func signedAmount(transaction entity.Transaction, walletID int) float64 {
+	var amount float64
+	if transaction.RecipientID == walletID {
+		amount += transaction.Amount
+	}
+	if transaction.SenderID == walletID {
+		amount -= transaction.Amount
+	}
+	return amount
+}
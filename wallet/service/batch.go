@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/susilo001/simple-wallet-system/wallet/entity"
+	"github.com/susilo001/simple-wallet-system/wallet/metrics"
+)
+
+// MaxBatchItems caps the number of transfers in one batch.
+const MaxBatchItems = 500
+
+const (
+	BatchItemSucceeded  = "SUCCEEDED"
+	BatchItemFailed     = "FAILED"
+	BatchItemRolledBack = "ROLLED_BACK"
+)
+
+type BatchTransferItem struct {
+	SenderID    int
+	RecipientID int
+	Amount      float64
+}
+
+type BatchItemResult struct {
+	Index         int
+	Status        string
+	TransactionID int
+	Error         error
+}
+
+type BatchResult struct {
+	Batch entity.TransferBatch
+	Items []BatchItemResult
+}
+
+// BatchTransfer executes items, which must all share the same sender or the
+// same recipient. In atomic mode either every transfer is applied or none
+// is, and the batch is FAILED if any item fails. In best-effort mode each
+// item is applied on its own and the batch is COMPLETED, PARTIAL or FAILED
+// depending on how many succeeded. An idempotency key in ctx is applied per
+// item as "<key>:<index>".
+func (s *walletService) BatchTransfer(ctx context.Context, items []BatchTransferItem, atomic bool) (BatchResult, error) {
+	if err := validateBatch(items); err != nil {
+		return BatchResult{}, fmt.Errorf("failed to batch transfer: %w", err)
+	}
+
+	batch := entity.TransferBatch{
+		Mode:      entity.BatchModeBestEffort,
+		Status:    entity.BatchStatusProcessing,
+		ItemCount: len(items),
+	}
+	if atomic {
+		batch.Mode = entity.BatchModeAtomic
+	}
+	for _, item := range items {
+		batch.TotalAmount += item.Amount
+	}
+	batch.TotalAmount = roundCents(batch.TotalAmount)
+	if err := s.walletRepo.CreateTransferBatch(ctx, &batch); err != nil {
+		return BatchResult{}, fmt.Errorf("failed to batch transfer: %w", err)
+	}
+
+	var results []BatchItemResult
+	if atomic {
+		results = s.runAtomicBatch(ctx, batch.ID, items)
+	} else {
+		results = s.runBestEffortBatch(ctx, batch.ID, items)
+	}
+
+	for _, result := range results {
+		if result.Status == BatchItemSucceeded {
+			batch.SucceededCount++
+		}
+	}
+	switch batch.SucceededCount {
+	case len(items):
+		batch.Status = entity.BatchStatusCompleted
+	case 0:
+		batch.Status = entity.BatchStatusFailed
+	default:
+		batch.Status = entity.BatchStatusPartial
+	}
+	if err := s.walletRepo.UpdateTransferBatch(ctx, &batch); err != nil {
+		return BatchResult{}, fmt.Errorf("failed to batch transfer: %w", err)
+	}
+	return BatchResult{Batch: batch, Items: results}, nil
+}
+
+func (s *walletService) GetTransferBatch(ctx context.Context, batchID int) (entity.TransferBatch, []entity.Transaction, error) {
+	batch, err := s.walletRepo.GetTransferBatchByID(ctx, batchID)
+	if err != nil {
+		return entity.TransferBatch{}, nil, fmt.Errorf("failed to get transfer batch: %w", err)
+	}
+	if batch.ID == 0 {
+		return entity.TransferBatch{}, nil, fmt.Errorf("failed to get transfer batch: batch %d: %w", batchID, ErrBatchNotFound)
+	}
+	transactions, err := s.walletRepo.GetTransactionsByBatchID(ctx, batchID)
+	if err != nil {
+		return entity.TransferBatch{}, nil, fmt.Errorf("failed to get transfer batch: %w", err)
+	}
+	return batch, transactions, nil
+}
+
+func (s *walletService) runAtomicBatch(ctx context.Context, batchID int, items []BatchTransferItem) []BatchItemResult {
+	results := make([]BatchItemResult, len(items))
+	for i := range results {
+		results[i] = BatchItemResult{Index: i, Status: BatchItemRolledBack}
+	}
+
+	// An atomic batch cannot be held in part, so an item that would need
+	// review fails the whole batch.
+	for i, item := range items {
+		if err := s.assessTransfer(ctx, item.SenderID, item.RecipientID, item.Amount, batchItemKey(ctx, i), false); err != nil {
+			results[i].Status = BatchItemFailed
+			results[i].Error = err
+			return results
+		}
+	}
+
+	levels, err := s.lookupKYCLevels(ctx, batchWalletIDs(items)...)
+	if err != nil {
+		for i := range results {
+			results[i].Status = BatchItemFailed
+			results[i].Error = err
+		}
+		return results
+	}
+
+	failed := -1
+	var failure error
+	err = s.walletRepo.Transaction(ctx, func(repo IWalletRepository) error {
+		// Lock every wallet up front in ID order; locking pair by pair could
+		// deadlock against another batch touching the same wallets.
+		for _, id := range batchWalletIDs(items) {
+			if _, err := repo.GetWalletForUpdate(ctx, id); err != nil {
+				return err
+			}
+		}
+
+		for i, item := range items {
+			transaction, err := s.applyTransfer(ctx, repo, item.SenderID, item.RecipientID, item.Amount, batchItemKey(ctx, i), batchID, levels)
+			if err != nil {
+				failed, failure = i, err
+				return err
+			}
+			results[i].TransactionID = transaction.ID
+		}
+		return nil
+	})
+
+	if failed >= 0 {
+		recordTransfer(metrics.TransferBatch, items[failed].Amount, failure)
+	}
+	if err != nil {
+		for i := range results {
+			results[i].TransactionID = 0
+		}
+		if failed >= 0 {
+			results[failed].Status = BatchItemFailed
+			results[failed].Error = failure
+		} else {
+			// The batch failed before any item ran, e.g. a wallet is missing.
+			for i := range results {
+				results[i].Status = BatchItemFailed
+				results[i].Error = err
+			}
+		}
+		return results
+	}
+
+	for i := range results {
+		results[i].Status = BatchItemSucceeded
+		recordTransfer(metrics.TransferBatch, items[i].Amount, nil)
+	}
+	return results
+}
+
+func (s *walletService) runBestEffortBatch(ctx context.Context, batchID int, items []BatchTransferItem) []BatchItemResult {
+	results := make([]BatchItemResult, len(items))
+	for i, item := range items {
+		results[i].Index = i
+		if err := s.assessTransfer(ctx, item.SenderID, item.RecipientID, item.Amount, batchItemKey(ctx, i), true); err != nil {
+			results[i].Status = BatchItemFailed
+			results[i].Error = err
+			continue
+		}
+		levels, err := s.lookupKYCLevels(ctx, item.SenderID, item.RecipientID)
+		if err == nil {
+			err = s.walletRepo.Transaction(ctx, func(repo IWalletRepository) error {
+				transaction, err := s.applyTransfer(ctx, repo, item.SenderID, item.RecipientID, item.Amount, batchItemKey(ctx, i), batchID, levels)
+				results[i].TransactionID = transaction.ID
+				return err
+			})
+		}
+		recordTransfer(metrics.TransferBatch, item.Amount, err)
+		if err != nil {
+			results[i].Status = BatchItemFailed
+			results[i].TransactionID = 0
+			results[i].Error = err
+			continue
+		}
+		results[i].Status = BatchItemSucceeded
+	}
+	return results
+}
+
+func validateBatch(items []BatchTransferItem) error {
+	if len(items) == 0 {
+		return fmt.Errorf("%w: at least one item is required", ErrInvalidBatch)
+	}
+	if len(items) > MaxBatchItems {
+		return fmt.Errorf("%w: at most %d items are allowed", ErrInvalidBatch, MaxBatchItems)
+	}
+
+	sameSender, sameRecipient := true, true
+	for i, item := range items {
+		if item.Amount <= 0 {
+			return fmt.Errorf("%w: item %d: %v", ErrInvalidBatch, i, ErrInvalidAmount)
+		}
+		if item.SenderID == item.RecipientID {
+			return fmt.Errorf("%w: item %d: %v", ErrInvalidBatch, i, ErrSameWallet)
+		}
+		sameSender = sameSender && item.SenderID == items[0].SenderID
+		sameRecipient = sameRecipient && item.RecipientID == items[0].RecipientID
+	}
+	if !sameSender && !sameRecipient {
+		return fmt.Errorf("%w: items must share either the sender or the recipient", ErrInvalidBatch)
+	}
+	return nil
+}
+
+func batchWalletIDs(items []BatchTransferItem) []int {
+	seen := make(map[int]bool)
+	var ids []int
+	for _, item := range items {
+		for _, id := range []int{item.SenderID, item.RecipientID} {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+func batchItemKey(ctx context.Context, index int) *string {
+	key := idempotencyKeyFrom(ctx)
+	if key == nil {
+		return nil
+	}
+	itemKey := fmt.Sprintf("%s:%d", *key, index)
+	return &itemKey
+}
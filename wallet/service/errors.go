@@ -0,0 +1,62 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrWalletNotFound      = errors.New("wallet not found")
+	ErrInvalidAmount       = errors.New("amount must be greater than zero")
+	ErrSameWallet          = errors.New("sender and recipient must be different wallets")
+	ErrInsufficientBalance = errors.New("insufficient balance")
+	ErrLimitExceeded       = errors.New("limit exceeded")
+	ErrInvalidPeriod       = errors.New("invalid period")
+
+	ErrWalletFrozen        = errors.New("wallet is frozen")
+	ErrWalletClosed        = errors.New("wallet is closed")
+	ErrInvalidWalletStatus = errors.New("invalid wallet status")
+	ErrWalletStatusChange  = errors.New("wallet cannot change to the requested status")
+
+	ErrScheduleNotFound    = errors.New("scheduled transfer not found")
+	ErrInvalidSchedule     = errors.New("invalid schedule")
+	ErrScheduleStateChange = errors.New("scheduled transfer cannot change to the requested state")
+
+	ErrInvalidBatch  = errors.New("invalid batch")
+	ErrBatchNotFound = errors.New("transfer batch not found")
+
+	ErrPaymentRequestNotFound = errors.New("payment request not found")
+	ErrPaymentRequestClosed   = errors.New("payment request is no longer pending")
+	ErrPaymentRequestExpired  = errors.New("payment request has expired")
+	ErrNotPaymentRequestParty = errors.New("wallet is not a party to this payment request")
+
+	ErrInvalidAdjustment         = errors.New("invalid balance adjustment")
+	ErrAdjustmentNotFound        = errors.New("balance adjustment not found")
+	ErrAdjustmentClosed          = errors.New("balance adjustment is no longer pending")
+	ErrAdjustmentStale           = errors.New("balance adjustment no longer matches the wallet")
+	ErrSelfReview                = errors.New("balance adjustment must be reviewed by someone other than its proposer")
+	ErrInvalidOwner              = errors.New("invalid wallet owner")
+	ErrReconciliationRunNotFound = errors.New("reconciliation run not found")
+
+	ErrTransferHeld         = errors.New("transfer held for review")
+	ErrTransferBlocked      = errors.New("transfer blocked by risk checks")
+	ErrHeldTransferNotFound = errors.New("held transfer not found")
+	ErrHeldTransferClosed   = errors.New("held transfer is no longer pending")
+)
+
+// LimitExceededError reports which wallet limit rejected an operation.
+// It matches ErrLimitExceeded with errors.Is.
+type LimitExceededError struct {
+	WalletID  int
+	Limit     string
+	Max       float64
+	Attempted float64
+}
+
+func (e *LimitExceededError) Error() string {
+	return fmt.Sprintf("limit exceeded: %s on wallet %d (max %.2f, attempted %.2f)", e.Limit, e.WalletID, e.Max, e.Attempted)
+}
+
+func (e *LimitExceededError) Is(target error) bool {
+	return target == ErrLimitExceeded
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/susilo001/simple-wallet-system/wallet/entity"
+)
+
+// fakeWalletRepository serves the fee rules, limits, outgoing totals,
+// payment requests, schedules and adjustments the checks read. Its
+// transactions are not rolled back. Calling any other method panics.
+type fakeWalletRepository struct {
+	IWalletRepository
+	feeRules     []entity.FeeRule
+	walletLimits map[int]entity.WalletLimit
+	tierLimits   map[string]entity.WalletLimit
+	// outgoing holds the sender's transfers, newest last.
+	outgoing        []fakeOutgoing
+	paymentRequests *fakePaymentRequestRepository
+	schedules       map[int]entity.ScheduledTransfer
+	adjustments     map[int]entity.BalanceAdjustment
+}
+
+type fakeOutgoing struct {
+	at     time.Time
+	amount float64
+}
+
+func (r *fakeWalletRepository) Transaction(ctx context.Context, fn func(repo IWalletRepository) error) error {
+	return fn(r)
+}
+
+func (r *fakeWalletRepository) GetFeeRules(ctx context.Context) ([]entity.FeeRule, error) {
+	return r.feeRules, nil
+}
+
+func (r *fakeWalletRepository) GetLimitByWalletID(ctx context.Context, walletID int) (entity.WalletLimit, error) {
+	return r.walletLimits[walletID], nil
+}
+
+func (r *fakeWalletRepository) GetLimitByTier(ctx context.Context, tier string) (entity.WalletLimit, error) {
+	return r.tierLimits[tier], nil
+}
+
+func (r *fakeWalletRepository) SumOutgoing(ctx context.Context, walletID int, since time.Time) (float64, int, error) {
+	var total float64
+	count := 0
+	for _, transfer := range r.outgoing {
+		if !transfer.at.Before(since) {
+			total += transfer.amount
+			count++
+		}
+	}
+	return total, count, nil
+}
+
+func (r *fakeWalletRepository) GetPaymentRequestByHeldTransferID(ctx context.Context, heldTransferID int) (entity.PaymentRequest, error) {
+	for _, request := range r.paymentRequests.requests {
+		if request.HeldTransferID != nil && *request.HeldTransferID == heldTransferID {
+			return request, nil
+		}
+	}
+	return entity.PaymentRequest{}, nil
+}
+
+func (r *fakeWalletRepository) UpdatePaymentRequestStatus(ctx context.Context, request *entity.PaymentRequest, from string) (bool, error) {
+	return r.paymentRequests.UpdatePaymentRequestStatus(ctx, request, from)
+}
+
+func (r *fakeWalletRepository) GetScheduledTransferByHeldTransferID(ctx context.Context, heldTransferID int) (entity.ScheduledTransfer, error) {
+	for _, schedule := range r.schedules {
+		if schedule.HeldTransferID != nil && *schedule.HeldTransferID == heldTransferID {
+			return schedule, nil
+		}
+	}
+	return entity.ScheduledTransfer{}, nil
+}
+
+func (r *fakeWalletRepository) UpdateScheduledTransfer(ctx context.Context, schedule *entity.ScheduledTransfer) error {
+	r.schedules[schedule.ID] = *schedule
+	return nil
+}
+
+func (r *fakeWalletRepository) GetBalanceAdjustmentForUpdate(ctx context.Context, id int) (entity.BalanceAdjustment, error) {
+	return r.adjustments[id], nil
+}
+
+func (r *fakeWalletRepository) UpdateBalanceAdjustment(ctx context.Context, adjustment *entity.BalanceAdjustment) error {
+	r.adjustments[adjustment.ID] = *adjustment
+	return nil
+}
+
+// fakeKYCLevels only marks KYC caps as enabled; the levels come from the
+// kycLevelsByUser passed to the checks.
+type fakeKYCLevels struct {
+	KYCLevels
+}
+
+// fakeWalletService moves no money: every transfer fails with transferErr,
+// or succeeds if it is nil. Every wallet exists.
+type fakeWalletService struct {
+	IWalletService
+	transferErr error
+	transfers   int
+}
+
+func (s *fakeWalletService) GetWalletByID(ctx context.Context, id int) (entity.Wallet, error) {
+	return entity.Wallet{ID: id}, nil
+}
+
+func (s *fakeWalletService) Transfer(ctx context.Context, senderID int, recipientID int, amount float64) error {
+	s.transfers++
+	return s.transferErr
+}
+
+// fakePaymentRequestRepository keeps payment requests in memory.
+type fakePaymentRequestRepository struct {
+	IPaymentRequestRepository
+	requests map[int]entity.PaymentRequest
+}
+
+func (r *fakePaymentRequestRepository) GetPaymentRequestByID(ctx context.Context, id int) (entity.PaymentRequest, error) {
+	return r.requests[id], nil
+}
+
+func (r *fakePaymentRequestRepository) GetPaymentRequestByCode(ctx context.Context, code string) (entity.PaymentRequest, error) {
+	for _, request := range r.requests {
+		if request.Code != nil && *request.Code == code {
+			return request, nil
+		}
+	}
+	return entity.PaymentRequest{}, nil
+}
+
+func (r *fakePaymentRequestRepository) UpdatePaymentRequestStatus(ctx context.Context, request *entity.PaymentRequest, from string) (bool, error) {
+	if r.requests[request.ID].Status != from {
+		return false, nil
+	}
+	r.requests[request.ID] = *request
+	return true, nil
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/susilo001/simple-wallet-system/wallet/entity"
+)
+
+// FeeQuote is the fee breakdown of a transfer or withdrawal.
+type FeeQuote struct {
+	Amount        float64
+	RuleID        int
+	FlatFee       float64
+	PercentageFee float64
+	// CapAdjustment is what the rule's min/max fee added to (positive) or
+	// removed from (negative) flat plus percentage fee.
+	CapAdjustment float64
+	Fee           float64
+	TotalDebit    float64
+	Waived        bool
+	WaiverReason  string
+}
+
+func (s *walletService) QuoteTransfer(ctx context.Context, senderID int, recipientID int, amount float64) (FeeQuote, error) {
+	if amount <= 0 {
+		return FeeQuote{}, fmt.Errorf("failed to quote transfer: %w", ErrInvalidAmount)
+	}
+	if senderID == recipientID {
+		return FeeQuote{}, fmt.Errorf("failed to quote transfer: %w", ErrSameWallet)
+	}
+
+	sender, err := s.getExistingWallet(ctx, senderID)
+	if err != nil {
+		return FeeQuote{}, fmt.Errorf("failed to quote transfer: %w", err)
+	}
+	recipient, err := s.getExistingWallet(ctx, recipientID)
+	if err != nil {
+		return FeeQuote{}, fmt.Errorf("failed to quote transfer: %w", err)
+	}
+
+	quote, err := s.quoteFee(ctx, s.walletRepo, entity.FeeOperationTransfer, sender, &recipient, amount)
+	if err != nil {
+		return FeeQuote{}, fmt.Errorf("failed to quote transfer: %w", err)
+	}
+	return quote, nil
+}
+
+func (s *walletService) QuoteWithdrawal(ctx context.Context, walletID int, amount float64) (FeeQuote, error) {
+	if amount <= 0 {
+		return FeeQuote{}, fmt.Errorf("failed to quote withdrawal: %w", ErrInvalidAmount)
+	}
+
+	wallet, err := s.getExistingWallet(ctx, walletID)
+	if err != nil {
+		return FeeQuote{}, fmt.Errorf("failed to quote withdrawal: %w", err)
+	}
+
+	quote, err := s.quoteFee(ctx, s.walletRepo, entity.FeeOperationWithdrawal, wallet, nil, amount)
+	if err != nil {
+		return FeeQuote{}, fmt.Errorf("failed to quote withdrawal: %w", err)
+	}
+	return quote, nil
+}
+
+func (s *walletService) GetFeeRules(ctx context.Context) ([]entity.FeeRule, error) {
+	rules, err := s.walletRepo.GetFeeRules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fee rules: %w", err)
+	}
+	return rules, nil
+}
+
+// SaveFeeRule creates the rule when rule.ID is zero and replaces it otherwise.
+func (s *walletService) SaveFeeRule(ctx context.Context, rule entity.FeeRule) (entity.FeeRule, error) {
+	if rule.Operation != entity.FeeOperationTransfer && rule.Operation != entity.FeeOperationWithdrawal {
+		return entity.FeeRule{}, fmt.Errorf("failed to save fee rule: unknown operation %q", rule.Operation)
+	}
+	if rule.MinAmount < 0 || rule.MaxAmount < 0 || rule.FlatFee < 0 || rule.Percentage < 0 || rule.MinFee < 0 || rule.MaxFee < 0 {
+		return entity.FeeRule{}, fmt.Errorf("failed to save fee rule: values must not be negative")
+	}
+	if rule.MaxAmount > 0 && rule.MaxAmount <= rule.MinAmount {
+		return entity.FeeRule{}, fmt.Errorf("failed to save fee rule: max amount must be greater than min amount")
+	}
+	if rule.MaxFee > 0 && rule.MaxFee < rule.MinFee {
+		return entity.FeeRule{}, fmt.Errorf("failed to save fee rule: max fee must not be less than min fee")
+	}
+
+	if err := s.walletRepo.SaveFeeRule(ctx, &rule); err != nil {
+		return entity.FeeRule{}, fmt.Errorf("failed to save fee rule: %w", err)
+	}
+	return rule, nil
+}
+
+// quoteFee prices an operation paid by payer. recipient is nil for
+// withdrawals. Fees are waived whenever an internal wallet is involved.
+func (s *walletService) quoteFee(ctx context.Context, repo IWalletRepository, operation string, payer entity.Wallet, recipient *entity.Wallet, amount float64) (FeeQuote, error) {
+	quote := FeeQuote{Amount: amount, TotalDebit: amount}
+
+	if payer.Internal || (recipient != nil && recipient.Internal) {
+		quote.Waived = true
+		quote.WaiverReason = "internal wallet"
+		return quote, nil
+	}
+
+	rules, err := repo.GetFeeRules(ctx)
+	if err != nil {
+		return FeeQuote{}, err
+	}
+	rule, ok := matchFeeRule(rules, operation, payer.Tier, amount)
+	if !ok {
+		return quote, nil
+	}
+
+	quote.RuleID = rule.ID
+	quote.FlatFee = rule.FlatFee
+	quote.PercentageFee = roundCents(amount * rule.Percentage / 100)
+
+	fee := quote.FlatFee + quote.PercentageFee
+	capped := fee
+	if rule.MinFee > 0 && capped < rule.MinFee {
+		capped = rule.MinFee
+	}
+	if rule.MaxFee > 0 && capped > rule.MaxFee {
+		capped = rule.MaxFee
+	}
+	quote.CapAdjustment = roundCents(capped - fee)
+	quote.Fee = roundCents(capped)
+	quote.TotalDebit = roundCents(amount + quote.Fee)
+	return quote, nil
+}
+
+// matchFeeRule picks the active rule with the highest priority for the
+// operation. On equal priority a tier-specific rule beats a generic one.
+func matchFeeRule(rules []entity.FeeRule, operation string, tier string, amount float64) (entity.FeeRule, bool) {
+	var candidates []entity.FeeRule
+	for _, rule := range rules {
+		if !rule.Active || rule.Operation != operation {
+			continue
+		}
+		if rule.Tier != "" && rule.Tier != tier {
+			continue
+		}
+		if amount < rule.MinAmount || (rule.MaxAmount > 0 && amount >= rule.MaxAmount) {
+			continue
+		}
+		candidates = append(candidates, rule)
+	}
+	if len(candidates) == 0 {
+		return entity.FeeRule{}, false
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Priority != candidates[j].Priority {
+			return candidates[i].Priority > candidates[j].Priority
+		}
+		return candidates[i].Tier != "" && candidates[j].Tier == ""
+	})
+	return candidates[0], true
+}
+
+// postFee moves a charged fee from the payer to the house wallet and links
+// it to the transaction it was charged for. It must run inside the same
+// database transaction as the operation itself.
+func (s *walletService) postFee(ctx context.Context, repo IWalletRepository, payerID int, quote FeeQuote, relatedID int) error {
+	if quote.Fee <= 0 {
+		return nil
+	}
+	if err := repo.AddToBalance(ctx, s.houseWalletID, quote.Fee); err != nil {
+		return err
+	}
+	return repo.CreateTransaction(ctx, &entity.Transaction{
+		Type:        entity.TransactionTypeFee,
+		SenderID:    payerID,
+		RecipientID: s.houseWalletID,
+		Amount:      quote.Fee,
+		RelatedID:   relatedID,
+	})
+}
+
+func (s *walletService) getExistingWallet(ctx context.Context, id int) (entity.Wallet, error) {
+	wallet, err := s.walletRepo.GetWalletByID(ctx, id)
+	if err != nil {
+		return entity.Wallet{}, err
+	}
+	if wallet.ID == 0 {
+		return entity.Wallet{}, fmt.Errorf("wallet %d: %w", id, ErrWalletNotFound)
+	}
+	return wallet, nil
+}
+
+func roundCents(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/susilo001/simple-wallet-system/wallet/entity"
+)
+
+func TestMatchFeeRule(t *testing.T) {
+	rules := []entity.FeeRule{
+		{ID: 1, Operation: entity.FeeOperationTransfer, FlatFee: 2500, Active: true},
+		{ID: 2, Operation: entity.FeeOperationTransfer, Tier: "premium", Active: true},
+		{ID: 3, Operation: entity.FeeOperationTransfer, MinAmount: 1_000_000, Priority: 1, Active: true},
+		{ID: 4, Operation: entity.FeeOperationTransfer, MaxAmount: 10_000, Priority: 2, Active: true},
+		{ID: 5, Operation: entity.FeeOperationTransfer, Priority: 9, Active: false},
+		{ID: 6, Operation: entity.FeeOperationWithdrawal, FlatFee: 6500, Active: true},
+	}
+	tests := []struct {
+		name      string
+		operation string
+		tier      string
+		amount    float64
+		wantID    int
+	}{
+		{name: "generic rule", operation: entity.FeeOperationTransfer, tier: "basic", amount: 50_000, wantID: 1},
+		{name: "tier rule beats generic rule of equal priority", operation: entity.FeeOperationTransfer, tier: "premium", amount: 50_000, wantID: 2},
+		{name: "higher priority wins", operation: entity.FeeOperationTransfer, tier: "premium", amount: 2_000_000, wantID: 3},
+		{name: "min amount is inclusive", operation: entity.FeeOperationTransfer, tier: "basic", amount: 1_000_000, wantID: 3},
+		{name: "max amount is exclusive", operation: entity.FeeOperationTransfer, tier: "basic", amount: 10_000, wantID: 1},
+		{name: "below max amount", operation: entity.FeeOperationTransfer, tier: "basic", amount: 9_999, wantID: 4},
+		{name: "other operation", operation: entity.FeeOperationWithdrawal, tier: "basic", amount: 50_000, wantID: 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, ok := matchFeeRule(rules, tt.operation, tt.tier, tt.amount)
+			if !ok {
+				t.Fatalf("matchFeeRule() found no rule, want rule %d", tt.wantID)
+			}
+			if rule.ID != tt.wantID {
+				t.Errorf("matchFeeRule() = rule %d, want rule %d", rule.ID, tt.wantID)
+			}
+		})
+	}
+
+	if rule, ok := matchFeeRule(rules[4:5], entity.FeeOperationTransfer, "basic", 50_000); ok {
+		t.Errorf("matchFeeRule() = rule %d, want no rule when only an inactive one matches", rule.ID)
+	}
+}
+
+func TestQuoteFee(t *testing.T) {
+	customer := entity.Wallet{ID: 1, Tier: "basic"}
+	house := entity.Wallet{ID: 2, Internal: true}
+	tests := []struct {
+		name      string
+		rules     []entity.FeeRule
+		payer     entity.Wallet
+		recipient *entity.Wallet
+		amount    float64
+		want      FeeQuote
+	}{
+		{
+			name:   "no rule",
+			payer:  customer,
+			amount: 100_000,
+			want:   FeeQuote{Amount: 100_000, TotalDebit: 100_000},
+		},
+		{
+			name:   "flat plus percentage",
+			rules:  []entity.FeeRule{{ID: 1, Operation: entity.FeeOperationTransfer, FlatFee: 1000, Percentage: 0.5, Active: true}},
+			payer:  customer,
+			amount: 100_000,
+			want:   FeeQuote{Amount: 100_000, RuleID: 1, FlatFee: 1000, PercentageFee: 500, Fee: 1500, TotalDebit: 101_500},
+		},
+		{
+			name:   "raised to min fee",
+			rules:  []entity.FeeRule{{ID: 1, Operation: entity.FeeOperationTransfer, Percentage: 1, MinFee: 2500, Active: true}},
+			payer:  customer,
+			amount: 10_000,
+			want:   FeeQuote{Amount: 10_000, RuleID: 1, PercentageFee: 100, CapAdjustment: 2400, Fee: 2500, TotalDebit: 12_500},
+		},
+		{
+			name:   "lowered to max fee",
+			rules:  []entity.FeeRule{{ID: 1, Operation: entity.FeeOperationTransfer, Percentage: 1, MaxFee: 5000, Active: true}},
+			payer:  customer,
+			amount: 1_000_000,
+			want:   FeeQuote{Amount: 1_000_000, RuleID: 1, PercentageFee: 10_000, CapAdjustment: -5000, Fee: 5000, TotalDebit: 1_005_000},
+		},
+		{
+			name:   "percentage rounded to cents",
+			rules:  []entity.FeeRule{{ID: 1, Operation: entity.FeeOperationTransfer, Percentage: 0.33, Active: true}},
+			payer:  customer,
+			amount: 1001,
+			want:   FeeQuote{Amount: 1001, RuleID: 1, PercentageFee: 3.3, Fee: 3.3, TotalDebit: 1004.3},
+		},
+		{
+			name:      "waived for an internal recipient",
+			rules:     []entity.FeeRule{{ID: 1, Operation: entity.FeeOperationTransfer, FlatFee: 1000, Active: true}},
+			payer:     customer,
+			recipient: &house,
+			amount:    100_000,
+			want:      FeeQuote{Amount: 100_000, TotalDebit: 100_000, Waived: true, WaiverReason: "internal wallet"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &walletService{}
+			repo := &fakeWalletRepository{feeRules: tt.rules}
+			got, err := s.quoteFee(context.Background(), repo, entity.FeeOperationTransfer, tt.payer, tt.recipient, tt.amount)
+			if err != nil {
+				t.Fatalf("quoteFee() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("quoteFee() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
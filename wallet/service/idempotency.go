@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+
+	"github.com/susilo001/simple-wallet-system/wallet/entity"
+)
+
+type idempotencyKeyCtxKey struct{}
+
+// WithIdempotencyKey attaches an idempotency key to ctx. A money-moving
+// operation called with a key that was already used succeeds without
+// moving money again.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyCtxKey{}, key)
+}
+
+func idempotencyKeyFrom(ctx context.Context) *string {
+	key, ok := ctx.Value(idempotencyKeyCtxKey{}).(string)
+	if !ok || key == "" {
+		return nil
+	}
+	return &key
+}
+
+// findApplied returns the transaction recorded under key, or an empty
+// transaction if key is nil or unused.
+func findApplied(ctx context.Context, repo IWalletRepository, key *string) (entity.Transaction, error) {
+	if key == nil {
+		return entity.Transaction{}, nil
+	}
+	return repo.GetTransactionByIdempotencyKey(ctx, *key)
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/susilo001/simple-wallet-system/wallet/entity"
+)
+
+// KYCLevels looks up the KYC level the user service has verified for a
+// user.
+type KYCLevels interface {
+	GetKYCLevel(ctx context.Context, userID int) (string, error)
+}
+
+// kycLevelsByUser maps a user ID to the KYC level of that user.
+type kycLevelsByUser map[int]string
+
+// lookupKYCLevels asks the user service for the KYC levels of the owners of
+// the given wallets. It must be called before the database transaction is
+// opened so that locked wallet rows never wait on the user service; the
+// owner of a wallet does not change, so reading it unlocked is safe.
+// Missing and internal wallets are skipped.
+func (s *walletService) lookupKYCLevels(ctx context.Context, walletIDs ...int) (kycLevelsByUser, error) {
+	levels := kycLevelsByUser{}
+	if s.kycLevels == nil {
+		return levels, nil
+	}
+	for _, id := range walletIDs {
+		wallet, err := s.walletRepo.GetWalletByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if wallet.ID == 0 || wallet.Internal {
+			continue
+		}
+		if _, ok := levels[wallet.UserID]; ok {
+			continue
+		}
+		level, err := s.kycLevels.GetKYCLevel(ctx, wallet.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("KYC level of user %d: %w", wallet.UserID, err)
+		}
+		levels[wallet.UserID] = level
+	}
+	return levels, nil
+}
+
+// applyKYCCaps lowers the wallet's limits to the caps of its owner's KYC
+// level, taken from levels. A cap only ever tightens a limit: a wallet or
+// tier limit below the cap is kept, and a rule that is not enforced gets
+// the cap.
+func (s *walletService) applyKYCCaps(wallet entity.Wallet, levels kycLevelsByUser, limits *EffectiveLimits) error {
+	if s.kycLevels == nil || wallet.Internal {
+		return nil
+	}
+	level, ok := levels[wallet.UserID]
+	if !ok {
+		return fmt.Errorf("KYC level of user %d was not looked up", wallet.UserID)
+	}
+	// An unknown level must not lift every cap.
+	caps, ok := s.kycLimits[level]
+	if !ok {
+		return fmt.Errorf("no limits configured for KYC level %q", level)
+	}
+
+	limits.KYCLevel = level
+	capped := limits.Limit
+	capped.MaxSingleTransfer = capFloat(capped.MaxSingleTransfer, caps.MaxSingleTransfer)
+	capped.DailyOutgoing = capFloat(capped.DailyOutgoing, caps.DailyOutgoing)
+	capped.MonthlyOutgoing = capFloat(capped.MonthlyOutgoing, caps.MonthlyOutgoing)
+	capped.MaxBalance = capFloat(capped.MaxBalance, caps.MaxBalance)
+	capped.MaxTransfersPerHour = int(capFloat(float64(capped.MaxTransfersPerHour), float64(caps.MaxTransfersPerHour)))
+	if capped != limits.Limit && limits.Source == LimitSourceNone {
+		limits.Source = LimitSourceKYC
+	}
+	limits.Limit = capped
+	return nil
+}
+
+// capFloat returns the lower of limit and cap, where zero means no limit.
+func capFloat(limit float64, cap float64) float64 {
+	if cap > 0 && (limit == 0 || limit > cap) {
+		return cap
+	}
+	return limit
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/susilo001/simple-wallet-system/wallet/entity"
+)
+
+func TestApplyKYCCaps(t *testing.T) {
+	s := &walletService{
+		kycLevels: fakeKYCLevels{},
+		kycLimits: map[string]entity.WalletLimit{
+			"basic": {MaxSingleTransfer: 1000, DailyOutgoing: 5000, MaxTransfersPerHour: 5},
+		},
+	}
+	wallet := entity.Wallet{ID: 1, UserID: 7}
+	levels := kycLevelsByUser{7: "basic"}
+
+	t.Run("cap tightens a looser limit and fills unenforced ones", func(t *testing.T) {
+		limits := EffectiveLimits{Limit: entity.WalletLimit{ID: 1, MaxSingleTransfer: 2000, DailyOutgoing: 3000}, Source: LimitSourceWallet}
+		if err := s.applyKYCCaps(wallet, levels, &limits); err != nil {
+			t.Fatalf("applyKYCCaps() error = %v", err)
+		}
+		want := entity.WalletLimit{ID: 1, MaxSingleTransfer: 1000, DailyOutgoing: 3000, MaxTransfersPerHour: 5}
+		if limits.Limit != want || limits.Source != LimitSourceWallet || limits.KYCLevel != "basic" {
+			t.Errorf("applyKYCCaps() = %+v from %q at %q, want %+v from %q at %q", limits.Limit, limits.Source, limits.KYCLevel, want, LimitSourceWallet, "basic")
+		}
+	})
+
+	t.Run("caps alone are reported as the KYC source", func(t *testing.T) {
+		limits := EffectiveLimits{Source: LimitSourceNone}
+		if err := s.applyKYCCaps(wallet, levels, &limits); err != nil {
+			t.Fatalf("applyKYCCaps() error = %v", err)
+		}
+		if limits.Source != LimitSourceKYC {
+			t.Errorf("applyKYCCaps() source = %q, want %q", limits.Source, LimitSourceKYC)
+		}
+	})
+
+	t.Run("internal wallets are not capped", func(t *testing.T) {
+		limits := EffectiveLimits{Source: LimitSourceNone}
+		if err := s.applyKYCCaps(entity.Wallet{ID: 2, Internal: true}, nil, &limits); err != nil {
+			t.Fatalf("applyKYCCaps() error = %v", err)
+		}
+		if limits.Limit != (entity.WalletLimit{}) {
+			t.Errorf("applyKYCCaps() = %+v, want no limits", limits.Limit)
+		}
+	})
+
+	t.Run("level that was not looked up", func(t *testing.T) {
+		limits := EffectiveLimits{Source: LimitSourceNone}
+		if err := s.applyKYCCaps(wallet, kycLevelsByUser{}, &limits); err == nil {
+			t.Error("applyKYCCaps() error = nil, want an error")
+		}
+	})
+
+	t.Run("level without configured caps", func(t *testing.T) {
+		limits := EffectiveLimits{Source: LimitSourceNone}
+		if err := s.applyKYCCaps(wallet, kycLevelsByUser{7: "unknown"}, &limits); err == nil {
+			t.Error("applyKYCCaps() error = nil, want an error")
+		}
+	})
+}
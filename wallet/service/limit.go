@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/susilo001/simple-wallet-system/wallet/entity"
+)
+
+const (
+	LimitSourceWallet = "wallet"
+	LimitSourceTier   = "tier"
+	LimitSourceKYC    = "kyc"
+	LimitSourceNone   = "none"
+)
+
+// EffectiveLimits is the limit set that applies to a wallet together with
+// how much of each window has already been used.
+type EffectiveLimits struct {
+	Limit               entity.WalletLimit
+	Source              string
+	DailyOutgoingUsed   float64
+	MonthlyOutgoingUsed float64
+	TransfersLastHour   int
+	// KYCLevel is the owner's KYC level whose caps were applied, if any.
+	KYCLevel string
+}
+
+func (s *walletService) SetWalletTier(ctx context.Context, walletID int, tier string) error {
+	if tier == "" {
+		return fmt.Errorf("failed to set wallet tier: tier is required")
+	}
+	if err := s.walletRepo.SetWalletTier(ctx, walletID, tier); err != nil {
+		return fmt.Errorf("failed to set wallet tier: %w", err)
+	}
+	return nil
+}
+
+func (s *walletService) GetWalletLimits(ctx context.Context, walletID int) (EffectiveLimits, error) {
+	wallet, err := s.getExistingWallet(ctx, walletID)
+	if err != nil {
+		return EffectiveLimits{}, fmt.Errorf("failed to get wallet limits: %w", err)
+	}
+
+	levels, err := s.lookupKYCLevels(ctx, wallet.ID)
+	if err != nil {
+		return EffectiveLimits{}, fmt.Errorf("failed to get wallet limits: %w", err)
+	}
+	limits, err := s.resolveLimits(ctx, s.walletRepo, wallet, levels)
+	if err != nil {
+		return EffectiveLimits{}, fmt.Errorf("failed to get wallet limits: %w", err)
+	}
+
+	now := time.Now().UTC()
+	if limits.DailyOutgoingUsed, _, err = s.walletRepo.SumOutgoing(ctx, walletID, startOfDay(now)); err != nil {
+		return EffectiveLimits{}, fmt.Errorf("failed to get wallet limits: %w", err)
+	}
+	if limits.MonthlyOutgoingUsed, _, err = s.walletRepo.SumOutgoing(ctx, walletID, startOfMonth(now)); err != nil {
+		return EffectiveLimits{}, fmt.Errorf("failed to get wallet limits: %w", err)
+	}
+	if _, limits.TransfersLastHour, err = s.walletRepo.SumOutgoing(ctx, walletID, now.Add(-time.Hour)); err != nil {
+		return EffectiveLimits{}, fmt.Errorf("failed to get wallet limits: %w", err)
+	}
+	return limits, nil
+}
+
+// SetWalletLimits creates or replaces the limit set of a wallet or a tier.
+// Exactly one of limit.WalletID and limit.Tier must be set.
+func (s *walletService) SetWalletLimits(ctx context.Context, limit entity.WalletLimit) (entity.WalletLimit, error) {
+	if (limit.WalletID == 0) == (limit.Tier == "") {
+		return entity.WalletLimit{}, fmt.Errorf("failed to set wallet limits: exactly one of wallet ID or tier is required")
+	}
+	if limit.MaxSingleTransfer < 0 || limit.DailyOutgoing < 0 || limit.MonthlyOutgoing < 0 ||
+		limit.MaxTransfersPerHour < 0 || limit.MaxBalance < 0 {
+		return entity.WalletLimit{}, fmt.Errorf("failed to set wallet limits: limits must not be negative")
+	}
+
+	var existing entity.WalletLimit
+	var err error
+	if limit.WalletID != 0 {
+		existing, err = s.walletRepo.GetLimitByWalletID(ctx, limit.WalletID)
+	} else {
+		existing, err = s.walletRepo.GetLimitByTier(ctx, limit.Tier)
+	}
+	if err != nil {
+		return entity.WalletLimit{}, fmt.Errorf("failed to set wallet limits: %w", err)
+	}
+
+	limit.ID = existing.ID
+	limit.CreatedAt = existing.CreatedAt
+	if err := s.walletRepo.SaveLimit(ctx, &limit); err != nil {
+		return entity.WalletLimit{}, fmt.Errorf("failed to set wallet limits: %w", err)
+	}
+	return limit, nil
+}
+
+// resolveLimits returns the wallet's own limit set if it has one, falling
+// back to the limit set of its tier, capped by the KYC level of the
+// wallet's owner in levels.
+func (s *walletService) resolveLimits(ctx context.Context, repo IWalletRepository, wallet entity.Wallet, levels kycLevelsByUser) (EffectiveLimits, error) {
+	limits := EffectiveLimits{Source: LimitSourceNone}
+	limit, err := repo.GetLimitByWalletID(ctx, wallet.ID)
+	if err != nil {
+		return EffectiveLimits{}, err
+	}
+	if limit.ID != 0 {
+		limits = EffectiveLimits{Limit: limit, Source: LimitSourceWallet}
+	} else {
+		limit, err = repo.GetLimitByTier(ctx, wallet.Tier)
+		if err != nil {
+			return EffectiveLimits{}, err
+		}
+		if limit.ID != 0 {
+			limits = EffectiveLimits{Limit: limit, Source: LimitSourceTier}
+		}
+	}
+
+	if err := s.applyKYCCaps(wallet, levels, &limits); err != nil {
+		return EffectiveLimits{}, err
+	}
+	return limits, nil
+}
+
+// checkOutgoingLimits applies to the principal amount only; fees are not
+// counted. It must be called with the sender's wallet row locked so that
+// concurrent transfers cannot both pass the window checks.
+func (s *walletService) checkOutgoingLimits(ctx context.Context, repo IWalletRepository, sender entity.Wallet, amount float64, levels kycLevelsByUser) error {
+	limits, err := s.resolveLimits(ctx, repo, sender, levels)
+	if err != nil {
+		return err
+	}
+	limit := limits.Limit
+
+	if limit.MaxSingleTransfer > 0 && amount > limit.MaxSingleTransfer {
+		return &LimitExceededError{WalletID: sender.ID, Limit: "max_single_transfer", Max: limit.MaxSingleTransfer, Attempted: amount}
+	}
+
+	now := time.Now().UTC()
+	if limit.DailyOutgoing > 0 {
+		total, _, err := repo.SumOutgoing(ctx, sender.ID, startOfDay(now))
+		if err != nil {
+			return err
+		}
+		if total+amount > limit.DailyOutgoing {
+			return &LimitExceededError{WalletID: sender.ID, Limit: "daily_outgoing", Max: limit.DailyOutgoing, Attempted: total + amount}
+		}
+	}
+	if limit.MonthlyOutgoing > 0 {
+		total, _, err := repo.SumOutgoing(ctx, sender.ID, startOfMonth(now))
+		if err != nil {
+			return err
+		}
+		if total+amount > limit.MonthlyOutgoing {
+			return &LimitExceededError{WalletID: sender.ID, Limit: "monthly_outgoing", Max: limit.MonthlyOutgoing, Attempted: total + amount}
+		}
+	}
+	if limit.MaxTransfersPerHour > 0 {
+		_, count, err := repo.SumOutgoing(ctx, sender.ID, now.Add(-time.Hour))
+		if err != nil {
+			return err
+		}
+		if count+1 > limit.MaxTransfersPerHour {
+			return &LimitExceededError{WalletID: sender.ID, Limit: "max_transfers_per_hour", Max: float64(limit.MaxTransfersPerHour), Attempted: float64(count + 1)}
+		}
+	}
+	return nil
+}
+
+func checkMaxBalance(wallet entity.Wallet, limit entity.WalletLimit, credit float64) error {
+	if limit.MaxBalance > 0 && wallet.Balance+credit > limit.MaxBalance {
+		return &LimitExceededError{WalletID: wallet.ID, Limit: "max_balance", Max: limit.MaxBalance, Attempted: wallet.Balance + credit}
+	}
+	return nil
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func startOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
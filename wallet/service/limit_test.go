@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/susilo001/simple-wallet-system/wallet/entity"
+)
+
+func TestResolveLimits(t *testing.T) {
+	walletLimit := entity.WalletLimit{ID: 1, WalletID: 1, DailyOutgoing: 1000}
+	tierLimit := entity.WalletLimit{ID: 2, Tier: "basic", DailyOutgoing: 5000}
+	tests := []struct {
+		name       string
+		repo       *fakeWalletRepository
+		wantLimit  entity.WalletLimit
+		wantSource string
+	}{
+		{
+			name: "wallet limit wins over tier limit",
+			repo: &fakeWalletRepository{
+				walletLimits: map[int]entity.WalletLimit{1: walletLimit},
+				tierLimits:   map[string]entity.WalletLimit{"basic": tierLimit},
+			},
+			wantLimit:  walletLimit,
+			wantSource: LimitSourceWallet,
+		},
+		{
+			name:       "falls back to tier limit",
+			repo:       &fakeWalletRepository{tierLimits: map[string]entity.WalletLimit{"basic": tierLimit}},
+			wantLimit:  tierLimit,
+			wantSource: LimitSourceTier,
+		},
+		{
+			name:       "no limit",
+			repo:       &fakeWalletRepository{},
+			wantSource: LimitSourceNone,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &walletService{}
+			got, err := s.resolveLimits(context.Background(), tt.repo, entity.Wallet{ID: 1, Tier: "basic"}, nil)
+			if err != nil {
+				t.Fatalf("resolveLimits() error = %v", err)
+			}
+			if got.Limit != tt.wantLimit || got.Source != tt.wantSource {
+				t.Errorf("resolveLimits() = %+v from %q, want %+v from %q", got.Limit, got.Source, tt.wantLimit, tt.wantSource)
+			}
+		})
+	}
+}
+
+func TestCheckOutgoingLimits(t *testing.T) {
+	now := time.Now().UTC()
+	sentToday := []fakeOutgoing{{at: now, amount: 600}, {at: now, amount: 300}}
+	tests := []struct {
+		name      string
+		limit     entity.WalletLimit
+		outgoing  []fakeOutgoing
+		amount    float64
+		wantLimit string
+		wantMax   float64
+		wantTried float64
+	}{
+		{name: "no limit", amount: 1_000_000},
+		{name: "within max single transfer", limit: entity.WalletLimit{MaxSingleTransfer: 500}, amount: 500},
+		{name: "over max single transfer", limit: entity.WalletLimit{MaxSingleTransfer: 500}, amount: 501, wantLimit: "max_single_transfer", wantMax: 500, wantTried: 501},
+		{name: "within daily outgoing", limit: entity.WalletLimit{DailyOutgoing: 1000}, outgoing: sentToday, amount: 100},
+		{name: "over daily outgoing", limit: entity.WalletLimit{DailyOutgoing: 1000}, outgoing: sentToday, amount: 101, wantLimit: "daily_outgoing", wantMax: 1000, wantTried: 1001},
+		{name: "over monthly outgoing", limit: entity.WalletLimit{MonthlyOutgoing: 1000}, outgoing: sentToday, amount: 200, wantLimit: "monthly_outgoing", wantMax: 1000, wantTried: 1100},
+		{name: "within transfers per hour", limit: entity.WalletLimit{MaxTransfersPerHour: 3}, outgoing: sentToday, amount: 1},
+		{name: "over transfers per hour", limit: entity.WalletLimit{MaxTransfersPerHour: 2}, outgoing: sentToday, amount: 1, wantLimit: "max_transfers_per_hour", wantMax: 2, wantTried: 3},
+		{
+			name:     "older transfers fall outside the hour",
+			limit:    entity.WalletLimit{MaxTransfersPerHour: 1},
+			outgoing: []fakeOutgoing{{at: now.Add(-2 * time.Hour), amount: 1}},
+			amount:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.limit.ID = 1
+			repo := &fakeWalletRepository{
+				walletLimits: map[int]entity.WalletLimit{1: tt.limit},
+				outgoing:     tt.outgoing,
+			}
+			s := &walletService{}
+			err := s.checkOutgoingLimits(context.Background(), repo, entity.Wallet{ID: 1}, tt.amount, nil)
+			if tt.wantLimit == "" {
+				if err != nil {
+					t.Fatalf("checkOutgoingLimits() error = %v, want nil", err)
+				}
+				return
+			}
+			var limitErr *LimitExceededError
+			if !errors.As(err, &limitErr) {
+				t.Fatalf("checkOutgoingLimits() error = %v, want a LimitExceededError", err)
+			}
+			if !errors.Is(err, ErrLimitExceeded) {
+				t.Errorf("checkOutgoingLimits() error does not match ErrLimitExceeded")
+			}
+			want := LimitExceededError{WalletID: 1, Limit: tt.wantLimit, Max: tt.wantMax, Attempted: tt.wantTried}
+			if *limitErr != want {
+				t.Errorf("checkOutgoingLimits() error = %+v, want %+v", *limitErr, want)
+			}
+		})
+	}
+}
+
+func TestCheckMaxBalance(t *testing.T) {
+	wallet := entity.Wallet{ID: 1, Balance: 900}
+	tests := []struct {
+		name    string
+		limit   entity.WalletLimit
+		credit  float64
+		wantErr bool
+	}{
+		{name: "no max balance", credit: 1_000_000},
+		{name: "up to max balance", limit: entity.WalletLimit{MaxBalance: 1000}, credit: 100},
+		{name: "over max balance", limit: entity.WalletLimit{MaxBalance: 1000}, credit: 101, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkMaxBalance(wallet, tt.limit, tt.credit)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkMaxBalance() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var limitErr *LimitExceededError
+			if tt.wantErr && (!errors.As(err, &limitErr) || limitErr.Limit != "max_balance" || limitErr.Attempted != 1001) {
+				t.Errorf("checkMaxBalance() error = %v, want max_balance exceeded at 1001", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,391 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/susilo001/simple-wallet-system/wallet/entity"
+	"github.com/susilo001/simple-wallet-system/wallet/logging"
+	"github.com/susilo001/simple-wallet-system/wallet/pagination"
+)
+
+const (
+	DefaultPaymentRequestTTL = 7 * 24 * time.Hour
+	MaxPaymentRequestTTL     = 30 * 24 * time.Hour
+)
+
+type IPaymentRequestService interface {
+	RequestPayment(ctx context.Context, requesterID int, payerID int, amount float64, note string, ttl time.Duration) (entity.PaymentRequest, error)
+	CreatePaymentLink(ctx context.Context, requesterID int, amount float64, note string, ttl time.Duration) (entity.PaymentRequest, error)
+	GetPaymentRequest(ctx context.Context, id int) (entity.PaymentRequest, error)
+	GetPaymentLink(ctx context.Context, code string) (entity.PaymentRequest, error)
+	GetPaymentRequests(ctx context.Context, walletID int, page pagination.Page) ([]entity.PaymentRequest, int, error)
+	AcceptPaymentRequest(ctx context.Context, id int, payerID int) (entity.PaymentRequest, error)
+	PayPaymentLink(ctx context.Context, code string, payerID int) (entity.PaymentRequest, error)
+	DeclinePaymentRequest(ctx context.Context, id int, payerID int) (entity.PaymentRequest, error)
+	CancelPaymentRequest(ctx context.Context, id int, requesterID int) (entity.PaymentRequest, error)
+	// ExpirePaymentRequests marks every pending request past its expiry as
+	// expired and returns how many were changed.
+	ExpirePaymentRequests(ctx context.Context, now time.Time) (int, error)
+	// RunExpiry calls ExpirePaymentRequests every interval until ctx is
+	// cancelled.
+	RunExpiry(ctx context.Context, interval time.Duration)
+}
+
+type IPaymentRequestRepository interface {
+	CreatePaymentRequest(ctx context.Context, request *entity.PaymentRequest) error
+	GetPaymentRequestByID(ctx context.Context, id int) (entity.PaymentRequest, error)
+	GetPaymentRequestByCode(ctx context.Context, code string) (entity.PaymentRequest, error)
+	GetPaymentRequestsByWalletID(ctx context.Context, walletID int, page pagination.Page) ([]entity.PaymentRequest, int, error)
+	// UpdatePaymentRequestStatus stores the status, payer, paid time and
+	// held transfer of request if its current status is from, and reports
+	// whether it was.
+	UpdatePaymentRequestStatus(ctx context.Context, request *entity.PaymentRequest, from string) (bool, error)
+	ExpirePaymentRequests(ctx context.Context, now time.Time) (int, error)
+}
+
+type paymentRequestService struct {
+	requestRepo   IPaymentRequestRepository
+	walletService IWalletService
+	logger        *slog.Logger
+}
+
+func NewPaymentRequestService(requestRepo IPaymentRequestRepository, walletService IWalletService, logger *slog.Logger) IPaymentRequestService {
+	return &paymentRequestService{requestRepo: requestRepo, walletService: walletService, logger: logger}
+}
+
+func (s *paymentRequestService) RequestPayment(ctx context.Context, requesterID int, payerID int, amount float64, note string, ttl time.Duration) (entity.PaymentRequest, error) {
+	if requesterID == payerID {
+		return entity.PaymentRequest{}, fmt.Errorf("failed to request payment: %w", ErrSameWallet)
+	}
+	if err := s.checkWallet(ctx, payerID); err != nil {
+		return entity.PaymentRequest{}, fmt.Errorf("failed to request payment: %w", err)
+	}
+
+	request, err := s.create(ctx, entity.PaymentRequest{
+		Kind:        entity.PaymentRequestKindRequest,
+		RequesterID: requesterID,
+		PayerID:     payerID,
+		Amount:      amount,
+		Note:        note,
+	}, ttl)
+	if err != nil {
+		return entity.PaymentRequest{}, fmt.Errorf("failed to request payment: %w", err)
+	}
+	return request, nil
+}
+
+func (s *paymentRequestService) CreatePaymentLink(ctx context.Context, requesterID int, amount float64, note string, ttl time.Duration) (entity.PaymentRequest, error) {
+	code, err := newPaymentLinkCode()
+	if err != nil {
+		return entity.PaymentRequest{}, fmt.Errorf("failed to create payment link: %w", err)
+	}
+
+	request, err := s.create(ctx, entity.PaymentRequest{
+		Kind:        entity.PaymentRequestKindLink,
+		Code:        &code,
+		RequesterID: requesterID,
+		Amount:      amount,
+		Note:        note,
+	}, ttl)
+	if err != nil {
+		return entity.PaymentRequest{}, fmt.Errorf("failed to create payment link: %w", err)
+	}
+	return request, nil
+}
+
+func (s *paymentRequestService) GetPaymentRequest(ctx context.Context, id int) (entity.PaymentRequest, error) {
+	request, err := s.getByID(ctx, id)
+	if err != nil {
+		return entity.PaymentRequest{}, fmt.Errorf("failed to get payment request: %w", err)
+	}
+	return request, nil
+}
+
+func (s *paymentRequestService) GetPaymentLink(ctx context.Context, code string) (entity.PaymentRequest, error) {
+	request, err := s.getByCode(ctx, code)
+	if err != nil {
+		return entity.PaymentRequest{}, fmt.Errorf("failed to get payment link: %w", err)
+	}
+	return request, nil
+}
+
+func (s *paymentRequestService) GetPaymentRequests(ctx context.Context, walletID int, page pagination.Page) ([]entity.PaymentRequest, int, error) {
+	requests, total, err := s.requestRepo.GetPaymentRequestsByWalletID(ctx, walletID, page)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get payment requests: %w", err)
+	}
+	return requests, total, nil
+}
+
+func (s *paymentRequestService) AcceptPaymentRequest(ctx context.Context, id int, payerID int) (entity.PaymentRequest, error) {
+	request, err := s.getByID(ctx, id)
+	if err != nil {
+		return entity.PaymentRequest{}, fmt.Errorf("failed to accept payment request: %w", err)
+	}
+	if request.Kind != entity.PaymentRequestKindRequest || request.PayerID != payerID {
+		return entity.PaymentRequest{}, fmt.Errorf("failed to accept payment request: %w", ErrNotPaymentRequestParty)
+	}
+
+	request, err = s.pay(ctx, request, payerID)
+	if err != nil {
+		return entity.PaymentRequest{}, fmt.Errorf("failed to accept payment request: %w", err)
+	}
+	return request, nil
+}
+
+func (s *paymentRequestService) PayPaymentLink(ctx context.Context, code string, payerID int) (entity.PaymentRequest, error) {
+	request, err := s.getByCode(ctx, code)
+	if err != nil {
+		return entity.PaymentRequest{}, fmt.Errorf("failed to pay payment link: %w", err)
+	}
+
+	request, err = s.pay(ctx, request, payerID)
+	if err != nil {
+		return entity.PaymentRequest{}, fmt.Errorf("failed to pay payment link: %w", err)
+	}
+	return request, nil
+}
+
+func (s *paymentRequestService) DeclinePaymentRequest(ctx context.Context, id int, payerID int) (entity.PaymentRequest, error) {
+	request, err := s.getByID(ctx, id)
+	if err != nil {
+		return entity.PaymentRequest{}, fmt.Errorf("failed to decline payment request: %w", err)
+	}
+	if request.Kind != entity.PaymentRequestKindRequest || request.PayerID != payerID {
+		return entity.PaymentRequest{}, fmt.Errorf("failed to decline payment request: %w", ErrNotPaymentRequestParty)
+	}
+
+	request, err = s.close(ctx, request, entity.PaymentRequestStatusDeclined)
+	if err != nil {
+		return entity.PaymentRequest{}, fmt.Errorf("failed to decline payment request: %w", err)
+	}
+	return request, nil
+}
+
+func (s *paymentRequestService) CancelPaymentRequest(ctx context.Context, id int, requesterID int) (entity.PaymentRequest, error) {
+	request, err := s.getByID(ctx, id)
+	if err != nil {
+		return entity.PaymentRequest{}, fmt.Errorf("failed to cancel payment request: %w", err)
+	}
+	if request.RequesterID != requesterID {
+		return entity.PaymentRequest{}, fmt.Errorf("failed to cancel payment request: %w", ErrNotPaymentRequestParty)
+	}
+
+	request, err = s.close(ctx, request, entity.PaymentRequestStatusCancelled)
+	if err != nil {
+		return entity.PaymentRequest{}, fmt.Errorf("failed to cancel payment request: %w", err)
+	}
+	return request, nil
+}
+
+func (s *paymentRequestService) ExpirePaymentRequests(ctx context.Context, now time.Time) (int, error) {
+	count, err := s.requestRepo.ExpirePaymentRequests(ctx, now.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("failed to expire payment requests: %w", err)
+	}
+	return count, nil
+}
+
+func (s *paymentRequestService) RunExpiry(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		runCtx := logging.WithRequestID(ctx, logging.NewRequestID())
+		if count, err := s.ExpirePaymentRequests(runCtx, time.Now()); err != nil {
+			s.logger.ErrorContext(runCtx, "Error expiring payment requests", "error", err)
+		} else if count > 0 {
+			s.logger.InfoContext(runCtx, "Expired payment requests", "count", count)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *paymentRequestService) create(ctx context.Context, request entity.PaymentRequest, ttl time.Duration) (entity.PaymentRequest, error) {
+	if request.Amount <= 0 {
+		return entity.PaymentRequest{}, ErrInvalidAmount
+	}
+	if ttl <= 0 {
+		ttl = DefaultPaymentRequestTTL
+	}
+	if ttl > MaxPaymentRequestTTL {
+		return entity.PaymentRequest{}, fmt.Errorf("expiry must be at most %s", MaxPaymentRequestTTL)
+	}
+	if err := s.checkWallet(ctx, request.RequesterID); err != nil {
+		return entity.PaymentRequest{}, err
+	}
+
+	request.Status = entity.PaymentRequestStatusPending
+	request.ExpiresAt = time.Now().UTC().Add(ttl)
+	if err := s.requestRepo.CreatePaymentRequest(ctx, &request); err != nil {
+		return entity.PaymentRequest{}, err
+	}
+	return request, nil
+}
+
+// pay claims the request by moving it to PROCESSING, so that it cannot be
+// declined, cancelled or paid twice while the transfer runs, and then
+// executes the transfer. The transfer's idempotency key is derived from the
+// request, so a request can never be paid more than once.
+func (s *paymentRequestService) pay(ctx context.Context, request entity.PaymentRequest, payerID int) (entity.PaymentRequest, error) {
+	now := time.Now().UTC()
+	if err := s.checkPending(ctx, &request, now); err != nil {
+		return entity.PaymentRequest{}, err
+	}
+	if request.RequesterID == payerID {
+		return entity.PaymentRequest{}, ErrSameWallet
+	}
+
+	originalPayerID := request.PayerID
+	request.Status = entity.PaymentRequestStatusProcessing
+	request.PayerID = payerID
+	claimed, err := s.requestRepo.UpdatePaymentRequestStatus(ctx, &request, entity.PaymentRequestStatusPending)
+	if err != nil {
+		return entity.PaymentRequest{}, err
+	}
+	if !claimed {
+		return entity.PaymentRequest{}, ErrPaymentRequestClosed
+	}
+
+	key := fmt.Sprintf("payment-request:%d", request.ID)
+	if err := s.walletService.Transfer(WithIdempotencyKey(ctx, key), payerID, request.RequesterID, request.Amount); err != nil {
+		// A held payment keeps the request away from other payers until the
+		// review, which settles it in settleHeldPaymentRequest.
+		var heldErr *TransferHeldError
+		if errors.As(err, &heldErr) {
+			request.Status = entity.PaymentRequestStatusHeld
+			request.HeldTransferID = &heldErr.HeldTransferID
+			if _, holdErr := s.requestRepo.UpdatePaymentRequestStatus(ctx, &request, entity.PaymentRequestStatusProcessing); holdErr != nil {
+				s.logger.ErrorContext(ctx, "Error holding payment request", "payment_request_id", request.ID, "held_transfer_id", heldErr.HeldTransferID, "error", holdErr)
+			}
+			return entity.PaymentRequest{}, err
+		}
+
+		request.Status = entity.PaymentRequestStatusPending
+		request.PayerID = originalPayerID
+		if _, releaseErr := s.requestRepo.UpdatePaymentRequestStatus(ctx, &request, entity.PaymentRequestStatusProcessing); releaseErr != nil {
+			s.logger.ErrorContext(ctx, "Error releasing payment request", "payment_request_id", request.ID, "error", releaseErr)
+		}
+		return entity.PaymentRequest{}, err
+	}
+
+	request.Status = entity.PaymentRequestStatusPaid
+	request.PaidAt = &now
+	if _, err := s.requestRepo.UpdatePaymentRequestStatus(ctx, &request, entity.PaymentRequestStatusProcessing); err != nil {
+		return entity.PaymentRequest{}, err
+	}
+	return request, nil
+}
+
+// settleHeldPaymentRequest settles the payment request that held paid, if
+// any, once a reviewer has released or rejected it. A released payment
+// marks the request PAID; a rejected one puts it back to PENDING so that it
+// can be paid again.
+func settleHeldPaymentRequest(ctx context.Context, repo IWalletRepository, held entity.HeldTransfer) error {
+	request, err := repo.GetPaymentRequestByHeldTransferID(ctx, held.ID)
+	if err != nil || request.ID == 0 {
+		return err
+	}
+
+	request.HeldTransferID = nil
+	if held.Status == entity.HeldTransferStatusReleased {
+		request.Status = entity.PaymentRequestStatusPaid
+		request.PaidAt = held.ReviewedAt
+	} else {
+		request.Status = entity.PaymentRequestStatusPending
+		// A link goes back to being payable by anyone.
+		if request.Kind == entity.PaymentRequestKindLink {
+			request.PayerID = 0
+		}
+	}
+	_, err = repo.UpdatePaymentRequestStatus(ctx, &request, entity.PaymentRequestStatusHeld)
+	return err
+}
+
+func (s *paymentRequestService) close(ctx context.Context, request entity.PaymentRequest, status string) (entity.PaymentRequest, error) {
+	if err := s.checkPending(ctx, &request, time.Now().UTC()); err != nil {
+		return entity.PaymentRequest{}, err
+	}
+
+	request.Status = status
+	closed, err := s.requestRepo.UpdatePaymentRequestStatus(ctx, &request, entity.PaymentRequestStatusPending)
+	if err != nil {
+		return entity.PaymentRequest{}, err
+	}
+	if !closed {
+		return entity.PaymentRequest{}, ErrPaymentRequestClosed
+	}
+	return request, nil
+}
+
+// checkPending fails unless request can still be acted on, expiring it on
+// the spot if the expiry worker has not done so yet.
+func (s *paymentRequestService) checkPending(ctx context.Context, request *entity.PaymentRequest, now time.Time) error {
+	if request.Status == entity.PaymentRequestStatusExpired {
+		return ErrPaymentRequestExpired
+	}
+	if request.Status != entity.PaymentRequestStatusPending {
+		return ErrPaymentRequestClosed
+	}
+	if !now.Before(request.ExpiresAt) {
+		request.Status = entity.PaymentRequestStatusExpired
+		if _, err := s.requestRepo.UpdatePaymentRequestStatus(ctx, request, entity.PaymentRequestStatusPending); err != nil {
+			return err
+		}
+		return ErrPaymentRequestExpired
+	}
+	return nil
+}
+
+func (s *paymentRequestService) getByID(ctx context.Context, id int) (entity.PaymentRequest, error) {
+	request, err := s.requestRepo.GetPaymentRequestByID(ctx, id)
+	if err != nil {
+		return entity.PaymentRequest{}, err
+	}
+	if request.ID == 0 {
+		return entity.PaymentRequest{}, fmt.Errorf("payment request %d: %w", id, ErrPaymentRequestNotFound)
+	}
+	return request, nil
+}
+
+func (s *paymentRequestService) getByCode(ctx context.Context, code string) (entity.PaymentRequest, error) {
+	request, err := s.requestRepo.GetPaymentRequestByCode(ctx, code)
+	if err != nil {
+		return entity.PaymentRequest{}, err
+	}
+	if request.ID == 0 {
+		return entity.PaymentRequest{}, fmt.Errorf("payment link %s: %w", code, ErrPaymentRequestNotFound)
+	}
+	return request, nil
+}
+
+func (s *paymentRequestService) checkWallet(ctx context.Context, id int) error {
+	wallet, err := s.walletService.GetWalletByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if wallet.ID == 0 {
+		return fmt.Errorf("wallet %d: %w", id, ErrWalletNotFound)
+	}
+	return nil
+}
+
+// newPaymentLinkCode returns a random, URL-safe code with 80 bits of entropy.
+func newPaymentLinkCode() (string, error) {
+	b := make([]byte, 10)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b), nil
+}
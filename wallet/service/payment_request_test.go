@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/susilo001/simple-wallet-system/wallet/entity"
+)
+
+func TestPayPaymentLinkHeldForReview(t *testing.T) {
+	ctx := context.Background()
+	code := "LINKCODE"
+	requests := &fakePaymentRequestRepository{requests: map[int]entity.PaymentRequest{
+		1: {ID: 1, Kind: entity.PaymentRequestKindLink, Code: &code, RequesterID: 10, Amount: 100, Status: entity.PaymentRequestStatusPending, ExpiresAt: time.Now().Add(time.Hour)},
+	}}
+	wallets := &fakeWalletService{transferErr: &TransferHeldError{HeldTransferID: 7, Score: 60}}
+	s := NewPaymentRequestService(requests, wallets, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	if _, err := s.PayPaymentLink(ctx, code, 20); !errors.Is(err, ErrTransferHeld) {
+		t.Fatalf("PayPaymentLink() error = %v, want ErrTransferHeld", err)
+	}
+	request := requests.requests[1]
+	if request.Status != entity.PaymentRequestStatusHeld || request.PayerID != 20 || request.HeldTransferID == nil || *request.HeldTransferID != 7 {
+		t.Fatalf("request after held payment = %+v, want HELD by payer 20 on held transfer 7", request)
+	}
+
+	if _, err := s.PayPaymentLink(ctx, code, 30); !errors.Is(err, ErrPaymentRequestClosed) {
+		t.Errorf("PayPaymentLink() by another payer error = %v, want ErrPaymentRequestClosed", err)
+	}
+	if wallets.transfers != 1 {
+		t.Errorf("transfers = %d, want 1", wallets.transfers)
+	}
+}
+
+func TestSettleHeldPaymentRequest(t *testing.T) {
+	reviewedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	heldTransferID := 7
+	tests := []struct {
+		name       string
+		kind       string
+		status     string
+		wantStatus string
+		wantPayer  int
+		wantPaidAt *time.Time
+	}{
+		{name: "released link", kind: entity.PaymentRequestKindLink, status: entity.HeldTransferStatusReleased, wantStatus: entity.PaymentRequestStatusPaid, wantPayer: 20, wantPaidAt: &reviewedAt},
+		{name: "rejected link", kind: entity.PaymentRequestKindLink, status: entity.HeldTransferStatusRejected, wantStatus: entity.PaymentRequestStatusPending, wantPayer: 0},
+		{name: "rejected request", kind: entity.PaymentRequestKindRequest, status: entity.HeldTransferStatusRejected, wantStatus: entity.PaymentRequestStatusPending, wantPayer: 20},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := &fakePaymentRequestRepository{requests: map[int]entity.PaymentRequest{
+				1: {ID: 1, Kind: tt.kind, RequesterID: 10, PayerID: 20, Amount: 100, Status: entity.PaymentRequestStatusHeld, HeldTransferID: &heldTransferID},
+			}}
+			repo := &fakeWalletRepository{paymentRequests: requests}
+			held := entity.HeldTransfer{ID: heldTransferID, Status: tt.status, ReviewedAt: &reviewedAt}
+
+			if err := settleHeldPaymentRequest(context.Background(), repo, held); err != nil {
+				t.Fatalf("settleHeldPaymentRequest() error = %v", err)
+			}
+			got := requests.requests[1]
+			if got.Status != tt.wantStatus || got.PayerID != tt.wantPayer || got.HeldTransferID != nil {
+				t.Errorf("request = %+v, want %s by payer %d and no held transfer", got, tt.wantStatus, tt.wantPayer)
+			}
+			if (got.PaidAt == nil) != (tt.wantPaidAt == nil) || (got.PaidAt != nil && !got.PaidAt.Equal(*tt.wantPaidAt)) {
+				t.Errorf("paid at = %v, want %v", got.PaidAt, tt.wantPaidAt)
+			}
+		})
+	}
+
+	if err := settleHeldPaymentRequest(context.Background(), &fakeWalletRepository{paymentRequests: &fakePaymentRequestRepository{}}, entity.HeldTransfer{ID: 8}); err != nil {
+		t.Errorf("settleHeldPaymentRequest() without a request error = %v", err)
+	}
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/susilo001/simple-wallet-system/wallet/entity"
+	"github.com/susilo001/simple-wallet-system/wallet/logging"
+)
+
+// ReconciliationReport is a reconciliation run with the wallets whose stored
+// balance does not match their transaction history.
+type ReconciliationReport struct {
+	Run           entity.ReconciliationRun           `json:"run"`
+	Discrepancies []entity.ReconciliationDiscrepancy `json:"discrepancies"`
+}
+
+type IReconciliationService interface {
+	Reconcile(ctx context.Context, proposeAdjustments bool) (ReconciliationReport, error)
+	GetReconciliationReport(ctx context.Context, runID int) (ReconciliationReport, error)
+	Run(ctx context.Context, interval time.Duration, proposeAdjustments bool)
+}
+
+type IReconciliationRepository interface {
+	// GetNetAmountsByWallet returns credits minus debits over the whole
+	// history of every wallet that appears in a transaction.
+	GetNetAmountsByWallet(ctx context.Context) (map[int]float64, error)
+	// GetExternalNet returns the amount credited from outside the system
+	// minus the amount debited to outside it.
+	GetExternalNet(ctx context.Context) (float64, error)
+	SaveReconciliationRun(ctx context.Context, run *entity.ReconciliationRun, discrepancies []entity.ReconciliationDiscrepancy) error
+	GetReconciliationRunByID(ctx context.Context, id int) (entity.ReconciliationRun, []entity.ReconciliationDiscrepancy, error)
+}
+
+type reconciliationService struct {
+	reconciliationRepo IReconciliationRepository
+	walletRepo         IWalletRepository
+	walletService      IWalletService
+	logger             *slog.Logger
+}
+
+func NewReconciliationService(reconciliationRepo IReconciliationRepository, walletRepo IWalletRepository, walletService IWalletService, logger *slog.Logger) IReconciliationService {
+	return &reconciliationService{reconciliationRepo: reconciliationRepo, walletRepo: walletRepo, walletService: walletService, logger: logger}
+}
+
+// Reconcile compares every stored balance with the balance derived from the
+// transaction history and checks that the money held by all wallets equals
+// the money that entered minus the money that left. Wallets that differ in
+// the bulk pass are re-checked one by one under lock, so transfers in flight
+// during the pass are not reported. With proposeAdjustments, a pending
+// adjustment booking the difference is opened for each discrepancy that
+// does not have one yet.
+func (s *reconciliationService) Reconcile(ctx context.Context, proposeAdjustments bool) (ReconciliationReport, error) {
+	run := entity.ReconciliationRun{StartedAt: time.Now().UTC()}
+
+	wallets, err := s.walletRepo.GetAllWallets(ctx)
+	if err != nil {
+		return ReconciliationReport{}, fmt.Errorf("failed to reconcile: %w", err)
+	}
+	nets, err := s.reconciliationRepo.GetNetAmountsByWallet(ctx)
+	if err != nil {
+		return ReconciliationReport{}, fmt.Errorf("failed to reconcile: %w", err)
+	}
+	externalNet, err := s.reconciliationRepo.GetExternalNet(ctx)
+	if err != nil {
+		return ReconciliationReport{}, fmt.Errorf("failed to reconcile: %w", err)
+	}
+
+	var discrepancies []entity.ReconciliationDiscrepancy
+	for _, wallet := range wallets {
+		live, computed := wallet.Balance, roundCents(nets[wallet.ID])
+		delete(nets, wallet.ID)
+		if roundCents(live-computed) != 0 {
+			check, err := s.walletService.VerifyBalance(ctx, wallet.ID)
+			if err != nil {
+				return ReconciliationReport{}, fmt.Errorf("failed to reconcile: %w", err)
+			}
+			live, computed = check.LiveBalance, check.ComputedBalance
+			if !check.Consistent {
+				discrepancies = append(discrepancies, entity.ReconciliationDiscrepancy{
+					WalletID:        wallet.ID,
+					LiveBalance:     live,
+					ComputedBalance: computed,
+					Difference:      check.Difference,
+				})
+			}
+		}
+		run.TotalLiveBalance += live
+		run.TotalComputedBalance += computed
+		run.WalletsChecked++
+	}
+
+	// Whatever is left in nets is history of wallets that no longer exist.
+	for walletID, net := range nets {
+		computed := roundCents(net)
+		run.TotalComputedBalance += computed
+		if computed != 0 {
+			discrepancies = append(discrepancies, entity.ReconciliationDiscrepancy{
+				WalletID:        walletID,
+				ComputedBalance: computed,
+				Difference:      -computed,
+				WalletMissing:   true,
+			})
+		}
+	}
+	sort.Slice(discrepancies, func(i, j int) bool { return discrepancies[i].WalletID < discrepancies[j].WalletID })
+
+	run.TotalLiveBalance = roundCents(run.TotalLiveBalance)
+	run.TotalComputedBalance = roundCents(run.TotalComputedBalance)
+	run.ExternalNet = roundCents(externalNet)
+	run.Conserved = run.TotalLiveBalance == run.ExternalNet
+	run.DiscrepancyCount = len(discrepancies)
+	run.FinishedAt = time.Now().UTC()
+	if err := s.reconciliationRepo.SaveReconciliationRun(ctx, &run, discrepancies); err != nil {
+		return ReconciliationReport{}, fmt.Errorf("failed to reconcile: %w", err)
+	}
+
+	if proposeAdjustments && len(discrepancies) > 0 {
+		if err := s.proposeAdjustments(ctx, &run, discrepancies); err != nil {
+			return ReconciliationReport{}, fmt.Errorf("failed to reconcile: %w", err)
+		}
+	}
+	return ReconciliationReport{Run: run, Discrepancies: discrepancies}, nil
+}
+
+func (s *reconciliationService) proposeAdjustments(ctx context.Context, run *entity.ReconciliationRun, discrepancies []entity.ReconciliationDiscrepancy) error {
+	for i := range discrepancies {
+		discrepancy := &discrepancies[i]
+		if discrepancy.WalletMissing {
+			continue
+		}
+		pending, err := s.walletRepo.HasPendingAdjustment(ctx, discrepancy.WalletID, entity.AdjustmentSourceReconciliation)
+		if err != nil {
+			return err
+		}
+		if pending {
+			continue
+		}
+
+		adjustment := entity.BalanceAdjustment{
+			WalletID:            discrepancy.WalletID,
+			Amount:              discrepancy.Difference,
+			Source:              entity.AdjustmentSourceReconciliation,
+			Status:              entity.AdjustmentStatusPending,
+			Reason:              fmt.Sprintf("reconciliation run %d: stored balance %.2f, history %.2f", run.ID, discrepancy.LiveBalance, discrepancy.ComputedBalance),
+			ProposedBy:          "reconciliation",
+			ReconciliationRunID: run.ID,
+		}
+		if err := s.walletRepo.CreateBalanceAdjustment(ctx, &adjustment); err != nil {
+			return err
+		}
+		discrepancy.AdjustmentID = adjustment.ID
+		run.AdjustmentsProposed++
+	}
+	return s.reconciliationRepo.SaveReconciliationRun(ctx, run, discrepancies)
+}
+
+func (s *reconciliationService) GetReconciliationReport(ctx context.Context, runID int) (ReconciliationReport, error) {
+	run, discrepancies, err := s.reconciliationRepo.GetReconciliationRunByID(ctx, runID)
+	if err != nil {
+		return ReconciliationReport{}, fmt.Errorf("failed to get reconciliation report: %w", err)
+	}
+	if run.ID == 0 {
+		return ReconciliationReport{}, fmt.Errorf("failed to get reconciliation report: run %d: %w", runID, ErrReconciliationRunNotFound)
+	}
+	return ReconciliationReport{Run: run, Discrepancies: discrepancies}, nil
+}
+
+// Run reconciles every interval until ctx is cancelled.
+func (s *reconciliationService) Run(ctx context.Context, interval time.Duration, proposeAdjustments bool) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		runCtx := logging.WithRequestID(ctx, logging.NewRequestID())
+		if report, err := s.Reconcile(runCtx, proposeAdjustments); err != nil {
+			s.logger.ErrorContext(runCtx, "Error running reconciliation", "error", err)
+		} else if report.Run.DiscrepancyCount > 0 || !report.Run.Conserved {
+			s.logger.WarnContext(runCtx, "Reconciliation found discrepancies",
+				"run_id", report.Run.ID, "discrepancies", report.Run.DiscrepancyCount, "conserved", report.Run.Conserved)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
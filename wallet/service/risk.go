@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/susilo001/simple-wallet-system/wallet/entity"
+	"github.com/susilo001/simple-wallet-system/wallet/metrics"
+	"github.com/susilo001/simple-wallet-system/wallet/pagination"
+	"github.com/susilo001/simple-wallet-system/wallet/risk"
+)
+
+// TransferHeldError reports that a transfer was held for manual review
+// instead of being executed. It matches ErrTransferHeld with errors.Is.
+type TransferHeldError struct {
+	HeldTransferID int
+	Score          int
+}
+
+func (e *TransferHeldError) Error() string {
+	return fmt.Sprintf("%v: held transfer %d (risk score %d)", ErrTransferHeld, e.HeldTransferID, e.Score)
+}
+
+func (e *TransferHeldError) Is(target error) bool {
+	return target == ErrTransferHeld
+}
+
+// assessTransfer scores a transfer with the risk engine before any money
+// moves. A blocked transfer is rejected. A transfer that needs review is
+// rejected too, and when hold is set it is queued as a held transfer that
+// a reviewer can release later.
+func (s *walletService) assessTransfer(ctx context.Context, senderID int, recipientID int, amount float64, key *string, hold bool) error {
+	if s.riskEngine == nil {
+		return nil
+	}
+
+	// A retry of a transfer that already went through, or that is already
+	// waiting for review, is not assessed again.
+	if applied, err := findApplied(ctx, s.walletRepo, key); err != nil || applied.ID != 0 {
+		return err
+	}
+	if key != nil {
+		held, err := s.walletRepo.GetPendingHeldTransferByKey(ctx, *key)
+		if err != nil {
+			return err
+		}
+		if held.ID != 0 {
+			return &TransferHeldError{HeldTransferID: held.ID, Score: held.RiskScore}
+		}
+	}
+
+	sender, err := s.getExistingWallet(ctx, senderID)
+	if err != nil {
+		return err
+	}
+	recipient, err := s.getExistingWallet(ctx, recipientID)
+	if err != nil {
+		return err
+	}
+	// Money leaving an internal wallet is the system's own doing.
+	if sender.Internal {
+		return nil
+	}
+
+	assessment, err := s.riskEngine.Assess(ctx, risk.Transfer{
+		Sender:    sender,
+		Recipient: recipient,
+		Amount:    amount,
+		At:        time.Now().UTC(),
+	}, s.walletRepo)
+	if err != nil {
+		return err
+	}
+
+	switch assessment.Decision {
+	case risk.DecisionBlock:
+		return fmt.Errorf("%w: risk score %d", ErrTransferBlocked, assessment.Score)
+	case risk.DecisionReview:
+		if !hold {
+			return fmt.Errorf("%w: risk score %d needs review", ErrTransferBlocked, assessment.Score)
+		}
+		hits, err := json.Marshal(assessment.Hits)
+		if err != nil {
+			return err
+		}
+		held := entity.HeldTransfer{
+			SenderID:       sender.ID,
+			RecipientID:    recipient.ID,
+			Amount:         amount,
+			Status:         entity.HeldTransferStatusPending,
+			RiskScore:      assessment.Score,
+			RiskHits:       string(hits),
+			IdempotencyKey: key,
+		}
+		if err := s.walletRepo.CreateHeldTransfer(ctx, &held); err != nil {
+			return err
+		}
+		return &TransferHeldError{HeldTransferID: held.ID, Score: held.RiskScore}
+	}
+	return nil
+}
+
+// GetHeldTransfers returns a page of the held transfers in the given
+// status, or in any status when status is empty, and their count.
+func (s *walletService) GetHeldTransfers(ctx context.Context, status string, page pagination.Page) ([]entity.HeldTransfer, int, error) {
+	held, total, err := s.walletRepo.GetHeldTransfers(ctx, status, page)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get held transfers: %w", err)
+	}
+	return held, total, nil
+}
+
+// ReviewHeldTransfer releases or rejects a pending held transfer. A
+// released transfer is executed without being assessed again, but every
+// other check (balance, status, limits) still applies, in the same
+// database transaction that marks it released. The payment request or
+// scheduled transfer waiting for the transfer is settled in that
+// transaction too.
+func (s *walletService) ReviewHeldTransfer(ctx context.Context, id int, approve bool, reviewer string) (entity.HeldTransfer, error) {
+	if reviewer == "" {
+		return entity.HeldTransfer{}, fmt.Errorf("failed to review held transfer: reviewer is required")
+	}
+
+	// The KYC levels of the wallets' owners are looked up before the held
+	// transfer and the wallets are locked.
+	levels := kycLevelsByUser{}
+	if approve {
+		pending, err := s.walletRepo.GetHeldTransferByID(ctx, id)
+		if err != nil {
+			return entity.HeldTransfer{}, fmt.Errorf("failed to review held transfer: %w", err)
+		}
+		if pending.ID != 0 {
+			if levels, err = s.lookupKYCLevels(ctx, pending.SenderID, pending.RecipientID); err != nil {
+				return entity.HeldTransfer{}, fmt.Errorf("failed to review held transfer: %w", err)
+			}
+		}
+	}
+
+	var held entity.HeldTransfer
+	err := s.walletRepo.Transaction(ctx, func(repo IWalletRepository) error {
+		var err error
+		held, err = repo.GetHeldTransferForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if held.ID == 0 {
+			return fmt.Errorf("held transfer %d: %w", id, ErrHeldTransferNotFound)
+		}
+		if held.Status != entity.HeldTransferStatusPending {
+			return fmt.Errorf("held transfer %d is %s: %w", id, held.Status, ErrHeldTransferClosed)
+		}
+
+		now := time.Now().UTC()
+		held.ReviewedBy = reviewer
+		held.ReviewedAt = &now
+		if approve {
+			transaction, err := s.applyTransfer(ctx, repo, held.SenderID, held.RecipientID, held.Amount, held.IdempotencyKey, 0, levels)
+			if err != nil {
+				return err
+			}
+			held.Status = entity.HeldTransferStatusReleased
+			held.TransactionID = transaction.ID
+		} else {
+			held.Status = entity.HeldTransferStatusRejected
+		}
+		if err := repo.UpdateHeldTransfer(ctx, &held); err != nil {
+			return err
+		}
+		if err := settleHeldPaymentRequest(ctx, repo, held); err != nil {
+			return err
+		}
+		return settleHeldScheduledTransfer(ctx, repo, held)
+	})
+	if approve {
+		recordTransfer(metrics.TransferReleased, held.Amount, err)
+	}
+	if err != nil {
+		return entity.HeldTransfer{}, fmt.Errorf("failed to review held transfer: %w", err)
+	}
+	return held, nil
+}
+
+// GetRiskStats returns the risk engine's rule hit and decision counters,
+// or empty stats when risk checks are disabled.
+func (s *walletService) GetRiskStats() risk.Stats {
+	if s.riskEngine == nil {
+		return risk.Stats{Decisions: map[risk.Decision]int64{}}
+	}
+	return s.riskEngine.Stats()
+}
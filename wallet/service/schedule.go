@@ -0,0 +1,401 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/susilo001/simple-wallet-system/wallet/entity"
+	"github.com/susilo001/simple-wallet-system/wallet/logging"
+	"github.com/susilo001/simple-wallet-system/wallet/pagination"
+)
+
+const (
+	// MinScheduleInterval is the shortest allowed recurring interval.
+	MinScheduleInterval = time.Minute
+	// MaxScheduleAttempts is how often one occurrence is tried before it is
+	// skipped.
+	MaxScheduleAttempts = 5
+
+	scheduleRetryBase  = time.Minute
+	scheduleRetryMax   = time.Hour
+	scheduleClaimLease = 5 * time.Minute
+	scheduleBatchSize  = 100
+)
+
+type IScheduledTransferService interface {
+	ScheduleTransfer(ctx context.Context, schedule entity.ScheduledTransfer, startAt time.Time) (entity.ScheduledTransfer, error)
+	GetScheduledTransfers(ctx context.Context, walletID int, page pagination.Page) ([]entity.ScheduledTransfer, int, error)
+	GetScheduledTransfer(ctx context.Context, id int) (entity.ScheduledTransfer, error)
+	PauseScheduledTransfer(ctx context.Context, id int) (entity.ScheduledTransfer, error)
+	ResumeScheduledTransfer(ctx context.Context, id int) (entity.ScheduledTransfer, error)
+	CancelScheduledTransfer(ctx context.Context, id int) (entity.ScheduledTransfer, error)
+	// RunDue executes every schedule due at now and returns how many
+	// occurrences were attempted.
+	RunDue(ctx context.Context, now time.Time) (int, error)
+	// Run calls RunDue every interval until ctx is cancelled.
+	Run(ctx context.Context, interval time.Duration)
+}
+
+type IScheduledTransferRepository interface {
+	CreateScheduledTransfer(ctx context.Context, schedule *entity.ScheduledTransfer) error
+	GetScheduledTransferByID(ctx context.Context, id int) (entity.ScheduledTransfer, error)
+	GetScheduledTransfersByWalletID(ctx context.Context, walletID int, page pagination.Page) ([]entity.ScheduledTransfer, int, error)
+	UpdateScheduledTransfer(ctx context.Context, schedule *entity.ScheduledTransfer) error
+	// ClaimDueScheduledTransfers returns active schedules due at now and
+	// pushes their NextRunAt forward by lease, so that a second worker does
+	// not pick them up while they are executed.
+	ClaimDueScheduledTransfers(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]entity.ScheduledTransfer, error)
+	// SaveScheduledTransferRun stores the outcome of an execution unless the
+	// schedule was paused or cancelled in the meantime.
+	SaveScheduledTransferRun(ctx context.Context, schedule *entity.ScheduledTransfer) error
+}
+
+type scheduledTransferService struct {
+	scheduleRepo  IScheduledTransferRepository
+	walletService IWalletService
+	logger        *slog.Logger
+}
+
+func NewScheduledTransferService(scheduleRepo IScheduledTransferRepository, walletService IWalletService, logger *slog.Logger) IScheduledTransferService {
+	return &scheduledTransferService{scheduleRepo: scheduleRepo, walletService: walletService, logger: logger}
+}
+
+var cronParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+// ScheduleTransfer creates a standing order. The kind is taken from
+// schedule: a cron expression makes it CRON, an interval makes it INTERVAL
+// and otherwise it runs once at startAt. For recurring kinds startAt is the
+// earliest first occurrence and may be zero.
+func (s *scheduledTransferService) ScheduleTransfer(ctx context.Context, schedule entity.ScheduledTransfer, startAt time.Time) (entity.ScheduledTransfer, error) {
+	now := time.Now().UTC()
+
+	if schedule.Amount <= 0 {
+		return entity.ScheduledTransfer{}, fmt.Errorf("failed to schedule transfer: %w", ErrInvalidAmount)
+	}
+	if schedule.SenderID == schedule.RecipientID {
+		return entity.ScheduledTransfer{}, fmt.Errorf("failed to schedule transfer: %w", ErrSameWallet)
+	}
+	for _, id := range []int{schedule.SenderID, schedule.RecipientID} {
+		wallet, err := s.walletService.GetWalletByID(ctx, id)
+		if err != nil {
+			return entity.ScheduledTransfer{}, fmt.Errorf("failed to schedule transfer: %w", err)
+		}
+		if wallet.ID == 0 {
+			return entity.ScheduledTransfer{}, fmt.Errorf("failed to schedule transfer: wallet %d: %w", id, ErrWalletNotFound)
+		}
+	}
+
+	switch {
+	case schedule.CronExpr != "":
+		schedule.Kind = entity.ScheduleKindCron
+		if _, err := cronParser.Parse(schedule.CronExpr); err != nil {
+			return entity.ScheduledTransfer{}, fmt.Errorf("failed to schedule transfer: %w: %v", ErrInvalidSchedule, err)
+		}
+	case schedule.IntervalSeconds > 0:
+		schedule.Kind = entity.ScheduleKindInterval
+		if time.Duration(schedule.IntervalSeconds)*time.Second < MinScheduleInterval {
+			return entity.ScheduledTransfer{}, fmt.Errorf("failed to schedule transfer: %w: interval must be at least %s", ErrInvalidSchedule, MinScheduleInterval)
+		}
+	default:
+		schedule.Kind = entity.ScheduleKindOnce
+		if !startAt.After(now) {
+			return entity.ScheduledTransfer{}, fmt.Errorf("failed to schedule transfer: %w: start time must be in the future", ErrInvalidSchedule)
+		}
+	}
+
+	first, err := firstOccurrence(schedule, startAt.UTC(), now)
+	if err != nil {
+		return entity.ScheduledTransfer{}, fmt.Errorf("failed to schedule transfer: %w: %v", ErrInvalidSchedule, err)
+	}
+	if schedule.EndAt != nil && first.After(*schedule.EndAt) {
+		return entity.ScheduledTransfer{}, fmt.Errorf("failed to schedule transfer: %w: end time is before the first occurrence", ErrInvalidSchedule)
+	}
+
+	schedule.ID = 0
+	schedule.Status = entity.ScheduleStatusActive
+	schedule.OccurrenceAt = first
+	schedule.NextRunAt = first
+	schedule.Attempts = 0
+	schedule.ExecutedCount = 0
+	if err := s.scheduleRepo.CreateScheduledTransfer(ctx, &schedule); err != nil {
+		return entity.ScheduledTransfer{}, fmt.Errorf("failed to schedule transfer: %w", err)
+	}
+	return schedule, nil
+}
+
+func (s *scheduledTransferService) GetScheduledTransfers(ctx context.Context, walletID int, page pagination.Page) ([]entity.ScheduledTransfer, int, error) {
+	schedules, total, err := s.scheduleRepo.GetScheduledTransfersByWalletID(ctx, walletID, page)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get scheduled transfers: %w", err)
+	}
+	return schedules, total, nil
+}
+
+func (s *scheduledTransferService) GetScheduledTransfer(ctx context.Context, id int) (entity.ScheduledTransfer, error) {
+	schedule, err := s.scheduleRepo.GetScheduledTransferByID(ctx, id)
+	if err != nil {
+		return entity.ScheduledTransfer{}, fmt.Errorf("failed to get scheduled transfer: %w", err)
+	}
+	if schedule.ID == 0 {
+		return entity.ScheduledTransfer{}, fmt.Errorf("scheduled transfer %d: %w", id, ErrScheduleNotFound)
+	}
+	return schedule, nil
+}
+
+func (s *scheduledTransferService) PauseScheduledTransfer(ctx context.Context, id int) (entity.ScheduledTransfer, error) {
+	schedule, err := s.changeStatus(ctx, id, entity.ScheduleStatusPaused, entity.ScheduleStatusActive)
+	if err != nil {
+		return entity.ScheduledTransfer{}, fmt.Errorf("failed to pause scheduled transfer: %w", err)
+	}
+	return schedule, nil
+}
+
+// ResumeScheduledTransfer reactivates a paused schedule. Recurring
+// occurrences that fell due while it was paused are skipped.
+func (s *scheduledTransferService) ResumeScheduledTransfer(ctx context.Context, id int) (entity.ScheduledTransfer, error) {
+	schedule, err := s.changeStatus(ctx, id, entity.ScheduleStatusActive, entity.ScheduleStatusPaused)
+	if err != nil {
+		return entity.ScheduledTransfer{}, fmt.Errorf("failed to resume scheduled transfer: %w", err)
+	}
+	return schedule, nil
+}
+
+func (s *scheduledTransferService) CancelScheduledTransfer(ctx context.Context, id int) (entity.ScheduledTransfer, error) {
+	schedule, err := s.changeStatus(ctx, id, entity.ScheduleStatusCancelled, entity.ScheduleStatusActive, entity.ScheduleStatusPaused)
+	if err != nil {
+		return entity.ScheduledTransfer{}, fmt.Errorf("failed to cancel scheduled transfer: %w", err)
+	}
+	return schedule, nil
+}
+
+func (s *scheduledTransferService) changeStatus(ctx context.Context, id int, to string, from ...string) (entity.ScheduledTransfer, error) {
+	schedule, err := s.scheduleRepo.GetScheduledTransferByID(ctx, id)
+	if err != nil {
+		return entity.ScheduledTransfer{}, err
+	}
+	if schedule.ID == 0 {
+		return entity.ScheduledTransfer{}, fmt.Errorf("scheduled transfer %d: %w", id, ErrScheduleNotFound)
+	}
+
+	allowed := false
+	for _, status := range from {
+		allowed = allowed || schedule.Status == status
+	}
+	if !allowed {
+		return entity.ScheduledTransfer{}, fmt.Errorf("%w: %s to %s", ErrScheduleStateChange, schedule.Status, to)
+	}
+
+	schedule.Status = to
+	if to == entity.ScheduleStatusActive {
+		now := time.Now().UTC()
+		schedule.Attempts = 0
+		if schedule.Kind != entity.ScheduleKindOnce && schedule.OccurrenceAt.Before(now) {
+			next, err := nextOccurrence(schedule, now)
+			if err != nil {
+				return entity.ScheduledTransfer{}, err
+			}
+			schedule.OccurrenceAt = next
+		}
+		schedule.NextRunAt = schedule.OccurrenceAt
+		if schedule.EndAt != nil && schedule.OccurrenceAt.After(*schedule.EndAt) {
+			schedule.Status = entity.ScheduleStatusCompleted
+		}
+	}
+
+	if err := s.scheduleRepo.UpdateScheduledTransfer(ctx, &schedule); err != nil {
+		return entity.ScheduledTransfer{}, err
+	}
+	return schedule, nil
+}
+
+func (s *scheduledTransferService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		runCtx := logging.WithRequestID(ctx, logging.NewRequestID())
+		if count, err := s.RunDue(runCtx, time.Now()); err != nil {
+			s.logger.ErrorContext(runCtx, "Error running scheduled transfers", "error", err)
+		} else if count > 0 {
+			s.logger.InfoContext(runCtx, "Ran scheduled transfers", "count", count)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *scheduledTransferService) RunDue(ctx context.Context, now time.Time) (int, error) {
+	now = now.UTC()
+	schedules, err := s.scheduleRepo.ClaimDueScheduledTransfers(ctx, now, scheduleClaimLease, scheduleBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim scheduled transfers: %w", err)
+	}
+
+	for i := range schedules {
+		schedule := &schedules[i]
+		s.execute(ctx, schedule, now)
+		if err := s.scheduleRepo.SaveScheduledTransferRun(ctx, schedule); err != nil {
+			s.logger.ErrorContext(ctx, "Error saving run of scheduled transfer", "schedule_id", schedule.ID, "error", err)
+		}
+	}
+	return len(schedules), nil
+}
+
+// execute attempts the due occurrence of schedule and moves the schedule to
+// its next state. The occurrence's idempotency key guarantees that it is
+// paid at most once even if a previous attempt committed but could not be
+// recorded.
+func (s *scheduledTransferService) execute(ctx context.Context, schedule *entity.ScheduledTransfer, now time.Time) {
+	key := fmt.Sprintf("schedule:%d:%d", schedule.ID, schedule.OccurrenceAt.Unix())
+	err := s.walletService.Transfer(WithIdempotencyKey(ctx, key), schedule.SenderID, schedule.RecipientID, schedule.Amount)
+
+	schedule.LastRunAt = &now
+	schedule.Attempts++
+
+	var heldErr *TransferHeldError
+	switch {
+	case err == nil:
+		schedule.LastError = ""
+		schedule.ExecutedCount++
+	case errors.As(err, &heldErr):
+		// The occurrence stays due until the review, which counts or skips
+		// it in settleHeldScheduledTransfer.
+		schedule.LastError = err.Error()
+		schedule.HeldTransferID = &heldErr.HeldTransferID
+		return
+	case errors.Is(err, ErrInsufficientBalance) || errors.Is(err, ErrLimitExceeded):
+		schedule.LastError = err.Error()
+		if schedule.Attempts < MaxScheduleAttempts {
+			schedule.NextRunAt = now.Add(retryBackoff(schedule.Attempts))
+			return
+		}
+		s.logger.WarnContext(ctx, "Skipping occurrence of scheduled transfer",
+			"schedule_id", schedule.ID, "occurrence_at", schedule.OccurrenceAt, "attempts", schedule.Attempts, "error", err)
+	case errors.Is(err, ErrWalletNotFound):
+		schedule.LastError = err.Error()
+		schedule.Status = entity.ScheduleStatusFailed
+		return
+	default:
+		schedule.LastError = err.Error()
+		s.logger.ErrorContext(ctx, "Error executing scheduled transfer", "schedule_id", schedule.ID, "error", err)
+	}
+
+	advance(schedule, now)
+}
+
+// settleHeldScheduledTransfer finishes the occurrence whose transfer was
+// held as held, if any, once a reviewer has released or rejected it. A
+// released transfer counts as executed; a rejected one skips the
+// occurrence. A schedule cancelled in the meantime is only counted.
+func settleHeldScheduledTransfer(ctx context.Context, repo IWalletRepository, held entity.HeldTransfer) error {
+	schedule, err := repo.GetScheduledTransferByHeldTransferID(ctx, held.ID)
+	if err != nil || schedule.ID == 0 {
+		return err
+	}
+
+	schedule.HeldTransferID = nil
+	if held.Status == entity.HeldTransferStatusReleased {
+		schedule.LastError = ""
+		schedule.ExecutedCount++
+	} else {
+		schedule.LastError = fmt.Sprintf("held transfer %d was rejected", held.ID)
+	}
+	if schedule.Status != entity.ScheduleStatusCancelled {
+		advance(&schedule, *held.ReviewedAt)
+	}
+	return repo.UpdateScheduledTransfer(ctx, &schedule)
+}
+
+// advance moves schedule to its next occurrence after now, completing or
+// failing it when there is none.
+func advance(schedule *entity.ScheduledTransfer, now time.Time) {
+	schedule.Attempts = 0
+
+	if schedule.Kind == entity.ScheduleKindOnce {
+		schedule.Status = entity.ScheduleStatusCompleted
+		if schedule.ExecutedCount == 0 {
+			schedule.Status = entity.ScheduleStatusFailed
+		}
+		return
+	}
+
+	next, err := nextOccurrence(*schedule, now)
+	if err != nil {
+		schedule.LastError = err.Error()
+		schedule.Status = entity.ScheduleStatusFailed
+		return
+	}
+	if schedule.EndAt != nil && next.After(*schedule.EndAt) {
+		schedule.Status = entity.ScheduleStatusCompleted
+		return
+	}
+	schedule.OccurrenceAt = next
+	schedule.NextRunAt = next
+}
+
+func firstOccurrence(schedule entity.ScheduledTransfer, startAt time.Time, now time.Time) (time.Time, error) {
+	if schedule.Kind == entity.ScheduleKindOnce {
+		return startAt, nil
+	}
+	if startAt.After(now) {
+		if schedule.Kind == entity.ScheduleKindInterval {
+			return startAt, nil
+		}
+		// Next is exclusive, so step back to allow startAt itself.
+		return nextCron(schedule.CronExpr, startAt.Add(-time.Second))
+	}
+	schedule.OccurrenceAt = now
+	return nextOccurrence(schedule, now)
+}
+
+// nextOccurrence returns the first occurrence after both the current
+// occurrence and now. Occurrences missed while the worker was down are not
+// replayed.
+func nextOccurrence(schedule entity.ScheduledTransfer, now time.Time) (time.Time, error) {
+	switch schedule.Kind {
+	case entity.ScheduleKindInterval:
+		interval := time.Duration(schedule.IntervalSeconds) * time.Second
+		next := schedule.OccurrenceAt.Add(interval)
+		if !next.After(now) {
+			missed := now.Sub(next)/interval + 1
+			next = next.Add(missed * interval)
+		}
+		return next, nil
+	case entity.ScheduleKindCron:
+		from := schedule.OccurrenceAt
+		if now.After(from) {
+			from = now
+		}
+		return nextCron(schedule.CronExpr, from)
+	default:
+		return time.Time{}, fmt.Errorf("schedule kind %s does not recur", schedule.Kind)
+	}
+}
+
+func nextCron(expr string, after time.Time) (time.Time, error) {
+	sched, err := cronParser.Parse(expr)
+	if err != nil {
+		return time.Time{}, err
+	}
+	next := sched.Next(after.UTC())
+	if next.IsZero() {
+		return time.Time{}, fmt.Errorf("cron expression %q has no future occurrence", expr)
+	}
+	return next, nil
+}
+
+func retryBackoff(attempt int) time.Duration {
+	backoff := scheduleRetryBase << (attempt - 1)
+	if backoff > scheduleRetryMax || backoff <= 0 {
+		return scheduleRetryMax
+	}
+	return backoff
+}
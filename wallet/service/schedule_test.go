@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/susilo001/simple-wallet-system/wallet/entity"
+)
+
+func TestExecuteHeldOccurrence(t *testing.T) {
+	now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	wallets := &fakeWalletService{transferErr: &TransferHeldError{HeldTransferID: 7, Score: 60}}
+	s := &scheduledTransferService{walletService: wallets, logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	schedule := entity.ScheduledTransfer{ID: 1, SenderID: 10, RecipientID: 20, Amount: 100, Kind: entity.ScheduleKindOnce, Status: entity.ScheduleStatusActive, OccurrenceAt: now, NextRunAt: now}
+
+	s.execute(context.Background(), &schedule, now)
+
+	if schedule.Status != entity.ScheduleStatusActive || schedule.ExecutedCount != 0 {
+		t.Errorf("schedule = %s with %d executed, want ACTIVE with none executed", schedule.Status, schedule.ExecutedCount)
+	}
+	if schedule.HeldTransferID == nil || *schedule.HeldTransferID != 7 {
+		t.Errorf("held transfer = %v, want 7", schedule.HeldTransferID)
+	}
+	if !schedule.OccurrenceAt.Equal(now) {
+		t.Errorf("occurrence = %v, want it to stay at %v", schedule.OccurrenceAt, now)
+	}
+}
+
+func TestSettleHeldScheduledTransfer(t *testing.T) {
+	occurrence := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	reviewedAt := occurrence.Add(90 * time.Minute)
+	heldTransferID := 7
+	tests := []struct {
+		name             string
+		kind             string
+		status           string
+		heldStatus       string
+		wantStatus       string
+		wantExecuted     int
+		wantOccurrenceAt time.Time
+	}{
+		{name: "released once", kind: entity.ScheduleKindOnce, status: entity.ScheduleStatusActive, heldStatus: entity.HeldTransferStatusReleased, wantStatus: entity.ScheduleStatusCompleted, wantExecuted: 1, wantOccurrenceAt: occurrence},
+		{name: "rejected once", kind: entity.ScheduleKindOnce, status: entity.ScheduleStatusActive, heldStatus: entity.HeldTransferStatusRejected, wantStatus: entity.ScheduleStatusFailed, wantOccurrenceAt: occurrence},
+		{name: "released interval", kind: entity.ScheduleKindInterval, status: entity.ScheduleStatusActive, heldStatus: entity.HeldTransferStatusReleased, wantStatus: entity.ScheduleStatusActive, wantExecuted: 1, wantOccurrenceAt: occurrence.Add(2 * time.Hour)},
+		{name: "rejected interval", kind: entity.ScheduleKindInterval, status: entity.ScheduleStatusActive, heldStatus: entity.HeldTransferStatusRejected, wantStatus: entity.ScheduleStatusActive, wantOccurrenceAt: occurrence.Add(2 * time.Hour)},
+		{name: "released after cancel", kind: entity.ScheduleKindOnce, status: entity.ScheduleStatusCancelled, heldStatus: entity.HeldTransferStatusReleased, wantStatus: entity.ScheduleStatusCancelled, wantExecuted: 1, wantOccurrenceAt: occurrence},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeWalletRepository{schedules: map[int]entity.ScheduledTransfer{
+				1: {ID: 1, Kind: tt.kind, IntervalSeconds: 3600, Status: tt.status, OccurrenceAt: occurrence, NextRunAt: occurrence, Attempts: 1, HeldTransferID: &heldTransferID},
+			}}
+			held := entity.HeldTransfer{ID: heldTransferID, Status: tt.heldStatus, ReviewedAt: &reviewedAt}
+
+			if err := settleHeldScheduledTransfer(context.Background(), repo, held); err != nil {
+				t.Fatalf("settleHeldScheduledTransfer() error = %v", err)
+			}
+			got := repo.schedules[1]
+			if got.Status != tt.wantStatus || got.ExecutedCount != tt.wantExecuted || got.HeldTransferID != nil {
+				t.Errorf("schedule = %s with %d executed, held transfer %v, want %s with %d executed and none held",
+					got.Status, got.ExecutedCount, got.HeldTransferID, tt.wantStatus, tt.wantExecuted)
+			}
+			if !got.OccurrenceAt.Equal(tt.wantOccurrenceAt) {
+				t.Errorf("occurrence = %v, want %v", got.OccurrenceAt, tt.wantOccurrenceAt)
+			}
+			if tt.heldStatus == entity.HeldTransferStatusRejected && got.LastError == "" {
+				t.Error("last error is empty after a rejected occurrence")
+			}
+		})
+	}
+}
@@ -2,37 +2,141 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"time"
 
 	"github.com/susilo001/simple-wallet-system/wallet/entity"
+	"github.com/susilo001/simple-wallet-system/wallet/metrics"
+	"github.com/susilo001/simple-wallet-system/wallet/pagination"
+	"github.com/susilo001/simple-wallet-system/wallet/risk"
 )
 
 type IWalletService interface {
 	CreateWallet(ctx context.Context, wallet *entity.Wallet) (entity.Wallet, error)
 	GetWalletByID(ctx context.Context, id int) (entity.Wallet, error)
-	UpdateWallet(ctx context.Context, id int, wallet entity.Wallet) (entity.Wallet, error)
+	GetWalletsByUserID(ctx context.Context, userID int, page pagination.Page) ([]entity.Wallet, int, error)
 	TopUpWallet(ctx context.Context, walletID int, amount float64) error
 	Transfer(ctx context.Context, senderID int, recipientID int, amount float64) error
-	GetTransactions(ctx context.Context, walletID int) ([]entity.Transaction, error)
+	GetTransactions(ctx context.Context, walletID int, page pagination.Page) ([]entity.Transaction, int, error)
+	SetWalletTier(ctx context.Context, walletID int, tier string) error
+	GetWalletLimits(ctx context.Context, walletID int) (EffectiveLimits, error)
+	SetWalletLimits(ctx context.Context, limit entity.WalletLimit) (entity.WalletLimit, error)
+	WithdrawWallet(ctx context.Context, walletID int, amount float64) error
+	QuoteTransfer(ctx context.Context, senderID int, recipientID int, amount float64) (FeeQuote, error)
+	QuoteWithdrawal(ctx context.Context, walletID int, amount float64) (FeeQuote, error)
+	GetFeeRules(ctx context.Context) ([]entity.FeeRule, error)
+	SaveFeeRule(ctx context.Context, rule entity.FeeRule) (entity.FeeRule, error)
+	BatchTransfer(ctx context.Context, items []BatchTransferItem, atomic bool) (BatchResult, error)
+	GetTransferBatch(ctx context.Context, batchID int) (entity.TransferBatch, []entity.Transaction, error)
+	GetStatement(ctx context.Context, walletID int, from time.Time, to time.Time) (Statement, error)
+	GetBalanceAsOf(ctx context.Context, walletID int, at time.Time) (float64, error)
+	GetBalanceHistory(ctx context.Context, walletID int, from time.Time, to time.Time) (BalanceHistory, error)
+	VerifyBalance(ctx context.Context, walletID int) (BalanceCheck, error)
+	TakeBalanceSnapshots(ctx context.Context, at time.Time) (int, error)
+	RunBalanceSnapshots(ctx context.Context, interval time.Duration)
+	GetBalanceAdjustments(ctx context.Context, status string, page pagination.Page) ([]entity.BalanceAdjustment, int, error)
+	ProposeBalanceAdjustment(ctx context.Context, walletID int, amount float64, reason string, proposerID int) (entity.BalanceAdjustment, error)
+	ReviewBalanceAdjustment(ctx context.Context, id int, approve bool, reviewerID int) (entity.BalanceAdjustment, error)
+	TransferWalletOwnership(ctx context.Context, walletID int, newUserID int) (entity.Wallet, error)
+	FreezeWallet(ctx context.Context, walletID int, status string, reason string) (entity.Wallet, error)
+	UnfreezeWallet(ctx context.Context, walletID int, reason string) (entity.Wallet, error)
+	CloseWallet(ctx context.Context, walletID int, reason string) (entity.Wallet, error)
+	GetHeldTransfers(ctx context.Context, status string, page pagination.Page) ([]entity.HeldTransfer, int, error)
+	ReviewHeldTransfer(ctx context.Context, id int, approve bool, reviewer string) (entity.HeldTransfer, error)
+	GetRiskStats() risk.Stats
 }
 
 type IWalletRepository interface {
+	// Transaction runs fn inside a database transaction. The repository
+	// passed to fn is bound to that transaction; returning an error from fn
+	// rolls everything back.
+	Transaction(ctx context.Context, fn func(repo IWalletRepository) error) error
+
 	CreateWallet(ctx context.Context, wallet *entity.Wallet) (entity.Wallet, error)
 	GetWalletByID(ctx context.Context, id int) (entity.Wallet, error)
-	UpdateWallet(ctx context.Context, id int, wallet entity.Wallet) (entity.Wallet, error)
+	GetWalletForUpdate(ctx context.Context, id int) (entity.Wallet, error)
+	SetWalletOwner(ctx context.Context, id int, userID int) error
+	SetWalletStatus(ctx context.Context, id int, status string, reason string, changedAt time.Time) error
+	UpdateBalance(ctx context.Context, id int, balance float64) error
+	AddToBalance(ctx context.Context, id int, delta float64) error
+	EnsureInternalWallet(ctx context.Context, userID int) (entity.Wallet, error)
+	SetWalletTier(ctx context.Context, id int, tier string) error
 	DeleteWallet(ctx context.Context, id int) error
 	GetAllWallets(ctx context.Context) ([]entity.Wallet, error)
-	TopUpWallet(ctx context.Context, walletID int, amount float64) error
-	Transfer(ctx context.Context, senderID int, recipientID int, amount float64) error
-	GetTransactions(ctx context.Context, walletID int) ([]entity.Transaction, error)
+	GetWalletsByUserID(ctx context.Context, userID int, page pagination.Page) ([]entity.Wallet, int, error)
+	CreateTransaction(ctx context.Context, transaction *entity.Transaction) error
+	GetTransactionByIdempotencyKey(ctx context.Context, key string) (entity.Transaction, error)
+	GetTransactions(ctx context.Context, walletID int, page pagination.Page) ([]entity.Transaction, int, error)
+	// GetTransactionsBetween returns the wallet's transactions created in
+	// [from, to), oldest first.
+	GetTransactionsBetween(ctx context.Context, walletID int, from time.Time, to time.Time) ([]entity.Transaction, error)
+	// GetNetAmountBetween returns credits minus debits of the wallet over
+	// the transactions created in [from, to).
+	GetNetAmountBetween(ctx context.Context, walletID int, from time.Time, to time.Time) (float64, error)
+	// GetLatestSnapshotAtOrBefore returns the most recent balance snapshot
+	// taken at or before t, or a zero snapshot if there is none.
+	GetLatestSnapshotAtOrBefore(ctx context.Context, walletID int, t time.Time) (entity.BalanceSnapshot, error)
+	CreateBalanceSnapshot(ctx context.Context, snapshot *entity.BalanceSnapshot) error
+	CreateBalanceAdjustment(ctx context.Context, adjustment *entity.BalanceAdjustment) error
+	GetBalanceAdjustmentForUpdate(ctx context.Context, id int) (entity.BalanceAdjustment, error)
+	GetBalanceAdjustments(ctx context.Context, status string, page pagination.Page) ([]entity.BalanceAdjustment, int, error)
+	HasPendingAdjustment(ctx context.Context, walletID int, source string) (bool, error)
+	UpdateBalanceAdjustment(ctx context.Context, adjustment *entity.BalanceAdjustment) error
+	SumOutgoing(ctx context.Context, walletID int, since time.Time) (total float64, count int, err error)
+	GetLimitByWalletID(ctx context.Context, walletID int) (entity.WalletLimit, error)
+	GetLimitByTier(ctx context.Context, tier string) (entity.WalletLimit, error)
+	SaveLimit(ctx context.Context, limit *entity.WalletLimit) error
+	GetFeeRules(ctx context.Context) ([]entity.FeeRule, error)
+	SaveFeeRule(ctx context.Context, rule *entity.FeeRule) error
+	CreateTransferBatch(ctx context.Context, batch *entity.TransferBatch) error
+	UpdateTransferBatch(ctx context.Context, batch *entity.TransferBatch) error
+	GetTransferBatchByID(ctx context.Context, id int) (entity.TransferBatch, error)
+	GetTransactionsByBatchID(ctx context.Context, batchID int) ([]entity.Transaction, error)
+	CountTransfersTo(ctx context.Context, senderID int, recipientID int) (int, error)
+	// GetTransferStats returns the number and average amount of the
+	// transfers the wallet has sent since since.
+	GetTransferStats(ctx context.Context, walletID int, since time.Time) (int, float64, error)
+	CreateHeldTransfer(ctx context.Context, held *entity.HeldTransfer) error
+	GetPendingHeldTransferByKey(ctx context.Context, key string) (entity.HeldTransfer, error)
+	GetHeldTransferByID(ctx context.Context, id int) (entity.HeldTransfer, error)
+	GetHeldTransferForUpdate(ctx context.Context, id int) (entity.HeldTransfer, error)
+	GetHeldTransfers(ctx context.Context, status string, page pagination.Page) ([]entity.HeldTransfer, int, error)
+	UpdateHeldTransfer(ctx context.Context, held *entity.HeldTransfer) error
+	// GetPaymentRequestByHeldTransferID returns the payment request waiting
+	// for the held transfer, or a zero request if there is none.
+	GetPaymentRequestByHeldTransferID(ctx context.Context, heldTransferID int) (entity.PaymentRequest, error)
+	UpdatePaymentRequestStatus(ctx context.Context, request *entity.PaymentRequest, from string) (bool, error)
+	// GetScheduledTransferByHeldTransferID returns the scheduled transfer
+	// waiting for the held transfer, or a zero schedule if there is none.
+	GetScheduledTransferByHeldTransferID(ctx context.Context, heldTransferID int) (entity.ScheduledTransfer, error)
+	UpdateScheduledTransfer(ctx context.Context, schedule *entity.ScheduledTransfer) error
 }
 
 type walletService struct {
-	walletRepo IWalletRepository
+	walletRepo    IWalletRepository
+	houseWalletID int
+	riskEngine    *risk.Engine
+	kycLevels     KYCLevels
+	kycLimits     map[string]entity.WalletLimit
+	logger        *slog.Logger
 }
 
-func NewWalletService(walletRepo IWalletRepository) IWalletService {
-	return &walletService{walletRepo: walletRepo}
+// NewWalletService creates the wallet service. Fees are credited to the
+// wallet identified by houseWalletID. Transfers are assessed by riskEngine
+// before any money moves; a nil engine disables risk checks. The limits of
+// every customer wallet are capped by kycLimits for its owner's level as
+// reported by kycLevels; nil kycLevels disables the caps.
+func NewWalletService(walletRepo IWalletRepository, houseWalletID int, riskEngine *risk.Engine, kycLevels KYCLevels, kycLimits map[string]entity.WalletLimit, logger *slog.Logger) IWalletService {
+	return &walletService{
+		walletRepo:    walletRepo,
+		houseWalletID: houseWalletID,
+		riskEngine:    riskEngine,
+		kycLevels:     kycLevels,
+		kycLimits:     kycLimits,
+		logger:        logger,
+	}
 }
 
 func (s *walletService) CreateWallet(ctx context.Context, wallet *entity.Wallet) (entity.Wallet, error) {
@@ -51,36 +155,131 @@ func (s *walletService) GetWalletByID(ctx context.Context, id int) (entity.Walle
 	return wallet, nil
 }
 
-func (s *walletService) UpdateWallet(ctx context.Context, id int, wallet entity.Wallet) (entity.Wallet, error) {
-	updatedWallet, err := s.walletRepo.UpdateWallet(ctx, id, wallet)
+func (s *walletService) TopUpWallet(ctx context.Context, walletID int, amount float64) error {
+	if amount <= 0 {
+		return fmt.Errorf("failed to top up wallet: %w", ErrInvalidAmount)
+	}
+	levels, err := s.lookupKYCLevels(ctx, walletID)
 	if err != nil {
-		return entity.Wallet{}, fmt.Errorf("failed to update wallet: %v", err)
+		return fmt.Errorf("failed to top up wallet: %w", err)
 	}
-	return updatedWallet, nil
-}
 
-func (s *walletService) TopUpWallet(ctx context.Context, walletID int, amount float64) error {
-	err := s.walletRepo.TopUpWallet(ctx, walletID, amount)
+	err = s.walletRepo.Transaction(ctx, func(repo IWalletRepository) error {
+		wallet, err := repo.GetWalletForUpdate(ctx, walletID)
+		if err != nil {
+			return err
+		}
+		if err := checkCanCredit(wallet); err != nil {
+			return err
+		}
+
+		limits, err := s.resolveLimits(ctx, repo, wallet, levels)
+		if err != nil {
+			return err
+		}
+		if err := checkMaxBalance(wallet, limits.Limit, amount); err != nil {
+			return err
+		}
+
+		if err := repo.UpdateBalance(ctx, wallet.ID, wallet.Balance+amount); err != nil {
+			return err
+		}
+		return repo.CreateTransaction(ctx, &entity.Transaction{
+			Type:        entity.TransactionTypeTopUp,
+			RecipientID: wallet.ID,
+			Amount:      amount,
+		})
+	})
 	if err != nil {
-		return fmt.Errorf("failed to top up wallet: %v", err)
+		return fmt.Errorf("failed to top up wallet: %w", err)
 	}
+	metrics.TopUpCompleted(amount)
 	return nil
 }
 
 func (s *walletService) Transfer(ctx context.Context, senderID int, recipientID int, amount float64) error {
-	err := s.walletRepo.Transfer(ctx, senderID, recipientID, amount)
+	if amount <= 0 {
+		return fmt.Errorf("failed to transfer amount: %w", ErrInvalidAmount)
+	}
+	if senderID == recipientID {
+		return fmt.Errorf("failed to transfer amount: %w", ErrSameWallet)
+	}
+	if err := s.assessTransfer(ctx, senderID, recipientID, amount, idempotencyKeyFrom(ctx), true); err != nil {
+		return fmt.Errorf("failed to transfer amount: %w", err)
+	}
+	levels, err := s.lookupKYCLevels(ctx, senderID, recipientID)
+	if err != nil {
+		return fmt.Errorf("failed to transfer amount: %w", err)
+	}
+
+	err = s.walletRepo.Transaction(ctx, func(repo IWalletRepository) error {
+		_, err := s.applyTransfer(ctx, repo, senderID, recipientID, amount, idempotencyKeyFrom(ctx), 0, levels)
+		return err
+	})
+	recordTransfer(metrics.TransferSingle, amount, err)
+	if err != nil {
+		return fmt.Errorf("failed to transfer amount: %w", err)
+	}
+	return nil
+}
+
+func (s *walletService) WithdrawWallet(ctx context.Context, walletID int, amount float64) error {
+	if amount <= 0 {
+		return fmt.Errorf("failed to withdraw from wallet: %w", ErrInvalidAmount)
+	}
+	levels, err := s.lookupKYCLevels(ctx, walletID)
+	if err != nil {
+		return fmt.Errorf("failed to withdraw from wallet: %w", err)
+	}
+
+	err = s.walletRepo.Transaction(ctx, func(repo IWalletRepository) error {
+		wallet, err := repo.GetWalletForUpdate(ctx, walletID)
+		if err != nil {
+			return err
+		}
+		if err := checkCanDebit(wallet); err != nil {
+			return err
+		}
+
+		quote, err := s.quoteFee(ctx, repo, entity.FeeOperationWithdrawal, wallet, nil, amount)
+		if err != nil {
+			return err
+		}
+		if wallet.Balance < quote.TotalDebit {
+			return ErrInsufficientBalance
+		}
+		if err := s.checkOutgoingLimits(ctx, repo, wallet, amount, levels); err != nil {
+			return err
+		}
+
+		if err := repo.UpdateBalance(ctx, wallet.ID, wallet.Balance-quote.TotalDebit); err != nil {
+			return err
+		}
+		transaction := entity.Transaction{
+			Type:     entity.TransactionTypeWithdrawal,
+			SenderID: wallet.ID,
+			Amount:   amount,
+		}
+		if err := repo.CreateTransaction(ctx, &transaction); err != nil {
+			return err
+		}
+		return s.postFee(ctx, repo, wallet.ID, quote, transaction.ID)
+	})
+	if errors.Is(err, ErrInsufficientBalance) {
+		metrics.InsufficientBalance("withdrawal")
+	}
 	if err != nil {
-		return fmt.Errorf("failed to transfer amount: %v", err)
+		return fmt.Errorf("failed to withdraw from wallet: %w", err)
 	}
 	return nil
 }
 
-func (s *walletService) GetTransactions(ctx context.Context, walletID int) ([]entity.Transaction, error) {
-	transactions, err := s.walletRepo.GetTransactions(ctx, walletID)
+func (s *walletService) GetTransactions(ctx context.Context, walletID int, page pagination.Page) ([]entity.Transaction, int, error) {
+	transactions, total, err := s.walletRepo.GetTransactions(ctx, walletID, page)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get transactions: %v", err)
+		return nil, 0, fmt.Errorf("failed to get transactions: %v", err)
 	}
-	return transactions, nil
+	return transactions, total, nil
 }
 
 func (s *walletService) DeleteWallet(ctx context.Context, id int) error {
@@ -91,6 +290,14 @@ func (s *walletService) DeleteWallet(ctx context.Context, id int) error {
 	return nil
 }
 
+func (s *walletService) GetWalletsByUserID(ctx context.Context, userID int, page pagination.Page) ([]entity.Wallet, int, error) {
+	wallets, total, err := s.walletRepo.GetWalletsByUserID(ctx, userID, page)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get wallets of user: %v", err)
+	}
+	return wallets, total, nil
+}
+
 func (s *walletService) GetAllWallets(ctx context.Context) ([]entity.Wallet, error) {
 	wallets, err := s.walletRepo.GetAllWallets(ctx)
 	if err != nil {
@@ -98,3 +305,110 @@ func (s *walletService) GetAllWallets(ctx context.Context) ([]entity.Wallet, err
 	}
 	return wallets, nil
 }
+
+// applyTransfer moves amount plus fees from sender to recipient inside the
+// database transaction of repo and returns the TRANSFER transaction. When
+// key was already used, nothing is moved and the earlier transaction is
+// returned. levels must hold the KYC levels of both wallets' owners, looked
+// up before the transaction was opened.
+func (s *walletService) applyTransfer(ctx context.Context, repo IWalletRepository, senderID int, recipientID int, amount float64, key *string, batchID int, levels kycLevelsByUser) (entity.Transaction, error) {
+	if amount <= 0 {
+		return entity.Transaction{}, ErrInvalidAmount
+	}
+	if senderID == recipientID {
+		return entity.Transaction{}, ErrSameWallet
+	}
+
+	sender, recipient, err := lockWalletPair(ctx, repo, senderID, recipientID)
+	if err != nil {
+		return entity.Transaction{}, err
+	}
+
+	// Checked after locking so that a concurrent retry with the same key
+	// waits for the first attempt to commit.
+	if applied, err := findApplied(ctx, repo, key); err != nil || applied.ID != 0 {
+		return applied, err
+	}
+
+	if err := checkCanDebit(sender); err != nil {
+		return entity.Transaction{}, err
+	}
+	if err := checkCanCredit(recipient); err != nil {
+		return entity.Transaction{}, err
+	}
+
+	quote, err := s.quoteFee(ctx, repo, entity.FeeOperationTransfer, sender, &recipient, amount)
+	if err != nil {
+		return entity.Transaction{}, err
+	}
+	if sender.Balance < quote.TotalDebit {
+		return entity.Transaction{}, ErrInsufficientBalance
+	}
+
+	if err := s.checkOutgoingLimits(ctx, repo, sender, amount, levels); err != nil {
+		return entity.Transaction{}, err
+	}
+	recipientLimits, err := s.resolveLimits(ctx, repo, recipient, levels)
+	if err != nil {
+		return entity.Transaction{}, err
+	}
+	if err := checkMaxBalance(recipient, recipientLimits.Limit, amount); err != nil {
+		return entity.Transaction{}, err
+	}
+
+	if err := repo.UpdateBalance(ctx, sender.ID, sender.Balance-quote.TotalDebit); err != nil {
+		return entity.Transaction{}, err
+	}
+	if err := repo.UpdateBalance(ctx, recipient.ID, recipient.Balance+amount); err != nil {
+		return entity.Transaction{}, err
+	}
+	transaction := entity.Transaction{
+		Type:           entity.TransactionTypeTransfer,
+		SenderID:       sender.ID,
+		RecipientID:    recipient.ID,
+		Amount:         amount,
+		BatchID:        batchID,
+		IdempotencyKey: key,
+	}
+	if err := repo.CreateTransaction(ctx, &transaction); err != nil {
+		return entity.Transaction{}, err
+	}
+	if err := s.postFee(ctx, repo, sender.ID, quote, transaction.ID); err != nil {
+		return entity.Transaction{}, err
+	}
+	return transaction, nil
+}
+
+// recordTransfer reports the outcome of a transfer of the given kind to the
+// metrics once its transaction has ended.
+func recordTransfer(kind string, amount float64, err error) {
+	switch {
+	case err == nil:
+		metrics.TransferCompleted(kind, amount)
+	case errors.Is(err, ErrInsufficientBalance):
+		metrics.InsufficientBalance("transfer")
+	}
+}
+
+// lockWalletPair locks both wallets of a transfer for update, always in
+// ascending ID order so that two opposite transfers cannot deadlock.
+func lockWalletPair(ctx context.Context, repo IWalletRepository, senderID int, recipientID int) (entity.Wallet, entity.Wallet, error) {
+	firstID, secondID := senderID, recipientID
+	if firstID > secondID {
+		firstID, secondID = secondID, firstID
+	}
+
+	first, err := repo.GetWalletForUpdate(ctx, firstID)
+	if err != nil {
+		return entity.Wallet{}, entity.Wallet{}, err
+	}
+	second, err := repo.GetWalletForUpdate(ctx, secondID)
+	if err != nil {
+		return entity.Wallet{}, entity.Wallet{}, err
+	}
+
+	if first.ID == senderID {
+		return first, second, nil
+	}
+	return second, first, nil
+}
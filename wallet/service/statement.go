@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/susilo001/simple-wallet-system/wallet/entity"
+)
+
+// MaxStatementPeriod caps the length of one statement.
+const MaxStatementPeriod = 366 * 24 * time.Hour
+
+// Statement lists the movements of a wallet in [From, To) with the balance
+// before, after and between them.
+type Statement struct {
+	Wallet         entity.Wallet
+	From           time.Time
+	To             time.Time
+	OpeningBalance float64
+	Lines          []StatementLine
+	TotalCredits   float64
+	TotalDebits    float64
+	ClosingBalance float64
+	GeneratedAt    time.Time
+}
+
+type StatementLine struct {
+	Transaction entity.Transaction
+	// Counterparty is the other wallet of the movement, or zero for
+	// top-ups and withdrawals.
+	Counterparty   int
+	Credit         float64
+	Debit          float64
+	RunningBalance float64
+}
+
+// GetStatement builds the statement of a wallet for [from, to). Balances
+// are derived from the transaction history, not from the stored balance.
+func (s *walletService) GetStatement(ctx context.Context, walletID int, from time.Time, to time.Time) (Statement, error) {
+	from, to = from.UTC(), to.UTC()
+	if !to.After(from) {
+		return Statement{}, fmt.Errorf("failed to get statement: %w: period end must be after its start", ErrInvalidPeriod)
+	}
+	if to.Sub(from) > MaxStatementPeriod {
+		return Statement{}, fmt.Errorf("failed to get statement: %w: period must not exceed %s", ErrInvalidPeriod, MaxStatementPeriod)
+	}
+
+	wallet, err := s.getExistingWallet(ctx, walletID)
+	if err != nil {
+		return Statement{}, fmt.Errorf("failed to get statement: %w", err)
+	}
+
+	opening, err := balanceAt(ctx, s.walletRepo, walletID, from)
+	if err != nil {
+		return Statement{}, fmt.Errorf("failed to get statement: %w", err)
+	}
+	transactions, err := s.walletRepo.GetTransactionsBetween(ctx, walletID, from, to)
+	if err != nil {
+		return Statement{}, fmt.Errorf("failed to get statement: %w", err)
+	}
+
+	statement := Statement{
+		Wallet:         wallet,
+		From:           from,
+		To:             to,
+		OpeningBalance: roundCents(opening),
+		GeneratedAt:    time.Now().UTC(),
+	}
+	balance := statement.OpeningBalance
+	for _, transaction := range transactions {
+		line := StatementLine{Transaction: transaction}
+		if transaction.RecipientID == walletID {
+			line.Credit = transaction.Amount
+			line.Counterparty = transaction.SenderID
+		}
+		if transaction.SenderID == walletID {
+			line.Debit = transaction.Amount
+			line.Counterparty = transaction.RecipientID
+		}
+		balance = roundCents(balance + line.Credit - line.Debit)
+		line.RunningBalance = balance
+
+		statement.TotalCredits += line.Credit
+		statement.TotalDebits += line.Debit
+		statement.Lines = append(statement.Lines, line)
+	}
+	statement.TotalCredits = roundCents(statement.TotalCredits)
+	statement.TotalDebits = roundCents(statement.TotalDebits)
+	statement.ClosingBalance = balance
+	return statement, nil
+}
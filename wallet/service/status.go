@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/susilo001/simple-wallet-system/wallet/entity"
+)
+
+// walletStatusTransitions lists the statuses each status may change to.
+// CLOSED is final.
+var walletStatusTransitions = map[string][]string{
+	entity.WalletStatusActive:      {entity.WalletStatusFrozenDebit, entity.WalletStatusFrozenAll, entity.WalletStatusClosed},
+	entity.WalletStatusFrozenDebit: {entity.WalletStatusActive, entity.WalletStatusFrozenAll, entity.WalletStatusClosed},
+	entity.WalletStatusFrozenAll:   {entity.WalletStatusActive, entity.WalletStatusFrozenDebit, entity.WalletStatusClosed},
+}
+
+// FreezeWallet stops money leaving the wallet (FROZEN_DEBIT) or moving in
+// either direction (FROZEN_ALL).
+func (s *walletService) FreezeWallet(ctx context.Context, walletID int, status string, reason string) (entity.Wallet, error) {
+	if status != entity.WalletStatusFrozenDebit && status != entity.WalletStatusFrozenAll {
+		return entity.Wallet{}, fmt.Errorf("failed to freeze wallet: %w: %q is not a frozen status", ErrInvalidWalletStatus, status)
+	}
+	wallet, err := s.changeWalletStatus(ctx, walletID, status, reason)
+	if err != nil {
+		return entity.Wallet{}, fmt.Errorf("failed to freeze wallet: %w", err)
+	}
+	return wallet, nil
+}
+
+func (s *walletService) UnfreezeWallet(ctx context.Context, walletID int, reason string) (entity.Wallet, error) {
+	wallet, err := s.changeWalletStatus(ctx, walletID, entity.WalletStatusActive, reason)
+	if err != nil {
+		return entity.Wallet{}, fmt.Errorf("failed to unfreeze wallet: %w", err)
+	}
+	return wallet, nil
+}
+
+// CloseWallet closes a wallet for good. Its balance must be zero, so that
+// no money is stranded in it.
+func (s *walletService) CloseWallet(ctx context.Context, walletID int, reason string) (entity.Wallet, error) {
+	wallet, err := s.changeWalletStatus(ctx, walletID, entity.WalletStatusClosed, reason)
+	if err != nil {
+		return entity.Wallet{}, fmt.Errorf("failed to close wallet: %w", err)
+	}
+	return wallet, nil
+}
+
+func (s *walletService) changeWalletStatus(ctx context.Context, walletID int, to string, reason string) (entity.Wallet, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return entity.Wallet{}, fmt.Errorf("%w: a reason is required", ErrInvalidWalletStatus)
+	}
+
+	var wallet entity.Wallet
+	err := s.walletRepo.Transaction(ctx, func(repo IWalletRepository) error {
+		var err error
+		wallet, err = repo.GetWalletForUpdate(ctx, walletID)
+		if err != nil {
+			return err
+		}
+		if wallet.Internal {
+			return fmt.Errorf("%w: wallet %d is internal", ErrWalletStatusChange, wallet.ID)
+		}
+
+		allowed := false
+		for _, status := range walletStatusTransitions[walletStatus(wallet)] {
+			allowed = allowed || status == to
+		}
+		if !allowed {
+			return fmt.Errorf("%w: %s to %s", ErrWalletStatusChange, walletStatus(wallet), to)
+		}
+		if to == entity.WalletStatusClosed && wallet.Balance != 0 {
+			return fmt.Errorf("%w: wallet %d still holds %.2f", ErrWalletStatusChange, wallet.ID, wallet.Balance)
+		}
+
+		now := time.Now().UTC()
+		if err := repo.SetWalletStatus(ctx, wallet.ID, to, reason, now); err != nil {
+			return err
+		}
+		wallet.Status = to
+		wallet.StatusReason = reason
+		wallet.StatusChangedAt = &now
+		return nil
+	})
+	if err != nil {
+		return entity.Wallet{}, err
+	}
+	return wallet, nil
+}
+
+// checkCanDebit rejects taking money out of a wallet that is frozen or
+// closed.
+func checkCanDebit(wallet entity.Wallet) error {
+	switch walletStatus(wallet) {
+	case entity.WalletStatusFrozenDebit, entity.WalletStatusFrozenAll:
+		return fmt.Errorf("wallet %d: %w", wallet.ID, ErrWalletFrozen)
+	case entity.WalletStatusClosed:
+		return fmt.Errorf("wallet %d: %w", wallet.ID, ErrWalletClosed)
+	}
+	return nil
+}
+
+// checkCanCredit rejects paying money into a wallet that is frozen for all
+// movements or closed.
+func checkCanCredit(wallet entity.Wallet) error {
+	switch walletStatus(wallet) {
+	case entity.WalletStatusFrozenAll:
+		return fmt.Errorf("wallet %d: %w", wallet.ID, ErrWalletFrozen)
+	case entity.WalletStatusClosed:
+		return fmt.Errorf("wallet %d: %w", wallet.ID, ErrWalletClosed)
+	}
+	return nil
+}
+
+// walletStatus treats a wallet without a status as active.
+func walletStatus(wallet entity.Wallet) string {
+	if wallet.Status == "" {
+		return entity.WalletStatusActive
+	}
+	return wallet.Status
+}
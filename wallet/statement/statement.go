@@ -0,0 +1,185 @@
+// Package statement renders wallet statements as CSV and PDF documents.
+package statement
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/susilo001/simple-wallet-system/wallet/entity"
+	"github.com/susilo001/simple-wallet-system/wallet/service"
+)
+
+const (
+	FormatCSV = "csv"
+	FormatPDF = "pdf"
+)
+
+const dateLayout = "2006-01-02"
+
+// Render writes s to w in the given format and returns the content type of
+// the document.
+func Render(w io.Writer, s service.Statement, format string) (string, error) {
+	switch format {
+	case FormatCSV:
+		return "text/csv", CSV(w, s)
+	case FormatPDF:
+		return "application/pdf", PDF(w, s)
+	default:
+		return "", fmt.Errorf("unknown statement format %q", format)
+	}
+}
+
+// Filename returns the suggested download name of a statement document.
+func Filename(s service.Statement, format string) string {
+	return fmt.Sprintf("statement-wallet-%d-%s-%s.%s", s.Wallet.ID,
+		s.From.Format(dateLayout), s.To.Format(dateLayout), format)
+}
+
+// CSV writes one row per transaction, framed by opening and closing balance
+// rows, so that the file can be loaded as a single table.
+func CSV(w io.Writer, s service.Statement) error {
+	cw := csv.NewWriter(w)
+	rows := [][]string{
+		{"date", "transaction_id", "type", "description", "debit", "credit", "balance"},
+		{s.From.Format(time.RFC3339), "", "", "Opening balance", "", "", money(s.OpeningBalance)},
+	}
+	for _, line := range s.Lines {
+		rows = append(rows, []string{
+			line.Transaction.CreatedAt.UTC().Format(time.RFC3339),
+			strconv.Itoa(line.Transaction.ID),
+			line.Transaction.Type,
+			describe(line),
+			optionalMoney(line.Debit),
+			optionalMoney(line.Credit),
+			money(line.RunningBalance),
+		})
+	}
+	rows = append(rows,
+		[]string{s.To.Format(time.RFC3339), "", "", "Totals", money(s.TotalDebits), money(s.TotalCredits), ""},
+		[]string{s.To.Format(time.RFC3339), "", "", "Closing balance", "", "", money(s.ClosingBalance)},
+	)
+
+	if err := cw.WriteAll(rows); err != nil {
+		return fmt.Errorf("failed to write statement CSV: %w", err)
+	}
+	return nil
+}
+
+// PDF writes an A4 statement with a summary block followed by the itemized
+// transactions. The table header is repeated on every page.
+func PDF(w io.Writer, s service.Statement) error {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle(fmt.Sprintf("Statement for wallet %d", s.Wallet.ID), true)
+	pdf.SetAutoPageBreak(true, 15)
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-12)
+		pdf.SetFont("Helvetica", "", 8)
+		pdf.CellFormat(0, 5, fmt.Sprintf("Page %d of {nb}", pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+	pdf.AliasNbPages("{nb}")
+
+	columns := []struct {
+		title string
+		width float64
+		align string
+	}{
+		{"Date", 34, "L"},
+		{"ID", 14, "R"},
+		{"Type", 24, "L"},
+		{"Description", 46, "L"},
+		{"Debit", 24, "R"},
+		{"Credit", 24, "R"},
+		{"Balance", 24, "R"},
+	}
+	tableHeader := func() {
+		pdf.SetFont("Helvetica", "B", 9)
+		pdf.SetFillColor(230, 230, 230)
+		for _, col := range columns {
+			pdf.CellFormat(col.width, 7, col.title, "1", 0, col.align, true, 0, "")
+		}
+		pdf.Ln(-1)
+		pdf.SetFont("Helvetica", "", 9)
+	}
+	pdf.SetHeaderFunc(func() {
+		if pdf.PageNo() > 1 {
+			tableHeader()
+		}
+	})
+
+	pdf.AddPage()
+	pdf.SetFont("Helvetica", "B", 16)
+	pdf.CellFormat(0, 10, "Wallet Statement", "", 1, "L", false, 0, "")
+
+	pdf.SetFont("Helvetica", "", 10)
+	summary := [][2]string{
+		{"Wallet ID", strconv.Itoa(s.Wallet.ID)},
+		{"User ID", strconv.Itoa(s.Wallet.UserID)},
+		{"Period", fmt.Sprintf("%s to %s (UTC, end exclusive)", s.From.Format(dateLayout), s.To.Format(dateLayout))},
+		{"Opening balance", money(s.OpeningBalance)},
+		{"Total credits", money(s.TotalCredits)},
+		{"Total debits", money(s.TotalDebits)},
+		{"Closing balance", money(s.ClosingBalance)},
+		{"Generated at", s.GeneratedAt.Format(time.RFC3339)},
+	}
+	for _, row := range summary {
+		pdf.CellFormat(40, 6, row[0], "", 0, "L", false, 0, "")
+		pdf.CellFormat(0, 6, row[1], "", 1, "L", false, 0, "")
+	}
+	pdf.Ln(4)
+
+	tableHeader()
+	if len(s.Lines) == 0 {
+		pdf.CellFormat(0, 7, "No transactions in this period.", "1", 1, "C", false, 0, "")
+	}
+	for _, line := range s.Lines {
+		cells := []string{
+			line.Transaction.CreatedAt.UTC().Format("2006-01-02 15:04:05"),
+			strconv.Itoa(line.Transaction.ID),
+			line.Transaction.Type,
+			describe(line),
+			optionalMoney(line.Debit),
+			optionalMoney(line.Credit),
+			money(line.RunningBalance),
+		}
+		for i, col := range columns {
+			pdf.CellFormat(col.width, 6, cells[i], "1", 0, col.align, false, 0, "")
+		}
+		pdf.Ln(-1)
+	}
+
+	if err := pdf.Output(w); err != nil {
+		return fmt.Errorf("failed to write statement PDF: %w", err)
+	}
+	return nil
+}
+
+func describe(line service.StatementLine) string {
+	t := line.Transaction
+	switch {
+	case t.Type == entity.TransactionTypeTopUp:
+		return "Top-up"
+	case t.Type == entity.TransactionTypeWithdrawal:
+		return "Withdrawal"
+	case t.Type == entity.TransactionTypeFee && line.Debit > 0:
+		return fmt.Sprintf("Fee for transaction %d", t.RelatedID)
+	case line.Debit > 0:
+		return fmt.Sprintf("To wallet %d", line.Counterparty)
+	default:
+		return fmt.Sprintf("From wallet %d", line.Counterparty)
+	}
+}
+
+func money(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
+
+func optionalMoney(v float64) string {
+	if v == 0 {
+		return ""
+	}
+	return money(v)
+}
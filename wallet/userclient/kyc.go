@@ -0,0 +1,46 @@
+// Package userclient gives the wallet service access to the user service.
+package userclient
+
+import (
+	"context"
+	"encoding/base64"
+
+	userpb "github.com/susilo001/simple-wallet-system/user/proto/user/v1"
+	"github.com/susilo001/simple-wallet-system/wallet/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// unverifiedLevel is the KYC level of a user who was never verified.
+const unverifiedLevel = "none"
+
+// KYCLevels looks up KYC levels through the user service's GetKYCLevel
+// RPC.
+type KYCLevels struct {
+	client   userpb.UserServiceClient
+	username string
+	password string
+}
+
+// NewKYCLevels authenticates every call with the user service's basic
+// credentials username and password.
+func NewKYCLevels(conn grpc.ClientConnInterface, username string, password string) *KYCLevels {
+	return &KYCLevels{client: userpb.NewUserServiceClient(conn), username: username, password: password}
+}
+
+// GetKYCLevel treats a user the user service does not know, e.g. one that
+// was deleted while keeping their wallet, as unverified.
+func (k *KYCLevels) GetKYCLevel(ctx context.Context, userID int) (string, error) {
+	auth := base64.StdEncoding.EncodeToString([]byte(k.username + ":" + k.password))
+	ctx = logging.OutgoingContext(metadata.NewOutgoingContext(ctx, metadata.Pairs("authorization", "Basic "+auth)))
+	resp, err := k.client.GetKYCLevel(ctx, &userpb.GetKYCLevelRequest{UserId: int32(userID)})
+	if status.Code(err) == codes.NotFound {
+		return unverifiedLevel, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return resp.GetLevel(), nil
+}